@@ -25,3 +25,41 @@ func ToPBUser(u *models.User) *pb.User {
 		UpdatedAt:   timestamppb.New(u.UpdatedAt),
 	}
 }
+
+// ToPBSession converts domain UserSession to protobuf Session.
+func ToPBSession(s models.UserSession) *pb.Session {
+	session := &pb.Session{
+		Id:         s.ID,
+		DeviceName: s.DeviceName,
+		DeviceId:   s.DeviceID,
+		DeviceOs:   s.DeviceOS,
+		OsVersion:  s.OSVersion,
+		AppType:    s.AppType,
+		AppVersion: s.AppVersion,
+		IpAddress:  s.IPAddress,
+		IsCurrent:  s.IsCurrent,
+		IsTrusted:  s.IsTrusted,
+		LastActive: timestamppb.New(s.LastActive),
+		CreatedAt:  timestamppb.New(s.CreatedAt),
+	}
+	if s.TrustedUntil != nil {
+		session.TrustedUntil = timestamppb.New(*s.TrustedUntil)
+	}
+	return session
+}
+
+// ToPBLoginLockout converts domain LoginLockout to protobuf LoginLockout.
+func ToPBLoginLockout(l models.LoginLockout) *pb.LoginLockout {
+	lockout := &pb.LoginLockout{
+		UserId:         l.UserID,
+		Phone:          l.Phone,
+		FullName:       l.FullName,
+		FailedAttempts: int32(l.FailedAttempts),
+		LastMethod:     l.LastMethod,
+		LastFailedAt:   timestamppb.New(l.LastFailedAt),
+	}
+	if l.LockedUntil != nil {
+		lockout.LockedUntil = timestamppb.New(*l.LockedUntil)
+	}
+	return lockout
+}
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"math"
+
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToPBCartItem(item models.CartItem) *pb.CartItem {
+	return &pb.CartItem{
+		Id:           item.ID,
+		ProductId:    item.ProductID,
+		ShopId:       item.ShopID,
+		ProductName:  item.ProductName,
+		ProductImage: item.ProductImage,
+		VariantName:  item.VariantName,
+		VariantValue: item.VariantValue,
+		Quantity:     int32(item.Quantity),
+		Price:        item.Price,
+		AddedPrice:   item.AddedPrice,
+		PriceChanged: item.PriceChanged(),
+		Available:    item.Available,
+		LineTotal:    item.LineTotal(),
+		CreatedAt:    timestamppb.New(item.CreatedAt),
+	}
+}
+
+// ToPBCart maps a cart and computes its totals over the available items.
+func ToPBCart(cart models.Cart) *pb.Cart {
+	pbCart := &pb.Cart{Items: []*pb.CartItem{}}
+	var subtotal float64
+	for _, item := range cart.Items {
+		pbItem := ToPBCartItem(item)
+		pbCart.Items = append(pbCart.Items, pbItem)
+		if !item.Available {
+			pbCart.HasUnavailableItems = true
+			continue
+		}
+		pbCart.ItemsCount += int32(item.Quantity)
+		pbCart.HasPriceChanges = pbCart.HasPriceChanges || pbItem.PriceChanged
+		subtotal += pbItem.LineTotal
+	}
+	pbCart.Subtotal = math.Round(subtotal*100) / 100
+	return pbCart
+}
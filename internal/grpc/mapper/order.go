@@ -51,6 +51,8 @@ func ToPBOrderItem(item models.OrderItem) *pb.OrderItem {
 		ProductImage: item.ProductImage,
 		Quantity:     int32(item.Quantity),
 		Price:        item.Price,
+		VariantName:  item.VariantName,
+		VariantValue: item.VariantValue,
 		CreatedAt:    timestamppb.New(item.CreatedAt),
 	}
 	if item.ProductID != nil {
@@ -61,26 +63,57 @@ func ToPBOrderItem(item models.OrderItem) *pb.OrderItem {
 
 func ToPBOrder(order models.Order) *pb.Order {
 	pbOrder := &pb.Order{
-		Id:            order.ID,
-		ShopId:        order.ShopID,
-		ShopName:      order.ShopName,
-		ClientName:    order.ClientName,
-		ClientPhone:   order.ClientPhone,
-		ClientAddress: order.ClientAddress,
-		TotalAmount:   order.TotalAmount,
-		DeliveryPrice: order.DeliveryPrice,
-		Status:        ToPBOrderStatus(order.Status),
-		ClientNote:    order.ClientNote,
-		SellerNote:    order.SellerNote,
-		ItemsCount:    int32(order.ItemsCount),
-		CreatedAt:     timestamppb.New(order.CreatedAt),
-		UpdatedAt:     timestamppb.New(order.UpdatedAt),
+		Id:                 order.ID,
+		ShopId:             order.ShopID,
+		ShopName:           order.ShopName,
+		ClientName:         order.ClientName,
+		ClientPhone:        order.ClientPhone,
+		ClientAddress:      order.ClientAddress,
+		TotalAmount:        order.TotalAmount,
+		DeliveryPrice:      order.DeliveryPrice,
+		Status:             ToPBOrderStatus(order.Status),
+		ClientNote:         order.ClientNote,
+		SellerNote:         order.SellerNote,
+		CancellationReason: order.CancellationReason,
+		RejectionNote:      order.RejectionNote,
+		ItemsCount:         int32(order.ItemsCount),
+		CreatedAt:          timestamppb.New(order.CreatedAt),
+		UpdatedAt:          timestamppb.New(order.UpdatedAt),
+	}
+	if order.UserID != nil {
+		pbOrder.UserId = *order.UserID
+	}
+	if order.CheckoutID != nil {
+		pbOrder.CheckoutId = *order.CheckoutID
 	}
 	if order.CompletedAt != nil {
 		pbOrder.CompletedAt = timestamppb.New(*order.CompletedAt)
 	}
+	if order.ConfirmedAt != nil {
+		pbOrder.ConfirmedAt = timestamppb.New(*order.ConfirmedAt)
+	}
 	for _, item := range order.Items {
 		pbOrder.Items = append(pbOrder.Items, ToPBOrderItem(item))
 	}
+	for _, change := range order.History {
+		pbOrder.History = append(pbOrder.History, ToPBOrderStatusChange(change))
+	}
 	return pbOrder
 }
+
+func ToPBOrderStatusChange(change models.OrderStatusChange) *pb.OrderStatusChange {
+	pbChange := &pb.OrderStatusChange{
+		Id:        change.ID,
+		OrderId:   change.OrderID,
+		OldStatus: ToPBOrderStatus(change.OldStatus),
+		NewStatus: ToPBOrderStatus(change.NewStatus),
+		ActorRole: change.ActorRole,
+		Note:      change.Note,
+		Reason:    change.Reason,
+		CreatedAt: timestamppb.New(change.CreatedAt),
+	}
+	if change.ActorID != nil {
+		pbChange.ActorId = *change.ActorID
+	}
+	return pbChange
+}
@@ -0,0 +1,47 @@
+package mapper
+
+import (
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToPBShopMember converts domain ShopMember to protobuf ShopMember.
+func ToPBShopMember(m models.ShopMember) *pb.ShopMember {
+	member := &pb.ShopMember{
+		Id:       m.ID,
+		ShopId:   m.ShopID,
+		UserId:   m.UserID,
+		Phone:    m.Phone,
+		FullName: m.FullName,
+		Role:     m.Role,
+		Status:   m.Status,
+	}
+	if !m.CreatedAt.IsZero() {
+		member.CreatedAt = timestamppb.New(m.CreatedAt)
+	}
+	return member
+}
+
+// ToPBApiKey converts domain ShopAPIKey to protobuf ApiKey.
+func ToPBApiKey(k models.ShopAPIKey) *pb.ApiKey {
+	key := &pb.ApiKey{
+		Id:        k.ID,
+		ShopId:    k.ShopID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedBy: k.CreatedBy,
+	}
+	if k.LastUsedAt != nil {
+		key.LastUsedAt = timestamppb.New(*k.LastUsedAt)
+	}
+	if k.ExpiresAt != nil {
+		key.ExpiresAt = timestamppb.New(*k.ExpiresAt)
+	}
+	if !k.CreatedAt.IsZero() {
+		key.CreatedAt = timestamppb.New(k.CreatedAt)
+	}
+	return key
+}
@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToPBStockLevel(level models.StockLevel) *pb.StockLevel {
+	return &pb.StockLevel{
+		ProductId:    level.ProductID,
+		VariantName:  level.VariantName,
+		VariantValue: level.VariantValue,
+		OnHand:       int32(level.OnHand),
+		Reserved:     int32(level.Reserved),
+		Available:    int32(level.Available()),
+		UpdatedAt:    timestamppb.New(level.UpdatedAt),
+	}
+}
+
+func ToPBStockMovement(movement models.StockMovement) *pb.StockMovement {
+	pbMovement := &pb.StockMovement{
+		Id:             movement.ID,
+		ProductId:      movement.ProductID,
+		VariantName:    movement.VariantName,
+		VariantValue:   movement.VariantValue,
+		Kind:           movement.Kind,
+		OnHandChange:   int32(movement.OnHandChange),
+		ReservedChange: int32(movement.ReservedChange),
+		OnHandAfter:    int32(movement.OnHandAfter),
+		ReservedAfter:  int32(movement.ReservedAfter),
+		Note:           movement.Note,
+		CreatedAt:      timestamppb.New(movement.CreatedAt),
+	}
+	if movement.OrderID != nil {
+		pbMovement.OrderId = *movement.OrderID
+	}
+	if movement.ActorID != nil {
+		pbMovement.ActorId = *movement.ActorID
+	}
+	return pbMovement
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"mebellar-backend/models"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader is the metadata header carrying a shop API key.
+const APIKeyHeader = "x-api-key"
+
+// apiKeyTouchInterval limits how often shop_api_keys.last_used_at is written.
+const apiKeyTouchInterval = time.Minute
+
+// HashAPIKey returns the value stored in shop_api_keys.key_hash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiKeyFromMetadata reads the raw x-api-key header.
+func apiKeyFromMetadata(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(APIKeyHeader); len(keys) > 0 {
+			return strings.TrimSpace(keys[0])
+		}
+	}
+	return ""
+}
+
+// authenticateAPIKey resolves a shop API key. The call acts as the key's creator with
+// their current role in the key's shop, so keys stop working once the creator loses
+// access, is deactivated or has their token version bumped. The platform role is
+// always seller: admin exemptions never apply to keys.
+func authenticateAPIKey(ctx context.Context, db *sql.DB, shops *shopResolver, key string) (context.Context, error) {
+	if db == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	var keyID, shopID, userID string
+	var scopes pq.StringArray
+	err := db.QueryRowContext(ctx, `
+		SELECT k.id, k.shop_id, k.created_by, k.scopes
+		FROM shop_api_keys k
+		JOIN users u ON u.id = k.created_by
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL AND (k.expires_at IS NULL OR k.expires_at > NOW())
+		  AND COALESCE(u.is_active, true) AND u.token_version = k.creator_token_version
+	`, HashAPIKey(key)).Scan(&keyID, &shopID, &userID, &scopes)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "API key lookup error: %v", err)
+	}
+
+	if header := shopIDFromMetadata(ctx); header != "" && header != shopID {
+		return nil, status.Error(codes.PermissionDenied, "API key is scoped to another shop")
+	}
+
+	shopRole, err := shops.role(ctx, shopID, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "shop lookup error: %v", err)
+	}
+	if shopRole == "" {
+		return nil, status.Error(codes.Unauthenticated, "API key owner no longer has access to the shop")
+	}
+
+	ctx = context.WithValue(ctx, ctxUserID, userID)
+	ctx = context.WithValue(ctx, ctxRole, models.RoleSeller)
+	ctx = context.WithValue(ctx, ctxSessionID, "")
+	ctx = context.WithValue(ctx, ctxMFA, false)
+	ctx = context.WithValue(ctx, ctxShopID, shopID)
+	ctx = context.WithValue(ctx, ctxShopRole, shopRole)
+	ctx = context.WithValue(ctx, ctxAPIKeyID, keyID)
+	ctx = context.WithValue(ctx, ctxScopes, []string(scopes))
+	touchAPIKey(ctx, db, keyID)
+	return ctx, nil
+}
+
+// touchAPIKey keeps shop_api_keys.last_used_at fresh for the keys screen.
+// Writes are throttled by apiKeyTouchInterval; failures are not fatal.
+func touchAPIKey(ctx context.Context, db *sql.DB, keyID string) {
+	_, _ = db.ExecContext(ctx, `
+		UPDATE shop_api_keys SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - $2 * INTERVAL '1 second')
+	`, keyID, int(apiKeyTouchInterval.Seconds()))
+}
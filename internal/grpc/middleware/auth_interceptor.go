@@ -3,9 +3,16 @@ package middleware
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strings"
+	"time"
+
+	"mebellar-backend/pkg/cache"
+	"mebellar-backend/pkg/jwtkeys"
+	"mebellar-backend/pkg/logger"
 
 	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -15,16 +22,37 @@ import (
 type ctxKey string
 
 const (
-	ctxUserID ctxKey = "user_id"
-	ctxRole   ctxKey = "role"
-	ctxShopID ctxKey = "shop_id"
+	ctxUserID    ctxKey = "user_id"
+	ctxRole      ctxKey = "role"
+	ctxShopID    ctxKey = "shop_id"
+	ctxSessionID ctxKey = "session_id"
+	ctxShopRole  ctxKey = "shop_role"
+	ctxMFA       ctxKey = "mfa"
+	ctxAPIKeyID  ctxKey = "api_key_id"
+	ctxScopes    ctxKey = "scopes"
+	ctxActorID   ctxKey = "actor_id"
 )
 
+// TokenTypeAccess is the "typ" claim of access tokens. Refresh tokens are opaque
+// and never accepted here.
+const TokenTypeAccess = "access"
+
 // AuthContext carries the authenticated user and shop context.
+// ShopID is the X-Shop-ID header verified against the caller's shops;
+// ShopRole is the caller's role in that shop (empty for platform staff).
+// MFA is set when the session passed a second factor (TOTP) at login.
+// APIKeyID and Scopes are set for calls authenticated with a shop API key instead of a JWT.
+// ActorID is the admin acting as UserID with an impersonation token (the "act" claim).
 type AuthContext struct {
-	UserID string
-	Role   string
-	ShopID string
+	UserID    string
+	Role      string
+	ShopID    string
+	ShopRole  string
+	SessionID string
+	MFA       bool
+	APIKeyID  string
+	Scopes    []string
+	ActorID   string
 }
 
 // GetAuthContext extracts the AuthContext from the gRPC context if available.
@@ -33,21 +61,41 @@ func GetAuthContext(ctx context.Context) *AuthContext {
 	if val == nil {
 		return nil
 	}
+	shopID, _ := ctx.Value(ctxShopID).(string)
+	shopRole, _ := ctx.Value(ctxShopRole).(string)
+	sessionID, _ := ctx.Value(ctxSessionID).(string)
+	mfa, _ := ctx.Value(ctxMFA).(bool)
+	apiKeyID, _ := ctx.Value(ctxAPIKeyID).(string)
+	scopes, _ := ctx.Value(ctxScopes).([]string)
+	actorID, _ := ctx.Value(ctxActorID).(string)
 	return &AuthContext{
-		UserID: val.(string),
-		Role:   ctx.Value(ctxRole).(string),
-		ShopID: ctx.Value(ctxShopID).(string),
+		UserID:    val.(string),
+		Role:      ctx.Value(ctxRole).(string),
+		ShopID:    shopID,
+		ShopRole:  shopRole,
+		SessionID: sessionID,
+		MFA:       mfa,
+		APIKeyID:  apiKeyID,
+		Scopes:    scopes,
+		ActorID:   actorID,
 	}
 }
 
 // NewAuthInterceptors creates unary and stream interceptors that:
-// - Extract Authorization Bearer token from metadata.
-// - Validate JWT using the provided secret.
-// - Extract X-Shop-ID from metadata for multi-shop operations.
-// skipAuthMethods should contain fully-qualified gRPC method names that are public (e.g., /auth.AuthService/Login).
-func NewAuthInterceptors(jwtSecret []byte, db *sql.DB, skipAuthMethods map[string]bool) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+// - Look up the access policy of the called method; methods without a policy are denied.
+// - Extract Authorization Bearer token from metadata and validate JWT against the key ring (by kid),
+//   or resolve a shop API key from the x-api-key header.
+// - Reject tokens issued before the user's token version was bumped (versions cached in authCache, may be nil).
+// - Reject tokens whose session was revoked (live sessions cached in authCache).
+// - Verify X-Shop-ID against the caller's shops for multi-shop operations (owners cached in authCache).
+// - Enforce the policy's roles, its second-factor requirement and, for unary calls, its ownership check.
+func NewAuthInterceptors(keys *jwtkeys.KeyRing, db *sql.DB, policies Policies, authCache cache.Cache) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	shops := &shopResolver{db: db, cache: authCache}
+	versions := NewTokenVersions(db, authCache)
+	sessions := NewSessions(db, authCache)
+
 	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		ctxWithAuth, err := authenticate(ctx, info.FullMethod, jwtSecret, db, skipAuthMethods)
+		ctxWithAuth, err := authorize(ctx, info.FullMethod, keys, db, versions, sessions, shops, policies, req)
 		if err != nil {
 			return nil, err
 		}
@@ -55,7 +103,7 @@ func NewAuthInterceptors(jwtSecret []byte, db *sql.DB, skipAuthMethods map[strin
 	}
 
 	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		ctxWithAuth, err := authenticate(ss.Context(), info.FullMethod, jwtSecret, db, skipAuthMethods)
+		ctxWithAuth, err := authorize(ss.Context(), info.FullMethod, keys, db, versions, sessions, shops, policies, nil)
 		if err != nil {
 			return err
 		}
@@ -66,13 +114,41 @@ func NewAuthInterceptors(jwtSecret []byte, db *sql.DB, skipAuthMethods map[strin
 	return unary, stream
 }
 
-// authenticate validates JWT and enriches context with user/shop.
-func authenticate(ctx context.Context, fullMethod string, jwtSecret []byte, _ *sql.DB, skip map[string]bool) (context.Context, error) {
-	if skip != nil && skip[fullMethod] {
-		// still capture shop id if provided
-		return attachShopID(ctx), nil
+// authorize applies the method's policy. req is nil for streams.
+func authorize(ctx context.Context, fullMethod string, keys *jwtkeys.KeyRing, db *sql.DB, versions *TokenVersions, sessions *Sessions, shops *shopResolver, policies Policies, req interface{}) (context.Context, error) {
+	policy, ok := policies[fullMethod]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access policy is not defined for this method")
+	}
+	if policy.Public {
+		if policy.OptionalAuth && hasAuthorization(ctx) {
+			return authenticate(ctx, keys, db, versions, sessions, shops)
+		}
+		return ctx, nil
 	}
 
+	ctx, err := authenticate(ctx, keys, db, versions, sessions, shops)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.authorize(ctx, db, req); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// hasAuthorization reports whether the request carries an authorization header.
+func hasAuthorization(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get("authorization")) > 0
+}
+
+// sessionTouchInterval limits how often last_active is written for a session.
+const sessionTouchInterval = time.Minute
+
+// authenticate validates JWT and enriches context with user/shop.
+// Requests without an authorization header may authenticate with an API key.
+func authenticate(ctx context.Context, keys *jwtkeys.KeyRing, db *sql.DB, versions *TokenVersions, sessions *Sessions, shops *shopResolver) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "missing metadata")
@@ -80,6 +156,9 @@ func authenticate(ctx context.Context, fullMethod string, jwtSecret []byte, _ *s
 
 	authHeaders := md.Get("authorization")
 	if len(authHeaders) == 0 {
+		if key := apiKeyFromMetadata(ctx); key != "" {
+			return authenticateAPIKey(ctx, db, shops, key)
+		}
 		return nil, status.Error(codes.Unauthenticated, "authorization header is required")
 	}
 
@@ -88,13 +167,38 @@ func authenticate(ctx context.Context, fullMethod string, jwtSecret []byte, _ *s
 		return nil, status.Error(codes.Unauthenticated, "invalid authorization scheme")
 	}
 
-	tokenString := strings.TrimSpace(raw[len("bearer "):])
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, status.Error(codes.Unauthenticated, "unexpected signing method")
-		}
-		return jwtSecret, nil
-	})
+	claims, err := ParseAccessToken(ctx, keys, versions, sessions, strings.TrimSpace(raw[len("bearer "):]))
+	if err != nil {
+		return nil, err
+	}
+
+	userID, _ := claims["user_id"].(string)
+	role, _ := claims["role"].(string)
+	sessionID, _ := claims["sid"].(string)
+	mfa, _ := claims["mfa"].(bool)
+	actorID := actorFromClaims(claims)
+	if role == "" {
+		role = "customer"
+	}
+
+	ctx = context.WithValue(ctx, ctxUserID, userID)
+	ctx = context.WithValue(ctx, ctxRole, role)
+	ctx = context.WithValue(ctx, ctxSessionID, sessionID)
+	ctx = context.WithValue(ctx, ctxMFA, mfa)
+	ctx = context.WithValue(ctx, ctxActorID, actorID)
+	ctx, err = shops.attach(ctx, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	touchSession(ctx, db, sessionID)
+	return ctx, nil
+}
+
+// ParseAccessToken validates an access token: signature and expiry against the key ring,
+// the "typ" claim, the user's current token version and its session. Other transports
+// (WebSocket) use it to accept exactly the tokens the interceptor accepts. Errors are gRPC statuses.
+func ParseAccessToken(ctx context.Context, keys *jwtkeys.KeyRing, versions *TokenVersions, sessions *Sessions, tokenString string) (jwt.MapClaims, error) {
+	token, err := keys.Parse(tokenString)
 	if err != nil || !token.Valid {
 		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
 	}
@@ -104,32 +208,79 @@ func authenticate(ctx context.Context, fullMethod string, jwtSecret []byte, _ *s
 		return nil, status.Error(codes.Unauthenticated, "invalid claims")
 	}
 
+	if typ, _ := claims["typ"].(string); typ != TokenTypeAccess {
+		return nil, status.Error(codes.Unauthenticated, "access token required")
+	}
+
 	userID, _ := claims["user_id"].(string)
-	role, _ := claims["role"].(string)
 	if userID == "" {
 		return nil, status.Error(codes.Unauthenticated, "user_id missing in token")
 	}
-	if role == "" {
-		role = "customer"
+
+	// Tokens issued before the last bump (deactivation, role change, logout everywhere) are revoked
+	if err := checkTokenVersion(ctx, versions, userID, claims["ver"]); err != nil {
+		return nil, err
+	}
+	// An impersonation token also dies with the acting admin's version
+	if act, ok := claims["act"].(map[string]interface{}); ok {
+		actorID, _ := act["sub"].(string)
+		if actorID == "" {
+			return nil, status.Error(codes.Unauthenticated, "actor missing in token")
+		}
+		if err := checkTokenVersion(ctx, versions, actorID, act["ver"]); err != nil {
+			return nil, err
+		}
 	}
 
-	ctx = context.WithValue(ctx, ctxUserID, userID)
-	ctx = context.WithValue(ctx, ctxRole, role)
-	ctx = attachShopID(ctx)
-	return ctx, nil
+	// Signing a device out deletes its session; impersonation tokens have none
+	if sessionID, _ := claims["sid"].(string); sessionID != "" {
+		exists, err := sessions.Exists(ctx, sessionID)
+		if err != nil {
+			logger.Error("Failed to check session", zap.String("session_id", sessionID), zap.Error(err))
+			return nil, status.Error(codes.Internal, "authentication is temporarily unavailable")
+		}
+		if !exists {
+			return nil, status.Error(codes.Unauthenticated, "session has been revoked")
+		}
+	}
+	return claims, nil
 }
 
-// attachShopID reads X-Shop-ID from metadata and attaches to context.
-func attachShopID(ctx context.Context) context.Context {
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		shopIDs := md.Get("x-shop-id")
-		if len(shopIDs) > 0 {
-			ctx = context.WithValue(ctx, ctxShopID, shopIDs[0])
-			return ctx
-		}
+// checkTokenVersion compares the version a token was issued with against the user's current one.
+func checkTokenVersion(ctx context.Context, versions *TokenVersions, userID string, claimed interface{}) error {
+	tokenVersion, _ := claimed.(float64)
+	current, err := versions.Current(ctx, userID)
+	if errors.Is(err, ErrTokenUserNotFound) {
+		return status.Error(codes.Unauthenticated, "token has been revoked")
+	}
+	if err != nil {
+		logger.Error("Failed to load token version", zap.String("user_id", userID), zap.Error(err))
+		return status.Error(codes.Internal, "authentication is temporarily unavailable")
+	}
+	if int64(tokenVersion) != current {
+		return status.Error(codes.Unauthenticated, "token has been revoked")
+	}
+	return nil
+}
+
+// actorFromClaims reads the impersonating admin from the RFC 8693 "act" claim
+// ({"sub": "<admin id>", "ver": <admin token version>}).
+func actorFromClaims(claims jwt.MapClaims) string {
+	act, _ := claims["act"].(map[string]interface{})
+	actorID, _ := act["sub"].(string)
+	return actorID
+}
+
+// touchSession keeps user_sessions.last_active fresh for the devices screen.
+// Writes are throttled by sessionTouchInterval; failures are not fatal.
+func touchSession(ctx context.Context, db *sql.DB, sessionID string) {
+	if db == nil || sessionID == "" {
+		return
 	}
-	ctx = context.WithValue(ctx, ctxShopID, "")
-	return ctx
+	_, _ = db.ExecContext(ctx, `
+		UPDATE user_sessions SET last_active = NOW()
+		WHERE id = $1 AND (last_active IS NULL OR last_active < NOW() - $2 * INTERVAL '1 second')
+	`, sessionID, int(sessionTouchInterval.Seconds()))
 }
 
 type serverStreamWithContext struct {
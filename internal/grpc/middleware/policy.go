@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OwnerCheck verifies that the caller owns the resource addressed by a unary request.
+// It runs after the role check and should return a gRPC status error on denial.
+type OwnerCheck func(ctx context.Context, db *sql.DB, auth *AuthContext, req interface{}) error
+
+// Policy describes who may call a single gRPC method.
+type Policy struct {
+	// Public methods are served without a token.
+	Public bool
+	// OptionalAuth makes a public method authenticate the caller when a bearer token is sent,
+	// so the handler can tell a logged-in user from a guest. An invalid token is still rejected.
+	OptionalAuth bool
+	// Roles allowed to call the method. Empty means any authenticated user.
+	Roles []string
+	// Owner is an optional resource ownership check for unary methods.
+	Owner OwnerCheck
+	// OwnerExempt roles skip the Owner check (e.g. admins managing any shop). The exemption
+	// needs a second factor, like the admin panel; without it the call is denied.
+	OwnerExempt []string
+	// RequireMFA denies access tokens of sessions that did not pass a second factor.
+	RequireMFA bool
+	// Scope an API key needs to call the method. Methods without a scope reject API keys.
+	Scope string
+	// DenyImpersonation blocks the method for admins impersonating a user (destructive or credential changes).
+	DenyImpersonation bool
+}
+
+// Policies maps fully-qualified gRPC method names (/package.Service/Method) to their policy.
+// Methods without a policy are denied.
+type Policies map[string]Policy
+
+// Public returns a policy for methods that don't require authentication.
+func Public() Policy {
+	return Policy{Public: true}
+}
+
+// Authenticated returns a policy for methods open to any logged-in user.
+func Authenticated() Policy {
+	return Policy{}
+}
+
+// RequireRoles returns a policy restricted to the given roles.
+func RequireRoles(roles ...string) Policy {
+	return Policy{Roles: roles}
+}
+
+// WithOptionalAuth authenticates callers of a public method that send a bearer token.
+func (p Policy) WithOptionalAuth() Policy {
+	p.OptionalAuth = true
+	return p
+}
+
+// WithOwner adds a resource ownership check that the exempt roles bypass once they passed MFA.
+func (p Policy) WithOwner(check OwnerCheck, exemptRoles ...string) Policy {
+	p.Owner = check
+	p.OwnerExempt = exemptRoles
+	return p
+}
+
+// WithMFA requires the caller's session to be verified with a second factor.
+func (p Policy) WithMFA() Policy {
+	p.RequireMFA = true
+	return p
+}
+
+// WithScope opens the method to shop API keys holding the scope.
+func (p Policy) WithScope(scope string) Policy {
+	p.Scope = scope
+	return p
+}
+
+// WithoutImpersonation blocks the method while an admin impersonates the caller.
+func (p Policy) WithoutImpersonation() Policy {
+	p.DenyImpersonation = true
+	return p
+}
+
+// AllowsRole reports whether the role passes the policy's role restriction.
+func (p Policy) AllowsRole(role string) bool {
+	if len(p.Roles) == 0 {
+		return true
+	}
+	return containsRole(p.Roles, role)
+}
+
+// authorize enforces API key scopes, roles, the MFA requirement and, for unary calls (req != nil), the ownership check.
+func (p Policy) authorize(ctx context.Context, db *sql.DB, req interface{}) error {
+	auth := GetAuthContext(ctx)
+	if auth == nil {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if auth.APIKeyID != "" {
+		if p.Scope == "" {
+			return status.Error(codes.PermissionDenied, "method is not available to API keys")
+		}
+		if !slices.Contains(auth.Scopes, p.Scope) {
+			return status.Errorf(codes.PermissionDenied, "API key lacks the %s scope", p.Scope)
+		}
+	}
+	if p.DenyImpersonation && auth.ActorID != "" {
+		return status.Error(codes.PermissionDenied, "not allowed while impersonating a user")
+	}
+	if !p.AllowsRole(auth.Role) {
+		return status.Errorf(codes.PermissionDenied, "%s role required", strings.Join(p.Roles, " or "))
+	}
+	if p.RequireMFA && !auth.MFA {
+		return status.Error(codes.PermissionDenied, "two-factor authentication required")
+	}
+	if p.Owner == nil || req == nil {
+		return nil
+	}
+	if containsRole(p.OwnerExempt, auth.Role) {
+		if !auth.MFA {
+			return status.Error(codes.PermissionDenied, "two-factor authentication required")
+		}
+		return nil
+	}
+	return p.Owner(ctx, db, auth, req)
+}
+
+// Validate checks that every method registered on the server has a policy
+// and that ownership checks are only attached to unary methods.
+func (p Policies) Validate(services map[string]grpc.ServiceInfo) error {
+	var problems []string
+	for serviceName, info := range services {
+		for _, method := range info.Methods {
+			fullMethod := "/" + serviceName + "/" + method.Name
+			policy, ok := p[fullMethod]
+			if !ok {
+				problems = append(problems, fullMethod+": no policy")
+				continue
+			}
+			if policy.Owner != nil && (method.IsClientStream || method.IsServerStream) {
+				problems = append(problems, fullMethod+": owner check is not supported for streams")
+			}
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid access policies:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"mebellar-backend/pkg/logger"
 	"mebellar-backend/pkg/ratelimit"
@@ -59,6 +60,25 @@ func getClientIdentifier(ctx context.Context, method string) string {
 	return "method:" + method
 }
 
+// phoneKeyedMethods - методы, лимит которых считается по номеру телефона из запроса,
+// а не по IP: смена IP не должна позволять перебирать коды одного номера
+var phoneKeyedMethods = map[string]bool{
+	"/auth.AuthService/ForgotPassword":   true,
+	"/auth.AuthService/ResetPassword":    true,
+	"/auth.AuthService/RequestLoginCode": true,
+	"/auth.AuthService/LoginWithCode":    true,
+}
+
+// getPhoneIdentifier возвращает ключ по номеру телефона запроса или fallback, если номера нет
+func getPhoneIdentifier(req interface{}, method, fallback string) string {
+	if r, ok := req.(interface{ GetPhone() string }); ok {
+		if phone := strings.TrimSpace(r.GetPhone()); phone != "" {
+			return "phone:" + phone + ":" + method
+		}
+	}
+	return fallback
+}
+
 // MethodSpecificRateLimits конфигурация лимитов для разных методов
 var MethodSpecificRateLimits = map[string]int{
 	"/auth.AuthService/Login":    5,  // 5 попыток входа в минуту
@@ -82,6 +102,9 @@ func AdaptiveRateLimitInterceptor(limiters map[string]ratelimit.Limiter) grpc.Un
 		}
 
 		key := getClientIdentifier(ctx, info.FullMethod)
+		if phoneKeyedMethods[info.FullMethod] {
+			key = getPhoneIdentifier(req, info.FullMethod, key)
+		}
 
 		allowed, err := limiter.Allow(key)
 		if err != nil || !allowed {
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"mebellar-backend/pkg/cache"
+)
+
+// sessionTTL bounds how long a cached live session is trusted. Revoking a single session
+// clears the shared (Redis) entry at once; the TTL covers bulk revocations and instances
+// with an in-memory cache.
+const sessionTTL = 10 * time.Second
+
+// Sessions tells whether the session an access token was issued for (the "sid" claim) is
+// still alive. Signing a device out deletes its user_sessions row, which revokes the
+// device's access token as well.
+type Sessions struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+// NewSessions creates a session store; c may be nil.
+func NewSessions(db *sql.DB, c cache.Cache) *Sessions {
+	return &Sessions{db: db, cache: c}
+}
+
+func sessionKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+// Exists reports whether the session was not revoked, using the cache when available.
+// Only live sessions are cached so a revoked one is never served from the cache.
+func (s *Sessions) Exists(ctx context.Context, sessionID string) (bool, error) {
+	if s.db == nil {
+		return true, nil
+	}
+	var exists bool
+	if s.cache != nil && s.cache.Get(sessionKey(sessionID), &exists) == nil && exists {
+		return true, nil
+	}
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM user_sessions WHERE id = $1)`, sessionID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	if exists && s.cache != nil {
+		_ = s.cache.Set(sessionKey(sessionID), true, sessionTTL)
+	}
+	return exists, nil
+}
+
+// Forget drops deleted sessions from the cache so their access tokens stop working at once.
+func (s *Sessions) Forget(sessionIDs ...string) {
+	if s.cache == nil {
+		return
+	}
+	for _, sessionID := range sessionIDs {
+		_ = s.cache.Delete(sessionKey(sessionID))
+	}
+}
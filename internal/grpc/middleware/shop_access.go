@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/cache"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// shopOwnerTTL is how long the shop → owner user mapping is cached.
+const shopOwnerTTL = time.Minute
+
+// shopResolver verifies the X-Shop-ID header against the shops the caller may act for.
+type shopResolver struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+// attach resolves X-Shop-ID for an authenticated caller and stores the verified shop
+// and the caller's role in it on the context. Platform admins and moderators may
+// address any existing shop; they get an empty shop role. Staff roles are not
+// cached so that removing a member takes effect immediately.
+func (r *shopResolver) attach(ctx context.Context, userID, role string) (context.Context, error) {
+	shopID := shopIDFromMetadata(ctx)
+	if shopID == "" {
+		ctx = context.WithValue(ctx, ctxShopID, "")
+		return context.WithValue(ctx, ctxShopRole, ""), nil
+	}
+	if _, err := uuid.Parse(shopID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid x-shop-id")
+	}
+
+	ownerID, err := r.shopOwner(ctx, shopID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "shop not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "shop lookup error: %v", err)
+	}
+
+	var shopRole string
+	switch {
+	case ownerID == userID:
+		shopRole = models.ShopRoleOwner
+	case role == models.RoleAdmin || role == models.RoleModerator:
+		shopRole = ""
+	default:
+		shopRole, err = ShopMemberRole(ctx, r.db, shopID, userID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "shop member lookup error: %v", err)
+		}
+		if shopRole == "" {
+			return nil, status.Error(codes.PermissionDenied, "you don't have access to this shop")
+		}
+	}
+
+	ctx = context.WithValue(ctx, ctxShopID, shopID)
+	return context.WithValue(ctx, ctxShopRole, shopRole), nil
+}
+
+// role returns the user's role in the shop: owner, an active staff role, or "" without access.
+func (r *shopResolver) role(ctx context.Context, shopID, userID string) (string, error) {
+	ownerID, err := r.shopOwner(ctx, shopID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if ownerID == userID {
+		return models.ShopRoleOwner, nil
+	}
+	return ShopMemberRole(ctx, r.db, shopID, userID)
+}
+
+// shopOwner returns the user owning the shop, using the cache when available.
+func (r *shopResolver) shopOwner(ctx context.Context, shopID string) (string, error) {
+	key := "shop_owner:" + shopID
+	var ownerID string
+	if r.cache != nil && r.cache.Get(key, &ownerID) == nil && ownerID != "" {
+		return ownerID, nil
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT sp.user_id FROM shops sh
+		JOIN seller_profiles sp ON sp.id = sh.seller_id
+		WHERE sh.id = $1
+	`, shopID).Scan(&ownerID)
+	if err != nil {
+		return "", err
+	}
+
+	if r.cache != nil {
+		_ = r.cache.Set(key, ownerID, shopOwnerTTL)
+	}
+	return ownerID, nil
+}
+
+// ShopMemberRole returns the role of an active staff member of the shop,
+// or "" if the user is not one. The shop owner is not a member.
+func ShopMemberRole(ctx context.Context, db *sql.DB, shopID, userID string) (string, error) {
+	var role string
+	err := db.QueryRowContext(ctx, `
+		SELECT role FROM shop_members
+		WHERE shop_id = $1 AND user_id = $2 AND status = $3
+	`, shopID, userID, models.ShopMemberActive).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
+
+// shopIDFromMetadata reads the raw X-Shop-ID header.
+func shopIDFromMetadata(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if shopIDs := md.Get("x-shop-id"); len(shopIDs) > 0 {
+			return strings.TrimSpace(shopIDs[0])
+		}
+	}
+	return ""
+}
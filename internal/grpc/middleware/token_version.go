@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"mebellar-backend/pkg/cache"
+)
+
+// ErrTokenUserNotFound is returned for tokens of users that no longer exist.
+var ErrTokenUserNotFound = errors.New("token user not found")
+
+// tokenVersionTTL bounds how long a cached users.token_version is trusted. Bumps overwrite
+// the shared (Redis) entry at once; the TTL covers instances with an in-memory cache.
+const tokenVersionTTL = 10 * time.Second
+
+// TokenVersions reads and bumps users.token_version. Access tokens carry the version they
+// were issued with in the "ver" claim and are rejected once the user's version moves on.
+type TokenVersions struct {
+	db    *sql.DB
+	cache cache.Cache
+}
+
+// NewTokenVersions creates a token version store; c may be nil.
+func NewTokenVersions(db *sql.DB, c cache.Cache) *TokenVersions {
+	return &TokenVersions{db: db, cache: c}
+}
+
+func tokenVersionKey(userID string) string {
+	return "token_version:" + userID
+}
+
+// Current returns the user's token version, using the cache when available.
+func (v *TokenVersions) Current(ctx context.Context, userID string) (int64, error) {
+	var version int64
+	if v.cache != nil && v.cache.Get(tokenVersionKey(userID), &version) == nil {
+		return version, nil
+	}
+	return v.Load(ctx, userID)
+}
+
+// Load reads the user's token version from the database and refreshes the cache.
+// Tokens are signed with it so a new token never carries a stale version.
+func (v *TokenVersions) Load(ctx context.Context, userID string) (int64, error) {
+	var version int64
+	if v.db == nil {
+		return 0, nil
+	}
+	err := v.db.QueryRowContext(ctx, `SELECT token_version FROM users WHERE id = $1`, userID).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrTokenUserNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	if v.cache != nil {
+		_ = v.cache.Set(tokenVersionKey(userID), version, tokenVersionTTL)
+	}
+	return version, nil
+}
+
+// Bump revokes every access token issued to the user so far. Call it after the change
+// that requires it (deactivation, role change, password reset, logout everywhere) is saved.
+func (v *TokenVersions) Bump(ctx context.Context, userID string) error {
+	var version int64
+	err := v.db.QueryRowContext(ctx, `
+		UPDATE users SET token_version = token_version + 1 WHERE id = $1
+		RETURNING token_version
+	`, userID).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if v.cache != nil {
+		_ = v.cache.Set(tokenVersionKey(userID), version, tokenVersionTTL)
+	}
+	return nil
+}
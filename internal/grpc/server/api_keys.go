@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"time"
+
+	"mebellar-backend/internal/grpc/mapper"
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/apperror"
+	"mebellar-backend/pkg/logger"
+	"mebellar-backend/pkg/pb"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// apiKeyPrefix starts every shop API key so leaked keys are easy to recognise.
+	apiKeyPrefix = "mbk_"
+	// apiKeyDisplayLength is how much of the key is kept in shop_api_keys.prefix.
+	apiKeyDisplayLength = 12
+	// maxShopAPIKeys limits active keys per shop.
+	maxShopAPIKeys = 20
+)
+
+// ============================================
+// SHOP API KEYS
+// ============================================
+
+func (s *ShopServiceServer) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyRequest) (*pb.CreateApiKeyResponse, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	name := strings.TrimSpace(req.GetName())
+	scopes := slices.Compact(slices.Sorted(slices.Values(req.GetScopes())))
+	if err := s.validateCreateApiKeyRequest(name, scopes, req.GetExpiresInDays()); err != nil {
+		return nil, err.(*apperror.AppError).ToGRPCError()
+	}
+	shopID := req.GetShopId()
+
+	var active int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM shop_api_keys WHERE shop_id = $1 AND revoked_at IS NULL
+	`, shopID).Scan(&active)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if active >= maxShopAPIKeys {
+		return nil, status.Errorf(codes.ResourceExhausted, "a shop can have at most %d API keys", maxShopAPIKeys)
+	}
+
+	key, err := newAPIKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "key generation error: %v", err)
+	}
+	var expiresAt *time.Time
+	if days := req.GetExpiresInDays(); days > 0 {
+		t := time.Now().AddDate(0, 0, int(days))
+		expiresAt = &t
+	}
+
+	var keyID string
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO shop_api_keys (shop_id, created_by, name, prefix, key_hash, scopes, expires_at, creator_token_version)
+		SELECT $1, $2, $3, $4, $5, $6, $7, token_version FROM users WHERE id = $2
+		RETURNING id
+	`, shopID, auth.UserID, name, key[:apiKeyDisplayLength], middleware.HashAPIKey(key), pq.Array(scopes), expiresAt).Scan(&keyID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "insert API key error: %v", err)
+	}
+
+	logger.Info("Shop API key created",
+		zap.String("shop_id", shopID),
+		zap.String("key_id", keyID),
+		zap.String("user_id", auth.UserID),
+		zap.Strings("scopes", scopes),
+	)
+
+	apiKey, err := s.getAPIKey(ctx, shopID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateApiKeyResponse{ApiKey: mapper.ToPBApiKey(apiKey), Key: key}, nil
+}
+
+func (s *ShopServiceServer) ListApiKeys(ctx context.Context, req *pb.ListApiKeysRequest) (*pb.ListApiKeysResponse, error) {
+	shopID := req.GetShopId()
+	if shopID == "" {
+		return nil, status.Error(codes.InvalidArgument, "shop_id is required")
+	}
+
+	rows, err := s.db.QueryContext(ctx, apiKeySelect+`
+		WHERE shop_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`, shopID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &pb.ListApiKeysResponse{}
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		resp.ApiKeys = append(resp.ApiKeys, mapper.ToPBApiKey(apiKey))
+	}
+	return resp, rows.Err()
+}
+
+func (s *ShopServiceServer) RevokeApiKey(ctx context.Context, req *pb.RevokeApiKeyRequest) (*pb.Empty, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE shop_api_keys SET revoked_at = NOW()
+		WHERE id = $1 AND shop_id = $2 AND revoked_at IS NULL
+	`, req.GetId(), req.GetShopId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, status.Error(codes.NotFound, "API key not found")
+	}
+
+	logger.Info("Shop API key revoked",
+		zap.String("shop_id", req.GetShopId()),
+		zap.String("key_id", req.GetId()),
+		zap.String("user_id", auth.UserID),
+	)
+	return &pb.Empty{}, nil
+}
+
+// ============================================
+// SHOP API KEY HELPERS
+// ============================================
+
+// newAPIKey returns a random key of the form "mbk_<43 base64url chars>".
+func newAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+const apiKeySelect = `
+	SELECT id, shop_id, name, prefix, scopes, created_by, last_used_at, expires_at, created_at
+	FROM shop_api_keys
+`
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (models.ShopAPIKey, error) {
+	var k models.ShopAPIKey
+	var scopes pq.StringArray
+	var lastUsedAt, expiresAt sql.NullTime
+	err := row.Scan(&k.ID, &k.ShopID, &k.Name, &k.Prefix, &scopes, &k.CreatedBy, &lastUsedAt, &expiresAt, &k.CreatedAt)
+	k.Scopes = scopes
+	if lastUsedAt.Valid {
+		k.LastUsedAt = &lastUsedAt.Time
+	}
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Time
+	}
+	return k, err
+}
+
+func (s *ShopServiceServer) getAPIKey(ctx context.Context, shopID, keyID string) (models.ShopAPIKey, error) {
+	apiKey, err := scanAPIKey(s.db.QueryRowContext(ctx, apiKeySelect+`
+		WHERE id = $1 AND shop_id = $2
+	`, keyID, shopID))
+	if err == sql.ErrNoRows {
+		return apiKey, status.Error(codes.NotFound, "API key not found")
+	}
+	if err != nil {
+		return apiKey, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+	"mebellar-backend/pkg/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestShopService_ApiKeys(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, nil, nil)
+	shopService := NewShopServiceServer(db, nil)
+
+	ownerID, sellerID := uuid.NewString(), uuid.NewString()
+	_, err := db.Exec(`
+		INSERT INTO users (id, full_name, phone, password_hash, role, is_active)
+		VALUES ($1, 'Shop Owner', '+998907770001', 'x', $2, true)
+	`, ownerID, models.RoleSeller)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO seller_profiles (id, user_id) VALUES ($1, $2)`, sellerID, ownerID)
+	require.NoError(t, err)
+	shopID, otherShopID := uuid.NewString(), uuid.NewString()
+	for _, id := range []string{shopID, otherShopID} {
+		_, err = db.Exec(`INSERT INTO shops (id, seller_id, name, slug) VALUES ($1, $2, '{"uz": "Mebel Uy"}', $3)`, id, sellerID, "mebel-uy-"+id[:8])
+		require.NoError(t, err)
+	}
+
+	ownerToken, err := authService.signAccessToken(context.Background(), ownerID, "+998907770001", models.RoleSeller, "", false)
+	require.NoError(t, err)
+
+	createReq := &pb.CreateApiKeyRequest{ShopId: shopID, Name: "1C", Scopes: []string{models.APIScopeProductsRead, models.APIScopeOrdersRead}}
+	created, err := callAuthenticated(t, jwtKeys, db, ownerToken, pb.ShopService_CreateApiKey_FullMethodName, createReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.CreateApiKey(ctx, createReq)
+	})
+	require.NoError(t, err)
+	key := created.(*pb.CreateApiKeyResponse).Key
+	require.NotEmpty(t, key)
+	assert.Equal(t, key[:apiKeyDisplayLength], created.(*pb.CreateApiKeyResponse).ApiKey.Prefix)
+
+	// Неизвестный scope отклоняется
+	badReq := &pb.CreateApiKeyRequest{ShopId: shopID, Name: "1C", Scopes: []string{"users:write"}}
+	_, err = callAuthenticated(t, jwtKeys, db, ownerToken, pb.ShopService_CreateApiKey_FullMethodName, badReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.CreateApiKey(ctx, badReq)
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	unary, _ := middleware.NewAuthInterceptors(jwtKeys, db, Policies(), nil)
+	callWithKey := func(apiKey, method string, req interface{}) (*middleware.AuthContext, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(middleware.APIKeyHeader, apiKey))
+		var auth *middleware.AuthContext
+		_, err := unary(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			auth = middleware.GetAuthContext(ctx)
+			return nil, nil
+		})
+		return auth, err
+	}
+
+	// Ключ действует от имени создателя только в своем магазине и в пределах scope
+	auth, err := callWithKey(key, pb.ProductService_ListSellerProducts_FullMethodName, &pb.ListSellerProductsRequest{ShopId: shopID})
+	require.NoError(t, err)
+	assert.Equal(t, ownerID, auth.UserID)
+	assert.Equal(t, shopID, auth.ShopID)
+	assert.Equal(t, models.ShopRoleOwner, auth.ShopRole)
+	assert.NotEmpty(t, auth.APIKeyID)
+
+	_, err = callWithKey(key, pb.ProductService_CreateProduct_FullMethodName, &pb.CreateProductRequest{ShopId: shopID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = callWithKey(key, pb.ProductService_ListSellerProducts_FullMethodName, &pb.ListSellerProductsRequest{ShopId: otherShopID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = callWithKey(key, pb.ShopService_CreateApiKey_FullMethodName, createReq)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = callWithKey("mbk_unknown", pb.ProductService_ListSellerProducts_FullMethodName, &pb.ListSellerProductsRequest{ShopId: shopID})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	listReq := &pb.ListApiKeysRequest{ShopId: shopID}
+	listed, err := callAuthenticated(t, jwtKeys, db, ownerToken, pb.ShopService_ListApiKeys_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.ListApiKeys(ctx, listReq)
+	})
+	require.NoError(t, err)
+	keys := listed.(*pb.ListApiKeysResponse).ApiKeys
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt)
+	assert.ElementsMatch(t, []string{models.APIScopeProductsRead, models.APIScopeOrdersRead}, keys[0].Scopes)
+
+	// Отозванный ключ больше не принимается
+	revokeReq := &pb.RevokeApiKeyRequest{ShopId: shopID, Id: keys[0].Id}
+	_, err = callAuthenticated(t, jwtKeys, db, ownerToken, pb.ShopService_RevokeApiKey_FullMethodName, revokeReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.RevokeApiKey(ctx, revokeReq)
+	})
+	require.NoError(t, err)
+	_, err = callWithKey(key, pb.ProductService_ListSellerProducts_FullMethodName, &pb.ListSellerProductsRequest{ShopId: shopID})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Выход со всех устройств создателя отзывает и его ключи
+	created, err = callAuthenticated(t, jwtKeys, db, ownerToken, pb.ShopService_CreateApiKey_FullMethodName, createReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.CreateApiKey(ctx, createReq)
+	})
+	require.NoError(t, err)
+	key = created.(*pb.CreateApiKeyResponse).Key
+	_, err = callWithKey(key, pb.ProductService_ListSellerProducts_FullMethodName, &pb.ListSellerProductsRequest{ShopId: shopID})
+	require.NoError(t, err)
+	require.NoError(t, middleware.NewTokenVersions(db, nil).Bump(context.Background(), ownerID))
+	_, err = callWithKey(key, pb.ProductService_ListSellerProducts_FullMethodName, &pb.ListSellerProductsRequest{ShopId: shopID})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+// Audit log actions.
+const (
+	auditPhoneChanged = "user.phone_changed"
+	auditEmailChanged = "user.email_changed"
+
+	auditImpersonationStarted = "admin.impersonation_started"
+	auditImpersonatedCall     = "admin.impersonated_call"
+	auditLoginLockoutCleared  = "admin.login_lockout_cleared"
+)
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// auditEntry is a row of audit_logs. ActorID defaults to UserID for self-service changes.
+type auditEntry struct {
+	UserID  string
+	ActorID string
+	Action  string
+	Details map[string]any
+}
+
+// recordAudit writes an audit log entry; the client IP is taken from the request context.
+func recordAudit(ctx context.Context, db execer, entry auditEntry) error {
+	if entry.ActorID == "" {
+		entry.ActorID = entry.UserID
+	}
+	if entry.Details == nil {
+		entry.Details = map[string]any{}
+	}
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO audit_logs (user_id, actor_id, action, details, ip_address)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	`, entry.UserID, entry.ActorID, entry.Action, details, clientIPFromContext(ctx))
+	return err
+}
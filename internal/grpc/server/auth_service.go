@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"math/rand"
 	"strings"
 	"time"
 
@@ -13,28 +12,66 @@ import (
 	"mebellar-backend/internal/grpc/middleware"
 	"mebellar-backend/models"
 	"mebellar-backend/pkg/apperror"
+	"mebellar-backend/pkg/jwtkeys"
 	"mebellar-backend/pkg/logger"
+	"mebellar-backend/pkg/otp"
 	"mebellar-backend/pkg/pb"
 	"mebellar-backend/pkg/sms"
+	"mebellar-backend/pkg/totp"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type AuthServiceServer struct {
 	pb.UnimplementedAuthServiceServer
-	db        *sql.DB
-	jwtSecret []byte
-	sms       sms.SMSService
+	db   *sql.DB
+	keys *jwtkeys.KeyRing
+	sms  sms.SMSService
+	otp  otp.Store
+
+	trustedDeviceTTL time.Duration
+	mfaKey           []byte
+	versions         *middleware.TokenVersions
+	sessions         *middleware.Sessions
 }
 
-func NewAuthServiceServer(db *sql.DB, jwtSecret []byte, sms sms.SMSService) *AuthServiceServer {
+func NewAuthServiceServer(db *sql.DB, keys *jwtkeys.KeyRing, sms sms.SMSService, otpStore otp.Store) *AuthServiceServer {
 	return &AuthServiceServer{
-		db:        db,
-		jwtSecret: jwtSecret,
-		sms:       sms,
+		db:               db,
+		keys:             keys,
+		sms:              sms,
+		otp:              otpStore,
+		trustedDeviceTTL: defaultTrustedDeviceTTL,
+		versions:         middleware.NewTokenVersions(db, nil),
+		sessions:         middleware.NewSessions(db, nil),
+	}
+}
+
+// SetTrustedDeviceTTL sets how long a trusted device may stay unused before PIN login stops working.
+func (s *AuthServiceServer) SetTrustedDeviceTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.trustedDeviceTTL = ttl
+	}
+}
+
+// SetTokenVersions shares the token version store (and its cache) with the auth interceptor.
+func (s *AuthServiceServer) SetTokenVersions(versions *middleware.TokenVersions) {
+	s.versions = versions
+}
+
+// SetSessions shares the session store (and its cache) with the auth interceptor.
+func (s *AuthServiceServer) SetSessions(sessions *middleware.Sessions) {
+	s.sessions = sessions
+}
+
+// SetMFAKey sets the key TOTP secrets are encrypted with. MFA setup fails until it is set.
+func (s *AuthServiceServer) SetMFAKey(key []byte) {
+	if len(key) > 0 {
+		s.mfaKey = key
 	}
 }
 
@@ -58,9 +95,11 @@ func (s *AuthServiceServer) SendOTP(ctx context.Context, req *pb.SendOTPRequest)
 		return nil, apperror.NewConflictError("Номер телефона уже зарегистрирован").ToGRPCError()
 	}
 
-	// Генерация OTP
-	rand.Seed(time.Now().UnixNano())
-	code := fmt.Sprintf("%05d", rand.Intn(100000))
+	// Генерация OTP (с учетом cooldown на повторную отправку)
+	code, err := s.otp.Issue(ctx, otp.PurposeRegister, phone)
+	if err != nil {
+		return nil, otpErrorToGRPC(err, phone)
+	}
 
 	// Отправка SMS
 	if s.sms != nil {
@@ -93,20 +132,7 @@ func (s *AuthServiceServer) Login(ctx context.Context, req *pb.LoginRequest) (*p
 		zap.String("phone", req.GetPhone()),
 	)
 
-	var user models.User
-	query := `
-		SELECT id, full_name, phone, COALESCE(email, ''), COALESCE(avatar_url, ''), 
-		       COALESCE(role, 'customer'), COALESCE(onesignal_id, ''), COALESCE(has_pin, false),
-		       COALESCE(password_hash, ''), created_at, updated_at, COALESCE(is_active, true)
-		FROM users
-		WHERE phone = $1
-	`
-	var isActive bool
-	err := s.db.QueryRowContext(ctx, query, req.GetPhone()).Scan(
-		&user.ID, &user.FullName, &user.Phone, &user.Email, &user.AvatarURL,
-		&user.Role, &user.OneSignalID, &user.HasPin, &user.PasswordHash,
-		&user.CreatedAt, &user.UpdatedAt, &isActive,
-	)
+	user, isActive, err := s.findUserByPhone(ctx, req.GetPhone())
 	if errors.Is(err, sql.ErrNoRows) {
 		logger.Warn("Login failed: user not found",
 			zap.String("phone", req.GetPhone()),
@@ -130,38 +156,224 @@ func (s *AuthServiceServer) Login(ctx context.Context, req *pb.LoginRequest) (*p
 		return nil, apperror.NewForbiddenError("Учетная запись деактивирована").ToGRPCError()
 	}
 
+	// Заблокированная учетная запись не проверяет пароль вовсе
+	if err := checkLoginLockout(ctx, s.db, user.ID); err != nil {
+		return nil, loginLockoutToGRPC(err, user.ID)
+	}
+
 	// Проверка пароля
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.GetPassword())); err != nil {
 		logger.Warn("Login failed: invalid password",
 			zap.String("phone", req.GetPhone()),
 			zap.String("user_id", user.ID),
 		)
+		if err := recordLoginFailure(ctx, s.db, s.sms, user.ID, loginMethodPassword); err != nil {
+			return nil, loginLockoutToGRPC(err, user.ID)
+		}
 		return nil, apperror.NewUnauthorizedError("Неверный пароль").ToGRPCError()
 	}
 
-	// Генерация токенов
-	access, refresh, err := s.issueTokens(user.ID, user.Phone, user.Role)
+	// Генерация токенов (создает новую сессию устройства) или запрос второго фактора
+	resp, err := s.completeLogin(ctx, user, loginDevice(ctx, req.GetDeviceName(), req.GetDeviceId()))
 	if err != nil {
-		logger.Error("Failed to issue tokens",
-			zap.String("user_id", user.ID),
-			zap.Error(err),
-		)
-		return nil, apperror.NewInternalError("Не удалось создать токен", err).ToGRPCError()
+		return nil, err
+	}
+	// При включенной 2FA счетчик сбрасывает VerifyMfaLogin, иначе верный пароль обнулял бы неверные коды
+	if !resp.MfaRequired {
+		if _, err := clearLoginFailures(ctx, s.db, user.ID); err != nil {
+			logger.Error("Failed to clear failed login attempts",
+				zap.String("user_id", user.ID),
+				zap.Error(err),
+			)
+		}
 	}
 
 	logger.Info("Login successful",
 		zap.String("user_id", user.ID),
 		zap.String("phone", user.Phone),
 		zap.String("role", user.Role),
+		zap.Bool("mfa_required", resp.MfaRequired),
+	)
+	return resp, nil
+}
+
+func (s *AuthServiceServer) RequestLoginCode(ctx context.Context, req *pb.RequestLoginCodeRequest) (*pb.RequestLoginCodeResponse, error) {
+	if err := s.validateSendOTPRequest(req.GetPhone()); err != nil {
+		return nil, err.(*apperror.AppError).ToGRPCError()
+	}
+
+	phone := strings.TrimSpace(req.GetPhone())
+
+	// Неизвестный номер допустим: аккаунт будет создан в LoginWithCode
+	var isActive bool
+	err := s.db.QueryRowContext(ctx, "SELECT COALESCE(is_active, true) FROM users WHERE phone = $1", phone).Scan(&isActive)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error("Database error during login code request",
+			zap.String("phone", phone),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("поиск пользователя", err).ToGRPCError()
+	}
+	if err == nil && !isActive {
+		return nil, apperror.NewForbiddenError("Учетная запись деактивирована").ToGRPCError()
+	}
+
+	code, err := s.otp.Issue(ctx, otp.PurposeLogin, phone)
+	if err != nil {
+		return nil, otpErrorToGRPC(err, phone)
+	}
+
+	if s.sms != nil {
+		if err := s.sms.SendOTP(phone, code); err != nil {
+			logger.Error("Failed to send login code via SMS",
+				zap.String("phone", phone),
+				zap.Error(err),
+			)
+			return nil, apperror.NewInternalError("Не удалось отправить SMS", err).ToGRPCError()
+		}
+	}
+
+	logger.Info("Login code sent",
+		zap.String("phone", phone),
+	)
+
+	return &pb.RequestLoginCodeResponse{
+		Success: true,
+		Message: "Код для входа отправлен",
+	}, nil
+}
+
+func (s *AuthServiceServer) LoginWithCode(ctx context.Context, req *pb.LoginWithCodeRequest) (*pb.LoginResponse, error) {
+	phone := strings.TrimSpace(req.GetPhone())
+	code := strings.TrimSpace(req.GetCode())
+	if err := s.validateSendOTPRequest(phone); err != nil {
+		return nil, err.(*apperror.AppError).ToGRPCError()
+	}
+	if code == "" {
+		return nil, apperror.NewValidationError("Код обязателен").ToGRPCError()
+	}
+
+	if err := s.otp.Verify(ctx, otp.PurposeLogin, phone, code); err != nil {
+		return nil, otpErrorToGRPC(err, phone)
+	}
+
+	// Неизвестный номер - создаем покупателя без пароля
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO users (id, full_name, phone, password_hash, role, is_active, phone_verified_at, created_at, updated_at)
+		VALUES ($1, '', $2, '', 'customer', true, NOW(), NOW(), NOW())
+		ON CONFLICT (phone) DO NOTHING
+	`, uuid.NewString(), phone)
+	if err != nil {
+		logger.Error("Database error during passwordless signup",
+			zap.String("phone", phone),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("создание пользователя", err).ToGRPCError()
+	}
+	created, _ := result.RowsAffected()
+
+	user, isActive, err := s.findUserByPhone(ctx, phone)
+	if err != nil {
+		logger.Error("Database error during login",
+			zap.String("phone", phone),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("поиск пользователя", err).ToGRPCError()
+	}
+	if !isActive {
+		return nil, apperror.NewForbiddenError("Учетная запись деактивирована").ToGRPCError()
+	}
+	// Код из SMS подтверждает номер; ошибка не мешает входу
+	if err := markPhoneVerified(ctx, s.db, user.ID, phone); err != nil {
+		logger.Error("Failed to mark phone verified",
+			zap.String("user_id", user.ID),
+			zap.Error(err),
+		)
+	}
+
+	resp, err := s.completeLogin(ctx, user, loginDevice(ctx, req.GetDeviceName(), req.GetDeviceId()))
+	if err != nil {
+		return nil, err
+	}
+	resp.IsNewUser = created > 0
+
+	logger.Info("Login with code successful",
+		zap.String("user_id", user.ID),
+		zap.Bool("new_user", created > 0),
+		zap.Bool("mfa_required", resp.MfaRequired),
 	)
+	return resp, nil
+}
+
+// completeLogin finishes a login whose first factor was accepted. Users with TOTP
+// enabled get a challenge token for VerifyMfaLogin instead of a session.
+func (s *AuthServiceServer) completeLogin(ctx context.Context, user *models.User, device sessionDevice) (*pb.LoginResponse, error) {
+	enabled, err := mfaEnabled(ctx, s.db, user.ID)
+	if err != nil {
+		logger.Error("Database error during MFA lookup",
+			zap.String("user_id", user.ID),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("проверка двухфакторной аутентификации", err).ToGRPCError()
+	}
+	if enabled {
+		challenge, err := s.signMfaChallenge(ctx, user.ID, device)
+		if err != nil {
+			return nil, apperror.NewInternalError("Не удалось создать токен", err).ToGRPCError()
+		}
+		return &pb.LoginResponse{
+			MfaRequired: true,
+			MfaToken:    challenge,
+		}, nil
+	}
 
+	access, refresh, err := s.issueTokens(ctx, user.ID, user.Phone, user.Role, device, false)
+	if err != nil {
+		logger.Error("Failed to issue tokens",
+			zap.String("user_id", user.ID),
+			zap.Error(err),
+		)
+		return nil, apperror.NewInternalError("Не удалось создать токен", err).ToGRPCError()
+	}
 	return &pb.LoginResponse{
-		AccessToken:  access,
-		RefreshToken: refresh,
-		User:         mapper.ToPBUser(&user),
+		AccessToken:           access,
+		RefreshToken:          refresh,
+		User:                  mapper.ToPBUser(user),
+		MfaEnrollmentRequired: mfaRequiredForRole(user.Role),
 	}, nil
 }
 
+// findUserByPhone loads the user with its password hash and active flag.
+// sql.ErrNoRows is returned unchanged for unknown numbers.
+func (s *AuthServiceServer) findUserByPhone(ctx context.Context, phone string) (*models.User, bool, error) {
+	return s.findUser(ctx, "phone", phone)
+}
+
+// findUserByID is findUserByPhone for a user ID.
+func (s *AuthServiceServer) findUserByID(ctx context.Context, userID string) (*models.User, bool, error) {
+	return s.findUser(ctx, "id", userID)
+}
+
+func (s *AuthServiceServer) findUser(ctx context.Context, column, value string) (*models.User, bool, error) {
+	var user models.User
+	var isActive bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, COALESCE(full_name, ''), phone, COALESCE(email, ''), COALESCE(avatar_url, ''),
+		       COALESCE(role, 'customer'), COALESCE(onesignal_id, ''), COALESCE(has_pin, false),
+		       COALESCE(password_hash, ''), created_at, updated_at, COALESCE(is_active, true)
+		FROM users
+		WHERE `+column+` = $1
+	`, value).Scan(
+		&user.ID, &user.FullName, &user.Phone, &user.Email, &user.AvatarURL,
+		&user.Role, &user.OneSignalID, &user.HasPin, &user.PasswordHash,
+		&user.CreatedAt, &user.UpdatedAt, &isActive,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	return &user, isActive, nil
+}
+
 func (s *AuthServiceServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	// Валидация
 	if err := s.validateRegisterRequest(
@@ -183,6 +395,11 @@ func (s *AuthServiceServer) Register(ctx context.Context, req *pb.RegisterReques
 		zap.String("role", role),
 	)
 
+	// Номер должен быть подтвержден через VerifyOTP
+	if err := s.otp.ConsumeTicket(ctx, otp.PurposeRegister, req.GetPhone(), req.GetVerificationToken()); err != nil {
+		return nil, otpErrorToGRPC(err, req.GetPhone())
+	}
+
 	// Хеширование пароля
 	hash, err := bcrypt.GenerateFromPassword([]byte(req.GetPassword()), bcrypt.DefaultCost)
 	if err != nil {
@@ -195,8 +412,8 @@ func (s *AuthServiceServer) Register(ctx context.Context, req *pb.RegisterReques
 
 	userID := uuid.NewString()
 	_, err = s.db.ExecContext(ctx, `
-		INSERT INTO users (id, full_name, phone, password_hash, role, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, true, NOW(), NOW())
+		INSERT INTO users (id, full_name, phone, password_hash, role, is_active, phone_verified_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, true, NOW(), NOW(), NOW())
 	`, userID, req.GetFullName(), req.GetPhone(), string(hash), role)
 
 	if err != nil {
@@ -214,6 +431,19 @@ func (s *AuthServiceServer) Register(ctx context.Context, req *pb.RegisterReques
 		return nil, apperror.NewDatabaseError("создание пользователя", err).ToGRPCError()
 	}
 
+	// Гостевые заказы на подтвержденный номер переходят в аккаунт; ошибка не мешает регистрации
+	if linked, err := linkGuestOrders(ctx, s.db, userID); err != nil {
+		logger.Error("Failed to link guest orders",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+	} else if linked > 0 {
+		logger.Info("Guest orders linked to new account",
+			zap.String("user_id", userID),
+			zap.Int64("orders", linked),
+		)
+	}
+
 	user := models.User{
 		ID:        userID,
 		FullName:  req.GetFullName(),
@@ -224,7 +454,7 @@ func (s *AuthServiceServer) Register(ctx context.Context, req *pb.RegisterReques
 		UpdatedAt: time.Now(),
 	}
 
-	access, refresh, err := s.issueTokens(user.ID, user.Phone, user.Role)
+	access, refresh, err := s.issueTokens(ctx, user.ID, user.Phone, user.Role, deviceFromContext(ctx), false)
 	if err != nil {
 		logger.Error("Failed to issue tokens after registration",
 			zap.String("user_id", userID),
@@ -247,14 +477,33 @@ func (s *AuthServiceServer) Register(ctx context.Context, req *pb.RegisterReques
 }
 
 func (s *AuthServiceServer) VerifyOTP(ctx context.Context, req *pb.VerifyOTPRequest) (*pb.VerifyOTPResponse, error) {
-	// In the REST layer OTP is tracked in memory; here we assume verification
-	// is handled upstream and just return a placeholder success to illustrate flow.
-	if strings.TrimSpace(req.GetPhone()) == "" || strings.TrimSpace(req.GetCode()) == "" {
+	phone := strings.TrimSpace(req.GetPhone())
+	code := strings.TrimSpace(req.GetCode())
+	if phone == "" || code == "" {
 		return nil, apperror.NewValidationError("Номер телефона и код обязательны").ToGRPCError()
 	}
+
+	if err := s.otp.Verify(ctx, otp.PurposeRegister, phone, code); err != nil {
+		return nil, otpErrorToGRPC(err, phone)
+	}
+
+	ticket, err := s.otp.IssueTicket(ctx, otp.PurposeRegister, phone)
+	if err != nil {
+		logger.Error("Failed to issue verification ticket",
+			zap.String("phone", phone),
+			zap.Error(err),
+		)
+		return nil, apperror.NewInternalError("Не удалось подтвердить номер", err).ToGRPCError()
+	}
+
+	logger.Info("OTP verified",
+		zap.String("phone", phone),
+	)
+
 	return &pb.VerifyOTPResponse{
-		Success: true,
-		Message: "OTP verified (stub). Wire to SMS/Redis store for production.",
+		Success:           true,
+		Message:           "Номер телефона подтвержден",
+		VerificationToken: ticket,
 	}, nil
 }
 
@@ -262,21 +511,39 @@ func (s *AuthServiceServer) RefreshToken(ctx context.Context, req *pb.RefreshTok
 	if strings.TrimSpace(req.GetRefreshToken()) == "" {
 		return nil, apperror.NewValidationError("Refresh token обязателен").ToGRPCError()
 	}
-	// For simplicity, treat refresh token as JWT with same secret and claims.
-	token, err := jwt.Parse(req.GetRefreshToken(), func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, apperror.NewUnauthorizedError("Неверный алгоритм подписи").ToGRPCError()
-		}
-		return s.jwtSecret, nil
-	})
-	if err != nil || !token.Valid {
+
+	sessionID, userID, refresh, err := rotateSession(ctx, s.db, strings.TrimSpace(req.GetRefreshToken()))
+	if errors.Is(err, errRefreshTokenReused) {
+		logger.Warn("Refresh token reuse detected, session revoked",
+			zap.String("session_id", sessionID),
+		)
+		return nil, apperror.NewUnauthorizedError("Сессия завершена, войдите заново").ToGRPCError()
+	}
+	if errors.Is(err, errRefreshTokenInvalid) {
 		return nil, apperror.NewUnauthorizedError("Неверный refresh token").ToGRPCError()
 	}
-	claims, _ := token.Claims.(jwt.MapClaims)
-	userID, _ := claims["user_id"].(string)
-	phone, _ := claims["phone"].(string)
-	role, _ := claims["role"].(string)
-	access, refresh, err := s.issueTokens(userID, phone, role)
+	if err != nil {
+		logger.Error("Failed to rotate refresh token",
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("обновление сессии", err).ToGRPCError()
+	}
+
+	// Роль и статус берем из базы, а не из старого токена; отметка 2FA хранится в сессии
+	var phone, role string
+	var isActive, mfa bool
+	err = s.db.QueryRowContext(ctx, `
+		SELECT u.phone, COALESCE(u.role, 'customer'), COALESCE(u.is_active, true), s.mfa_verified
+		FROM users u
+		JOIN user_sessions s ON s.user_id = u.id
+		WHERE u.id = $1 AND s.id = $2
+	`, userID, sessionID).Scan(&phone, &role, &isActive, &mfa)
+	if err != nil || !isActive {
+		revokeSession(ctx, s.db, userID, sessionID)
+		return nil, apperror.NewUnauthorizedError("Учетная запись недоступна").ToGRPCError()
+	}
+
+	access, err := s.signAccessToken(ctx, userID, phone, role, sessionID, mfa)
 	if err != nil {
 		return nil, apperror.NewInternalError("Не удалось создать токен", err).ToGRPCError()
 	}
@@ -286,28 +553,737 @@ func (s *AuthServiceServer) RefreshToken(ctx context.Context, req *pb.RefreshTok
 	}, nil
 }
 
-func (s *AuthServiceServer) issueTokens(userID, phone, role string) (string, string, error) {
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"phone":   phone,
-		"role":    role,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
-		"iat":     time.Now().Unix(),
+func (s *AuthServiceServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	authCtx := AuthFromContext(ctx)
+	if authCtx == nil {
+		return nil, apperror.NewUnauthorizedError("Требуется авторизация").ToGRPCError()
+	}
+
+	var err error
+	if req.GetAllDevices() {
+		// Access tokens already handed out stop working too
+		if _, err = revokeAllSessions(ctx, s.db, authCtx.UserID, ""); err == nil {
+			err = s.versions.Bump(ctx, authCtx.UserID)
+		}
+	} else if authCtx.SessionID != "" {
+		// Токен этого устройства перестает работать вместе с сессией
+		_, err = revokeSession(ctx, s.db, authCtx.UserID, authCtx.SessionID)
+		s.sessions.Forget(authCtx.SessionID)
 	}
-	access := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	accessToken, err := access.SignedString(s.jwtSecret)
 	if err != nil {
-		return "", "", err
+		logger.Error("Failed to revoke session",
+			zap.String("user_id", authCtx.UserID),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("завершение сессии", err).ToGRPCError()
+	}
+
+	logger.Info("Logout successful",
+		zap.String("user_id", authCtx.UserID),
+		zap.Bool("all_devices", req.GetAllDevices()),
+	)
+
+	return &pb.LogoutResponse{
+		Success: true,
+		Message: "Вы вышли из системы",
+	}, nil
+}
+
+func (s *AuthServiceServer) ForgotPassword(ctx context.Context, req *pb.ForgotPasswordRequest) (*pb.ForgotPasswordResponse, error) {
+	if err := s.validateSendOTPRequest(req.GetPhone()); err != nil {
+		return nil, err.(*apperror.AppError).ToGRPCError()
+	}
+
+	phone := strings.TrimSpace(req.GetPhone())
+	resp := &pb.ForgotPasswordResponse{
+		Success: true,
+		Message: "Если номер зарегистрирован, на него отправлен код",
 	}
 
-	// refresh token with longer expiry
-	claims["exp"] = time.Now().Add(24 * time.Hour * 7).Unix()
-	refresh := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	refreshToken, err := refresh.SignedString(s.jwtSecret)
+	// Не раскрываем, зарегистрирован ли номер
+	var userID string
+	var isActive bool
+	err := s.db.QueryRowContext(ctx, "SELECT id, COALESCE(is_active, true) FROM users WHERE phone = $1", phone).Scan(&userID, &isActive)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !isActive) {
+		logger.Warn("Password reset requested for unknown or inactive user",
+			zap.String("phone", phone),
+		)
+		return resp, nil
+	}
 	if err != nil {
-		return "", "", err
+		logger.Error("Database error during password reset request",
+			zap.String("phone", phone),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("поиск пользователя", err).ToGRPCError()
+	}
+
+	code, err := s.otp.Issue(ctx, otp.PurposeResetPassword, phone)
+	if err != nil {
+		return nil, otpErrorToGRPC(err, phone)
+	}
+
+	if s.sms != nil {
+		if err := s.sms.SendOTP(phone, code); err != nil {
+			logger.Error("Failed to send password reset code via SMS",
+				zap.String("phone", phone),
+				zap.Error(err),
+			)
+			return nil, apperror.NewInternalError("Не удалось отправить SMS", err).ToGRPCError()
+		}
+	}
+
+	logger.Info("Password reset code sent",
+		zap.String("user_id", userID),
+	)
+
+	return resp, nil
+}
+
+func (s *AuthServiceServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	phone := strings.TrimSpace(req.GetPhone())
+	code := strings.TrimSpace(req.GetCode())
+	if err := s.validateResetPasswordRequest(phone, code, req.GetNewPassword()); err != nil {
+		return nil, err.(*apperror.AppError).ToGRPCError()
+	}
+
+	if err := s.otp.Verify(ctx, otp.PurposeResetPassword, phone, code); err != nil {
+		return nil, otpErrorToGRPC(err, phone)
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.GetNewPassword()), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, apperror.NewInternalError("Не удалось обработать пароль", err).ToGRPCError()
+	}
+
+	var userID string
+	err = s.db.QueryRowContext(ctx, `
+		UPDATE users SET password_hash = $1, phone_verified_at = COALESCE(phone_verified_at, NOW()), updated_at = NOW()
+		WHERE phone = $2 AND COALESCE(is_active, true)
+		RETURNING id
+	`, string(hashed), phone).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NewNotFoundError("Пользователь не найден").ToGRPCError()
+	}
+	if err != nil {
+		logger.Error("Failed to update password",
+			zap.String("phone", phone),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("обновление пароля", err).ToGRPCError()
+	}
+
+	// Все устройства должны войти заново с новым паролем
+	revoked, err := revokeAllSessions(ctx, s.db, userID, "")
+	if err == nil {
+		err = s.versions.Bump(ctx, userID)
+	}
+	if err == nil {
+		_, err = clearLoginFailures(ctx, s.db, userID)
+	}
+	if err != nil {
+		logger.Error("Failed to revoke sessions after password reset",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("завершение сессий", err).ToGRPCError()
+	}
+
+	logger.Info("Password reset successful",
+		zap.String("user_id", userID),
+		zap.Int64("revoked_sessions", revoked),
+	)
+
+	return &pb.ResetPasswordResponse{
+		Success: true,
+		Message: "Пароль изменен, войдите с новым паролем",
+	}, nil
+}
+
+func (s *AuthServiceServer) RequestDeviceTrust(ctx context.Context, req *pb.RequestDeviceTrustRequest) (*pb.RequestDeviceTrustResponse, error) {
+	authCtx := AuthFromContext(ctx)
+	if authCtx == nil || authCtx.SessionID == "" {
+		return nil, apperror.NewUnauthorizedError("Требуется авторизация").ToGRPCError()
+	}
+
+	var phone string
+	if err := s.db.QueryRowContext(ctx, "SELECT phone FROM users WHERE id = $1", authCtx.UserID).Scan(&phone); err != nil {
+		return nil, apperror.NewDatabaseError("поиск пользователя", err).ToGRPCError()
+	}
+
+	// Без PIN-кода доверенное устройство бесполезно
+	hasPin, err := hasStoredPin(ctx, s.db, authCtx.UserID)
+	if err != nil {
+		return nil, apperror.NewDatabaseError("проверка PIN-кода", err).ToGRPCError()
+	}
+	if !hasPin {
+		return nil, apperror.NewValidationError("Сначала установите PIN-код").ToGRPCError()
+	}
+
+	code, err := s.otp.Issue(ctx, otp.PurposeTrustDevice, phone)
+	if err != nil {
+		return nil, otpErrorToGRPC(err, phone)
+	}
+
+	if s.sms != nil {
+		if err := s.sms.SendOTP(phone, code); err != nil {
+			logger.Error("Failed to send device trust code via SMS",
+				zap.String("phone", phone),
+				zap.Error(err),
+			)
+			return nil, apperror.NewInternalError("Не удалось отправить SMS", err).ToGRPCError()
+		}
+	}
+
+	return &pb.RequestDeviceTrustResponse{
+		Success: true,
+		Message: "Код подтверждения отправлен",
+	}, nil
+}
+
+func (s *AuthServiceServer) TrustDevice(ctx context.Context, req *pb.TrustDeviceRequest) (*pb.TrustDeviceResponse, error) {
+	authCtx := AuthFromContext(ctx)
+	if authCtx == nil || authCtx.SessionID == "" {
+		return nil, apperror.NewUnauthorizedError("Требуется авторизация").ToGRPCError()
+	}
+	code := strings.TrimSpace(req.GetCode())
+	if code == "" {
+		return nil, apperror.NewValidationError("Код обязателен").ToGRPCError()
+	}
+
+	var phone string
+	if err := s.db.QueryRowContext(ctx, "SELECT phone FROM users WHERE id = $1", authCtx.UserID).Scan(&phone); err != nil {
+		return nil, apperror.NewDatabaseError("поиск пользователя", err).ToGRPCError()
+	}
+
+	if err := s.otp.Verify(ctx, otp.PurposeTrustDevice, phone, code); err != nil {
+		return nil, otpErrorToGRPC(err, phone)
+	}
+	if err := markPhoneVerified(ctx, s.db, authCtx.UserID, phone); err != nil {
+		return nil, apperror.NewDatabaseError("подтверждение номера", err).ToGRPCError()
+	}
+
+	deviceToken, trustedUntil, err := trustSession(ctx, s.db, authCtx.UserID, authCtx.SessionID, s.trustedDeviceTTL)
+	if errors.Is(err, errSessionNotFound) {
+		return nil, apperror.NewUnauthorizedError("Сессия завершена, войдите заново").ToGRPCError()
+	}
+	if err != nil {
+		logger.Error("Failed to trust device",
+			zap.String("user_id", authCtx.UserID),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("сохранение устройства", err).ToGRPCError()
+	}
+
+	logger.Info("Device trusted",
+		zap.String("user_id", authCtx.UserID),
+		zap.String("session_id", authCtx.SessionID),
+	)
+
+	return &pb.TrustDeviceResponse{
+		DeviceToken:  deviceToken,
+		TrustedUntil: timestamppb.New(trustedUntil),
+	}, nil
+}
+
+func (s *AuthServiceServer) LoginWithPin(ctx context.Context, req *pb.LoginWithPinRequest) (*pb.LoginResponse, error) {
+	deviceToken := strings.TrimSpace(req.GetDeviceToken())
+	pin := strings.TrimSpace(req.GetPin())
+	if deviceToken == "" || pin == "" {
+		return nil, apperror.NewValidationError("Токен устройства и PIN-код обязательны").ToGRPCError()
+	}
+
+	device := loginDevice(ctx, "", req.GetDeviceId())
+	sessionID, userID, err := findTrustedSession(ctx, s.db, deviceToken, device.ID)
+	if errors.Is(err, errDeviceNotTrusted) {
+		return nil, apperror.NewUnauthorizedError("Устройство не доверенное, войдите по паролю или коду").ToGRPCError()
+	}
+	if err != nil {
+		logger.Error("Database error during PIN login",
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("поиск сессии", err).ToGRPCError()
+	}
+
+	var mismatch *pinMismatchError
+	var locked *loginLockedError
+	err = checkPin(ctx, s.db, s.sms, userID, pin)
+	switch {
+	case err == nil:
+	case errors.As(err, &locked):
+		return nil, loginLockoutToGRPC(err, userID)
+	case errors.Is(err, errPinFormat), errors.Is(err, errPinNotSet):
+		return nil, apperror.NewUnauthorizedError("Неверный PIN-код").ToGRPCError()
+	case errors.Is(err, errPinLocked), errors.As(err, &mismatch) && mismatch.AttemptsLeft == 0:
+		logger.Warn("PIN login locked",
+			zap.String("user_id", userID),
+		)
+		return nil, apperror.NewForbiddenError("PIN-код заблокирован, войдите по паролю или коду").ToGRPCError()
+	case errors.As(err, &mismatch):
+		return nil, apperror.NewUnauthorizedError(
+			fmt.Sprintf("Неверный PIN-код, осталось попыток: %d", mismatch.AttemptsLeft),
+		).ToGRPCError()
+	default:
+		return nil, apperror.NewDatabaseError("проверка PIN-кода", err).ToGRPCError()
+	}
+
+	user, isActive, err := s.findUserByID(ctx, userID)
+	if err != nil || !isActive {
+		revokeSession(ctx, s.db, userID, sessionID)
+		return nil, apperror.NewUnauthorizedError("Учетная запись недоступна").ToGRPCError()
+	}
+
+	refresh, err := resumeTrustedSession(ctx, s.db, sessionID, s.trustedDeviceTTL)
+	if err != nil {
+		logger.Error("Failed to resume trusted session",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, apperror.NewDatabaseError("обновление сессии", err).ToGRPCError()
+	}
+	access, err := s.signAccessToken(ctx, user.ID, user.Phone, user.Role, sessionID, false)
+	if err != nil {
+		return nil, apperror.NewInternalError("Не удалось создать токен", err).ToGRPCError()
+	}
+
+	logger.Info("Login with PIN successful",
+		zap.String("user_id", userID),
+		zap.String("session_id", sessionID),
+	)
+
+	return &pb.LoginResponse{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		User:         mapper.ToPBUser(user),
+	}, nil
+}
+
+func (s *AuthServiceServer) VerifyMfaLogin(ctx context.Context, req *pb.VerifyMfaLoginRequest) (*pb.LoginResponse, error) {
+	code := strings.TrimSpace(req.GetCode())
+	if strings.TrimSpace(req.GetMfaToken()) == "" || code == "" {
+		return nil, apperror.NewValidationError("Токен и код обязательны").ToGRPCError()
+	}
+
+	challenge, err := s.parseMfaChallenge(req.GetMfaToken())
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("Время входа истекло, войдите заново").ToGRPCError()
+	}
+	userID := challenge.UserID
+
+	user, isActive, err := s.findUserByID(ctx, userID)
+	if err != nil || !isActive {
+		return nil, apperror.NewUnauthorizedError("Учетная запись недоступна").ToGRPCError()
+	}
+
+	// Заблокированная учетная запись не проверяет код вовсе
+	if err := checkLoginLockout(ctx, s.db, userID); err != nil {
+		return nil, loginLockoutToGRPC(err, userID)
+	}
+
+	// Попытка засчитывается до проверки кода; исчерпав их, нужно снова войти с паролем
+	if err := takeMfaChallengeAttempt(ctx, s.db, challenge.ID); err != nil {
+		return nil, mfaErrorToGRPC(err, userID)
+	}
+
+	if err := verifyMfaCode(ctx, s.db, s.mfaKey, userID, code); err != nil {
+		if errors.Is(err, errMfaInvalidCode) {
+			logger.Warn("MFA login failed: invalid code",
+				zap.String("user_id", userID),
+			)
+			if err := recordLoginFailure(ctx, s.db, s.sms, userID, loginMethodMfa); err != nil {
+				return nil, loginLockoutToGRPC(err, userID)
+			}
+		}
+		return nil, mfaErrorToGRPC(err, userID)
+	}
+
+	// Токен второго шага одноразовый
+	if err := closeMfaChallenge(ctx, s.db, challenge.ID); err != nil {
+		return nil, mfaErrorToGRPC(err, userID)
+	}
+	if _, err := clearLoginFailures(ctx, s.db, userID); err != nil {
+		logger.Error("Failed to clear failed login attempts",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+	}
+
+	access, refresh, err := s.issueTokens(ctx, user.ID, user.Phone, user.Role, loginDevice(ctx, challenge.DeviceName, challenge.DeviceID), true)
+	if err != nil {
+		logger.Error("Failed to issue tokens",
+			zap.String("user_id", user.ID),
+			zap.Error(err),
+		)
+		return nil, apperror.NewInternalError("Не удалось создать токен", err).ToGRPCError()
+	}
+
+	logger.Info("MFA login successful",
+		zap.String("user_id", user.ID),
+		zap.String("role", user.Role),
+	)
+
+	return &pb.LoginResponse{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		User:         mapper.ToPBUser(user),
+	}, nil
+}
+
+func (s *AuthServiceServer) SetupMfa(ctx context.Context, req *pb.SetupMfaRequest) (*pb.SetupMfaResponse, error) {
+	authCtx := AuthFromContext(ctx)
+	if authCtx == nil {
+		return nil, apperror.NewUnauthorizedError("Требуется авторизация").ToGRPCError()
+	}
+
+	var phone string
+	if err := s.db.QueryRowContext(ctx, "SELECT phone FROM users WHERE id = $1", authCtx.UserID).Scan(&phone); err != nil {
+		return nil, apperror.NewDatabaseError("поиск пользователя", err).ToGRPCError()
+	}
+
+	secret, err := beginMfaSetup(ctx, s.db, s.mfaKey, authCtx.UserID)
+	if err != nil {
+		return nil, mfaErrorToGRPC(err, authCtx.UserID)
+	}
+
+	return &pb.SetupMfaResponse{
+		Secret:          secret,
+		ProvisioningUri: totp.ProvisioningURI(secret, mfaIssuer, phone),
+	}, nil
+}
+
+func (s *AuthServiceServer) ConfirmMfa(ctx context.Context, req *pb.ConfirmMfaRequest) (*pb.ConfirmMfaResponse, error) {
+	authCtx := AuthFromContext(ctx)
+	if authCtx == nil || authCtx.SessionID == "" {
+		return nil, apperror.NewUnauthorizedError("Требуется авторизация").ToGRPCError()
+	}
+	code := strings.TrimSpace(req.GetCode())
+	if code == "" {
+		return nil, apperror.NewValidationError("Код обязателен").ToGRPCError()
+	}
+
+	recoveryCodes, err := confirmMfaSetup(ctx, s.db, s.mfaKey, authCtx.UserID, code)
+	if err != nil {
+		return nil, mfaErrorToGRPC(err, authCtx.UserID)
+	}
+
+	// Текущая сессия уже прошла второй фактор
+	user, _, err := s.findUserByID(ctx, authCtx.UserID)
+	if err != nil {
+		return nil, apperror.NewDatabaseError("поиск пользователя", err).ToGRPCError()
+	}
+	if err := markSessionMFA(ctx, s.db, user.ID, authCtx.SessionID); err != nil {
+		if errors.Is(err, errSessionNotFound) {
+			return nil, apperror.NewUnauthorizedError("Сессия завершена, войдите заново").ToGRPCError()
+		}
+		return nil, apperror.NewDatabaseError("обновление сессии", err).ToGRPCError()
+	}
+	access, err := s.signAccessToken(ctx, user.ID, user.Phone, user.Role, authCtx.SessionID, true)
+	if err != nil {
+		return nil, apperror.NewInternalError("Не удалось создать токен", err).ToGRPCError()
+	}
+
+	logger.Info("MFA enabled",
+		zap.String("user_id", user.ID),
+	)
+
+	return &pb.ConfirmMfaResponse{
+		RecoveryCodes: recoveryCodes,
+		AccessToken:   access,
+	}, nil
+}
+
+func (s *AuthServiceServer) DisableMfa(ctx context.Context, req *pb.DisableMfaRequest) (*pb.DisableMfaResponse, error) {
+	authCtx := AuthFromContext(ctx)
+	if authCtx == nil {
+		return nil, apperror.NewUnauthorizedError("Требуется авторизация").ToGRPCError()
+	}
+	if mfaRequiredForRole(authCtx.Role) {
+		return nil, apperror.NewForbiddenError("Для администраторов и модераторов двухфакторная аутентификация обязательна").ToGRPCError()
+	}
+
+	if err := verifyMfaCode(ctx, s.db, s.mfaKey, authCtx.UserID, req.GetCode()); err != nil {
+		return nil, mfaErrorToGRPC(err, authCtx.UserID)
+	}
+	if err := disableMfa(ctx, s.db, authCtx.UserID); err != nil {
+		return nil, apperror.NewDatabaseError("отключение двухфакторной аутентификации", err).ToGRPCError()
+	}
+
+	logger.Info("MFA disabled",
+		zap.String("user_id", authCtx.UserID),
+	)
+
+	return &pb.DisableMfaResponse{
+		Success: true,
+		Message: "Двухфакторная аутентификация отключена",
+	}, nil
+}
+
+// issueTokens creates a new device session and returns an access token bound to it
+// together with the session's first refresh token. mfa marks sessions that passed TOTP.
+func (s *AuthServiceServer) issueTokens(ctx context.Context, userID, phone, role string, device sessionDevice, mfa bool) (string, string, error) {
+	sessionID, refreshToken, err := createSession(ctx, s.db, userID, device, mfa)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Гостевая корзина этого устройства переходит в аккаунт; ошибка не мешает входу
+	if err := mergeGuestCart(ctx, s.db, device.ID, userID); err != nil {
+		logger.Error("Failed to merge guest cart",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+	}
+
+	accessToken, err := s.signAccessToken(ctx, userID, phone, role, sessionID, mfa)
+	if err != nil {
+		return "", "", err
+	}
+	return accessToken, refreshToken, nil
+}
+
+// signAccessToken signs a short-lived access JWT for the given session.
+// The mfa claim tells the auth interceptor the session passed the second factor;
+// ver is the user's token version, so bumping it revokes the token.
+func (s *AuthServiceServer) signAccessToken(ctx context.Context, userID, phone, role, sessionID string, mfa bool) (string, error) {
+	version, err := s.versions.Load(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"phone":   phone,
+		"role":    role,
+		"sid":     sessionID,
+		"mfa":     mfa,
+		"ver":     version,
+		"typ":     middleware.TokenTypeAccess,
+		"exp":     time.Now().Add(accessTokenTTL).Unix(),
+		"iat":     time.Now().Unix(),
+	}
+	return s.keys.Sign(claims)
+}
+
+// otpErrorToGRPC maps OTP store errors to client-facing gRPC errors.
+func otpErrorToGRPC(err error, phone string) error {
+	var cooldown *otp.CooldownError
+	switch {
+	case errors.As(err, &cooldown):
+		return apperror.NewRateLimitError(
+			fmt.Sprintf("Повторная отправка возможна через %d сек.", int(cooldown.RetryAfter.Seconds())+1),
+		).ToGRPCError()
+	case errors.Is(err, otp.ErrNotFound):
+		return apperror.NewValidationError("Код истек или не был запрошен").ToGRPCError()
+	case errors.Is(err, otp.ErrInvalidCode):
+		return apperror.NewValidationError("Неверный код").ToGRPCError()
+	case errors.Is(err, otp.ErrTooManyAttempts):
+		logger.Warn("OTP attempts exhausted",
+			zap.String("phone", phone),
+		)
+		return apperror.NewRateLimitError("Превышено количество попыток, запросите новый код").ToGRPCError()
+	case errors.Is(err, otp.ErrInvalidTicket):
+		return apperror.NewForbiddenError("Номер телефона не подтвержден").ToGRPCError()
+	default:
+		logger.Error("OTP store error",
+			zap.String("phone", phone),
+			zap.Error(err),
+		)
+		return apperror.NewInternalError("Ошибка проверки кода", err).ToGRPCError()
+	}
+}
+
+func (s *AuthServiceServer) AdminImpersonate(ctx context.Context, req *pb.AdminImpersonateRequest) (*pb.AdminImpersonateResponse, error) {
+	authCtx := AuthFromContext(ctx)
+	if authCtx == nil {
+		return nil, apperror.NewUnauthorizedError("Требуется авторизация").ToGRPCError()
+	}
+	if _, err := uuid.Parse(req.GetUserId()); err != nil {
+		return nil, apperror.NewValidationError("Неверный ID пользователя").ToGRPCError()
+	}
+	reason := truncate(strings.TrimSpace(req.GetReason()), 500)
+	if reason == "" {
+		return nil, apperror.NewValidationError("Укажите причину").ToGRPCError()
+	}
+	if req.GetUserId() == authCtx.UserID {
+		return nil, apperror.NewValidationError("Нельзя войти от имени самого себя").ToGRPCError()
+	}
+
+	user, isActive, err := s.findUserByID(ctx, req.GetUserId())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.NewNotFoundError("Пользователь не найден").ToGRPCError()
+	}
+	if err != nil {
+		return nil, apperror.NewDatabaseError("поиск пользователя", err).ToGRPCError()
+	}
+	if !isActive {
+		return nil, apperror.NewForbiddenError("Учетная запись деактивирована").ToGRPCError()
+	}
+	if mfaRequiredForRole(user.Role) {
+		return nil, apperror.NewForbiddenError("Нельзя войти от имени администратора или модератора").ToGRPCError()
+	}
+
+	token, expiresAt, err := s.signImpersonationToken(ctx, user, authCtx.UserID)
+	if err != nil {
+		return nil, apperror.NewInternalError("Не удалось создать токен", err).ToGRPCError()
+	}
+
+	// Без записи в журнале токен не выдается
+	err = recordAudit(ctx, s.db, auditEntry{
+		UserID:  user.ID,
+		ActorID: authCtx.UserID,
+		Action:  auditImpersonationStarted,
+		Details: map[string]any{"reason": reason, "expires_at": expiresAt},
+	})
+	if err != nil {
+		return nil, apperror.NewDatabaseError("запись в журнал аудита", err).ToGRPCError()
+	}
+
+	logger.Warn("Admin impersonation started",
+		zap.String("admin_id", authCtx.UserID),
+		zap.String("user_id", user.ID),
+		zap.String("reason", reason),
+	)
+
+	return &pb.AdminImpersonateResponse{
+		AccessToken: token,
+		ExpiresAt:   timestamppb.New(expiresAt),
+		User:        mapper.ToPBUser(user),
+	}, nil
+}
+
+func (s *AuthServiceServer) AdminListLoginLockouts(ctx context.Context, req *pb.AdminListLoginLockoutsRequest) (*pb.AdminListLoginLockoutsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	page := max(int(req.GetPage()), 1)
+
+	where := ""
+	if req.GetLockedOnly() {
+		where = "WHERE l.locked_until > NOW()"
+	}
+
+	var total int32
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM login_lockouts l `+where).Scan(&total)
+	if err != nil {
+		return nil, apperror.NewDatabaseError("подсчет блокировок", err).ToGRPCError()
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT l.user_id, u.phone, u.full_name, l.failed_attempts, l.last_method, l.last_failed_at, l.locked_until
+		FROM login_lockouts l
+		JOIN users u ON u.id = l.user_id
+		`+where+`
+		ORDER BY l.last_failed_at DESC
+		LIMIT $1 OFFSET $2
+	`, limit, (page-1)*limit)
+	if err != nil {
+		return nil, apperror.NewDatabaseError("получение блокировок", err).ToGRPCError()
+	}
+	defer rows.Close()
+
+	resp := &pb.AdminListLoginLockoutsResponse{Total: total, Page: int32(page), Limit: int32(limit)}
+	for rows.Next() {
+		var lockout models.LoginLockout
+		var lockedUntil sql.NullTime
+		err := rows.Scan(&lockout.UserID, &lockout.Phone, &lockout.FullName, &lockout.FailedAttempts,
+			&lockout.LastMethod, &lockout.LastFailedAt, &lockedUntil)
+		if err != nil {
+			return nil, apperror.NewDatabaseError("чтение блокировок", err).ToGRPCError()
+		}
+		if lockedUntil.Valid {
+			lockout.LockedUntil = &lockedUntil.Time
+		}
+		resp.Lockouts = append(resp.Lockouts, mapper.ToPBLoginLockout(lockout))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewDatabaseError("чтение блокировок", err).ToGRPCError()
+	}
+	return resp, nil
+}
+
+func (s *AuthServiceServer) AdminClearLoginLockout(ctx context.Context, req *pb.AdminClearLoginLockoutRequest) (*pb.AdminClearLoginLockoutResponse, error) {
+	authCtx := AuthFromContext(ctx)
+	if authCtx == nil {
+		return nil, apperror.NewUnauthorizedError("Требуется авторизация").ToGRPCError()
+	}
+	if _, err := uuid.Parse(req.GetUserId()); err != nil {
+		return nil, apperror.NewValidationError("Неверный ID пользователя").ToGRPCError()
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperror.NewDatabaseError("снятие блокировки", err).ToGRPCError()
+	}
+	defer tx.Rollback()
+
+	cleared, err := clearLoginFailures(ctx, tx, req.GetUserId())
+	if err != nil {
+		return nil, apperror.NewDatabaseError("снятие блокировки", err).ToGRPCError()
+	}
+	if !cleared {
+		return nil, apperror.NewNotFoundError("Блокировка не найдена").ToGRPCError()
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		UserID:  req.GetUserId(),
+		ActorID: authCtx.UserID,
+		Action:  auditLoginLockoutCleared,
+	})
+	if err != nil {
+		return nil, apperror.NewDatabaseError("запись в журнал аудита", err).ToGRPCError()
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, apperror.NewDatabaseError("снятие блокировки", err).ToGRPCError()
+	}
+
+	logger.Info("Login lockout cleared",
+		zap.String("admin_id", authCtx.UserID),
+		zap.String("user_id", req.GetUserId()),
+	)
+
+	return &pb.AdminClearLoginLockoutResponse{
+		Success: true,
+		Message: "Блокировка снята",
+	}, nil
+}
+
+// loginLockoutToGRPC maps login lockout errors to client-facing gRPC errors.
+func loginLockoutToGRPC(err error, userID string) error {
+	var locked *loginLockedError
+	if errors.As(err, &locked) {
+		wait := locked.RetryAfter
+		message := fmt.Sprintf("Слишком много неудачных попыток, повторите через %d сек", int(wait.Seconds()))
+		if wait > time.Minute {
+			message = fmt.Sprintf("Слишком много неудачных попыток, повторите через %d мин", int((wait+time.Minute-1)/time.Minute))
+		}
+		return apperror.NewRateLimitError(message).ToGRPCError()
+	}
+	logger.Error("Login lockout error",
+		zap.String("user_id", userID),
+		zap.Error(err),
+	)
+	return apperror.NewDatabaseError("проверка блокировки входа", err).ToGRPCError()
+}
+
+// mfaErrorToGRPC maps MFA errors to client-facing gRPC errors.
+func mfaErrorToGRPC(err error, userID string) error {
+	switch {
+	case errors.Is(err, errMfaInvalidCode):
+		return apperror.NewUnauthorizedError("Неверный код").ToGRPCError()
+	case errors.Is(err, errMfaNotEnabled):
+		return apperror.NewValidationError("Двухфакторная аутентификация не включена").ToGRPCError()
+	case errors.Is(err, errMfaAlreadyEnabled):
+		return apperror.NewValidationError("Двухфакторная аутентификация уже включена").ToGRPCError()
+	case errors.Is(err, errMfaSetupNotStarted):
+		return apperror.NewValidationError("Сначала начните настройку двухфакторной аутентификации").ToGRPCError()
+	case errors.Is(err, errMfaChallengeClosed):
+		return apperror.NewUnauthorizedError("Время входа истекло, войдите заново").ToGRPCError()
+	default:
+		logger.Error("MFA error",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return apperror.NewInternalError("Ошибка двухфакторной аутентификации", err).ToGRPCError()
 	}
-	return accessToken, refreshToken, nil
 }
 
 // AuthFromContext is a helper for service methods needing user context.
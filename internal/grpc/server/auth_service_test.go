@@ -2,15 +2,31 @@ package server
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"regexp"
 	"testing"
+	"time"
 
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/email"
+	"mebellar-backend/pkg/jwtkeys"
+	"mebellar-backend/pkg/otp"
 	"mebellar-backend/pkg/pb"
 	"mebellar-backend/pkg/sms"
 	"mebellar-backend/pkg/testutil"
+	"mebellar-backend/pkg/totp"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestAuthService_Register(t *testing.T) {
@@ -22,7 +38,8 @@ func TestAuthService_Register(t *testing.T) {
 
 	// Создаем auth service
 	jwtSecret := []byte("test-secret-key-for-testing-32chars")
-	authService := NewAuthServiceServer(db, jwtSecret, mockSMS)
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
 
 	tests := []struct {
 		name        string
@@ -85,6 +102,9 @@ func TestAuthService_Register(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
+			if !tt.wantErr {
+				tt.request.VerificationToken = verifyPhone(t, authService, mockSMS, tt.request.Phone)
+			}
 			resp, err := authService.Register(ctx, tt.request)
 
 			if tt.wantErr {
@@ -109,7 +129,8 @@ func TestAuthService_Login(t *testing.T) {
 
 	mockSMS := &sms.MockSMSService{}
 	jwtSecret := []byte("test-secret-key-for-testing-32chars")
-	authService := NewAuthServiceServer(db, jwtSecret, mockSMS)
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
 
 	// Подготовка: создаем тестового пользователя
 	password := "testpassword123"
@@ -188,7 +209,8 @@ func TestAuthService_SendOTP(t *testing.T) {
 
 	mockSMS := &sms.MockSMSService{}
 	jwtSecret := []byte("test-secret-key-for-testing-32chars")
-	authService := NewAuthServiceServer(db, jwtSecret, mockSMS)
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
 
 	tests := []struct {
 		name        string
@@ -241,24 +263,1014 @@ func TestAuthService_DuplicateRegistration(t *testing.T) {
 
 	mockSMS := &sms.MockSMSService{}
 	jwtSecret := []byte("test-secret-key-for-testing-32chars")
-	authService := NewAuthServiceServer(db, jwtSecret, mockSMS)
+	jwtKeys := testKeyRing(t)
+	otpStore := otp.NewMemoryStore(jwtSecret, otp.DefaultConfig())
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otpStore)
 
 	// Первая регистрация
 	ctx := context.Background()
 	registerReq := &pb.RegisterRequest{
-		FullName: "Test User",
-		Phone:    "+998901234567",
-		Password: "password123",
-		Role:     "customer",
+		FullName:          "Test User",
+		Phone:             "+998901234567",
+		Password:          "password123",
+		Role:              "customer",
+		VerificationToken: verifyPhone(t, authService, mockSMS, "+998901234567"),
 	}
 
 	resp1, err := authService.Register(ctx, registerReq)
 	require.NoError(t, err)
 	assert.NotNil(t, resp1)
 
-	// Попытка повторной регистрации
+	// Попытка повторной регистрации (с новым тикетом, минуя SendOTP)
+	registerReq.VerificationToken, err = otpStore.IssueTicket(ctx, otp.PurposeRegister, registerReq.Phone)
+	require.NoError(t, err)
 	resp2, err := authService.Register(ctx, registerReq)
 	require.Error(t, err)
 	assert.Nil(t, resp2)
 	assert.Contains(t, err.Error(), "зарегистрирован")
 }
+
+func TestAuthService_RegisterRequiresVerifiedPhone(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+
+	ctx := context.Background()
+	registerReq := &pb.RegisterRequest{
+		FullName: "Test User",
+		Phone:    "+998901234567",
+		Password: "password123",
+	}
+
+	// Без тикета
+	_, err := authService.Register(ctx, registerReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "не подтвержден")
+
+	// Тикет выдан для другого номера
+	registerReq.VerificationToken = verifyPhone(t, authService, mockSMS, "+998907654321")
+	_, err = authService.Register(ctx, registerReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "не подтвержден")
+
+	// Тикет одноразовый
+	registerReq.VerificationToken = verifyPhone(t, authService, mockSMS, registerReq.Phone)
+	_, err = authService.Register(ctx, registerReq)
+	require.NoError(t, err)
+	_, err = authService.Register(ctx, registerReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "не подтвержден")
+}
+
+func TestAuthService_VerifyOTP(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+
+	ctx := context.Background()
+	phone := "+998901234567"
+
+	_, err := authService.SendOTP(ctx, &pb.SendOTPRequest{Phone: phone})
+	require.NoError(t, err)
+	code := mockSMS.LastCode
+
+	// Повторная отправка блокируется cooldown
+	_, err = authService.SendOTP(ctx, &pb.SendOTPRequest{Phone: phone})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Повторная отправка")
+
+	_, err = authService.VerifyOTP(ctx, &pb.VerifyOTPRequest{Phone: phone, Code: "00000" + code})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Неверный код")
+
+	resp, err := authService.VerifyOTP(ctx, &pb.VerifyOTPRequest{Phone: phone, Code: code})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.NotEmpty(t, resp.VerificationToken)
+
+	// Код одноразовый
+	_, err = authService.VerifyOTP(ctx, &pb.VerifyOTPRequest{Phone: phone, Code: code})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "истек")
+}
+
+func TestAuthService_RefreshTokenRotation(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	registerResp, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+
+	// Первая ротация выдает новый refresh token
+	first, err := authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registerResp.RefreshToken})
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.AccessToken)
+	assert.NotEqual(t, registerResp.RefreshToken, first.RefreshToken)
+
+	// Повторное использование старого токена отзывает сессию
+	_, err = authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registerResp.RefreshToken})
+	require.Error(t, err)
+
+	// ...вместе с токеном, выданным при ротации
+	_, err = authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: first.RefreshToken})
+	require.Error(t, err)
+
+	// Access token в качестве refresh token не принимается
+	_, err = authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registerResp.AccessToken})
+	require.Error(t, err)
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	registerResp, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+
+	// Неизвестный номер: ответ тот же, SMS не отправляется
+	mockSMS.LastCode = ""
+	resp, err := authService.ForgotPassword(ctx, &pb.ForgotPasswordRequest{Phone: "+998907654321"})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Empty(t, mockSMS.LastCode)
+
+	_, err = authService.ForgotPassword(ctx, &pb.ForgotPasswordRequest{Phone: phone})
+	require.NoError(t, err)
+	code := mockSMS.LastCode
+	require.NotEmpty(t, code)
+
+	// Код регистрации не подходит для сброса пароля и наоборот
+	_, err = authService.VerifyOTP(ctx, &pb.VerifyOTPRequest{Phone: phone, Code: code})
+	require.Error(t, err)
+
+	_, err = authService.ResetPassword(ctx, &pb.ResetPasswordRequest{Phone: phone, Code: code, NewPassword: "newPassword456"})
+	require.NoError(t, err)
+
+	// Старые сессии отозваны
+	_, err = authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registerResp.RefreshToken})
+	require.Error(t, err)
+
+	_, err = authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: "password123"})
+	require.Error(t, err)
+	_, err = authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: "newPassword456"})
+	require.NoError(t, err)
+
+	// Код одноразовый
+	_, err = authService.ResetPassword(ctx, &pb.ResetPasswordRequest{Phone: phone, Code: code, NewPassword: "otherPassword789"})
+	require.Error(t, err)
+}
+
+func TestAuthService_LoginWithCode(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	cfg := otp.DefaultConfig()
+	cfg.ResendCooldown = 0
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, cfg))
+
+	ctx := context.Background()
+	phone := "+998901234567"
+
+	// Неизвестный номер - аккаунт создается автоматически
+	_, err := authService.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{Phone: phone})
+	require.NoError(t, err)
+
+	_, err = authService.LoginWithCode(ctx, &pb.LoginWithCodeRequest{Phone: phone, Code: "0" + mockSMS.LastCode})
+	require.Error(t, err)
+
+	resp, err := authService.LoginWithCode(ctx, &pb.LoginWithCodeRequest{Phone: phone, Code: mockSMS.LastCode})
+	require.NoError(t, err)
+	assert.True(t, resp.IsNewUser)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.Equal(t, "customer", resp.User.Role)
+
+	// Повторный вход - тот же пользователь
+	_, err = authService.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{Phone: phone})
+	require.NoError(t, err)
+	again, err := authService.LoginWithCode(ctx, &pb.LoginWithCodeRequest{Phone: phone, Code: mockSMS.LastCode})
+	require.NoError(t, err)
+	assert.False(t, again.IsNewUser)
+	assert.Equal(t, resp.User.Id, again.User.Id)
+
+	// Беспарольный аккаунт нельзя открыть пустым паролем
+	_, err = authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: "password123"})
+	require.Error(t, err)
+}
+
+func TestUserService_DeviceSessions(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+	userService := NewUserServiceServer(db, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()), nil)
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	_, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+
+	// Вход с телефона (метаданные устройства из заголовков)
+	deviceCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(
+		"x-device-os", "iOS",
+		"x-app-type", "client",
+		"x-app-version", "1.0.0",
+	))
+	phoneLogin, err := authService.Login(deviceCtx, &pb.LoginRequest{
+		Phone: phone, Password: "password123", DeviceName: "iPhone 15", DeviceId: "device-1",
+	})
+	require.NoError(t, err)
+
+	listReq := &pb.ListSessionsRequest{}
+	sessions, err := callAuthenticated(t, jwtKeys, db, phoneLogin.AccessToken, pb.UserService_ListSessions_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return userService.ListSessions(ctx, listReq)
+	})
+	require.NoError(t, err)
+	list := sessions.(*pb.ListSessionsResponse).Sessions
+	require.Len(t, list, 2) // регистрация + вход
+
+	var current *pb.Session
+	for _, session := range list {
+		if session.IsCurrent {
+			current = session
+		}
+	}
+	require.NotNil(t, current)
+	assert.Equal(t, "iPhone 15", current.DeviceName)
+	assert.Equal(t, "iOS", current.DeviceOs)
+	assert.Equal(t, "client", current.AppType)
+
+	// Повторный вход с того же устройства заменяет сессию
+	_, err = authService.Login(ctx, &pb.LoginRequest{
+		Phone: phone, Password: "password123", DeviceName: "iPhone 15", DeviceId: "device-2",
+	})
+	require.NoError(t, err)
+	phoneLogin, err = authService.Login(deviceCtx, &pb.LoginRequest{
+		Phone: phone, Password: "password123", DeviceName: "iPhone 15", DeviceId: "device-1",
+	})
+	require.NoError(t, err)
+
+	revokeOthersReq := &pb.RevokeOtherSessionsRequest{}
+	revoked, err := callAuthenticated(t, jwtKeys, db, phoneLogin.AccessToken, pb.UserService_RevokeOtherSessions_FullMethodName, revokeOthersReq, func(ctx context.Context) (interface{}, error) {
+		return userService.RevokeOtherSessions(ctx, revokeOthersReq)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), revoked.(*pb.RevokeOtherSessionsResponse).RevokedCount)
+
+	// Выданные access токены отозваны, текущее устройство получает новый через refresh
+	_, err = callAuthenticated(t, jwtKeys, db, phoneLogin.AccessToken, pb.UserService_ListSessions_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return userService.ListSessions(ctx, listReq)
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	refreshed, err := authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: phoneLogin.RefreshToken})
+	require.NoError(t, err)
+
+	// Чужую или несуществующую сессию отозвать нельзя
+	revokeReq := &pb.RevokeSessionRequest{SessionId: "00000000-0000-0000-0000-000000000000"}
+	_, err = callAuthenticated(t, jwtKeys, db, refreshed.AccessToken, pb.UserService_RevokeSession_FullMethodName, revokeReq, func(ctx context.Context) (interface{}, error) {
+		return userService.RevokeSession(ctx, revokeReq)
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Отозванное устройство теряет и access токен
+	tablet, err := authService.Login(ctx, &pb.LoginRequest{
+		Phone: phone, Password: "password123", DeviceName: "iPad", DeviceId: "device-3",
+	})
+	require.NoError(t, err)
+	sessions, err = callAuthenticated(t, jwtKeys, db, tablet.AccessToken, pb.UserService_ListSessions_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return userService.ListSessions(ctx, listReq)
+	})
+	require.NoError(t, err)
+	for _, session := range sessions.(*pb.ListSessionsResponse).Sessions {
+		if session.IsCurrent {
+			revokeReq = &pb.RevokeSessionRequest{SessionId: session.Id}
+		}
+	}
+	_, err = callAuthenticated(t, jwtKeys, db, refreshed.AccessToken, pb.UserService_RevokeSession_FullMethodName, revokeReq, func(ctx context.Context) (interface{}, error) {
+		return userService.RevokeSession(ctx, revokeReq)
+	})
+	require.NoError(t, err)
+	_, err = callAuthenticated(t, jwtKeys, db, tablet.AccessToken, pb.UserService_ListSessions_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return userService.ListSessions(ctx, listReq)
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestUserService_TokenRevocation(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	versions := middleware.NewTokenVersions(db, nil)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+	authService.SetTokenVersions(versions)
+	userService := NewUserServiceServer(db, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()), nil)
+	userService.SetTokenVersions(versions)
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	registered, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+
+	getProfile := func(token string) error {
+		req := &pb.GetProfileRequest{}
+		_, err := callAuthenticated(t, jwtKeys, db, token, pb.UserService_GetProfile_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return userService.GetProfile(ctx, req)
+		})
+		return err
+	}
+	require.NoError(t, getProfile(registered.AccessToken))
+
+	// Смена роли отзывает токен, после refresh в токене новая роль
+	role := models.RoleSeller
+	_, err = userService.AdminUpdateUser(ctx, &pb.AdminUpdateUserRequest{Id: registered.User.Id, Role: &role})
+	require.NoError(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(getProfile(registered.AccessToken)))
+
+	refreshed, err := authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: registered.RefreshToken})
+	require.NoError(t, err)
+	require.NoError(t, getProfile(refreshed.AccessToken))
+
+	// Деактивация: токен отозван, refresh больше не работает
+	active := false
+	_, err = userService.AdminUpdateUser(ctx, &pb.AdminUpdateUserRequest{Id: registered.User.Id, IsActive: &active})
+	require.NoError(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(getProfile(refreshed.AccessToken)))
+	_, err = authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestUserService_Pin(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+	userService := NewUserServiceServer(db, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()), nil)
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	registered, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+	token := registered.AccessToken
+
+	call := func(method string, req interface{}, fn func(ctx context.Context) (interface{}, error)) error {
+		_, err := callAuthenticated(t, jwtKeys, db, token, method, req, fn)
+		return err
+	}
+	verify := func(pin string) error {
+		req := &pb.VerifyPinRequest{Pin: pin}
+		return call(pb.UserService_VerifyPin_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return userService.VerifyPin(ctx, req)
+		})
+	}
+
+	setReq := &pb.SetPinRequest{Pin: "1234"}
+	require.NoError(t, call(pb.UserService_SetPin_FullMethodName, setReq, func(ctx context.Context) (interface{}, error) {
+		return userService.SetPin(ctx, setReq)
+	}))
+	require.NoError(t, verify("1234"))
+
+	// Повторный SetPin не перезаписывает PIN
+	err = call(pb.UserService_SetPin_FullMethodName, setReq, func(ctx context.Context) (interface{}, error) {
+		return userService.SetPin(ctx, setReq)
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	changeReq := &pb.ChangePinRequest{OldPin: "1234", NewPin: "5678"}
+	require.NoError(t, call(pb.UserService_ChangePin_FullMethodName, changeReq, func(ctx context.Context) (interface{}, error) {
+		return userService.ChangePin(ctx, changeReq)
+	}))
+	assert.Equal(t, codes.PermissionDenied, status.Code(verify("1234")))
+	require.NoError(t, verify("5678"))
+
+	// После pinMaxAttempts ошибок PIN блокируется даже для верного значения
+	for i := 0; i < pinMaxAttempts; i++ {
+		require.Error(t, verify("0000"))
+	}
+	assert.Equal(t, codes.FailedPrecondition, status.Code(verify("5678")))
+
+	// Разблокировка через SMS код
+	resetReq := &pb.RequestPinResetRequest{}
+	require.NoError(t, call(pb.UserService_RequestPinReset_FullMethodName, resetReq, func(ctx context.Context) (interface{}, error) {
+		return userService.RequestPinReset(ctx, resetReq)
+	}))
+	assert.Equal(t, phone, mockSMS.LastPhone)
+
+	pinReq := &pb.ResetPinRequest{Code: mockSMS.LastCode, NewPin: "4321"}
+	require.NoError(t, call(pb.UserService_ResetPin_FullMethodName, pinReq, func(ctx context.Context) (interface{}, error) {
+		return userService.ResetPin(ctx, pinReq)
+	}))
+	require.NoError(t, verify("4321"))
+}
+
+func TestAuthService_TrustedDeviceLogin(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	otpStore := otp.NewMemoryStore(jwtSecret, otp.DefaultConfig())
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otpStore)
+	userService := NewUserServiceServer(db, mockSMS, otpStore, nil)
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	_, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+
+	login, err := authService.Login(ctx, &pb.LoginRequest{
+		Phone: phone, Password: "password123", DeviceName: "iPhone 15", DeviceId: "device-1",
+	})
+	require.NoError(t, err)
+
+	// Без PIN-кода устройство не доверяется
+	trustReq := &pb.RequestDeviceTrustRequest{}
+	requestTrust := func(ctx context.Context) (interface{}, error) {
+		return authService.RequestDeviceTrust(ctx, trustReq)
+	}
+	_, err = callAuthenticated(t, jwtKeys, db, login.AccessToken, pb.AuthService_RequestDeviceTrust_FullMethodName, trustReq, requestTrust)
+	require.Error(t, err)
+
+	setReq := &pb.SetPinRequest{Pin: "1234"}
+	_, err = callAuthenticated(t, jwtKeys, db, login.AccessToken, pb.UserService_SetPin_FullMethodName, setReq, func(ctx context.Context) (interface{}, error) {
+		return userService.SetPin(ctx, setReq)
+	})
+	require.NoError(t, err)
+
+	_, err = callAuthenticated(t, jwtKeys, db, login.AccessToken, pb.AuthService_RequestDeviceTrust_FullMethodName, trustReq, requestTrust)
+	require.NoError(t, err)
+
+	confirmReq := &pb.TrustDeviceRequest{Code: mockSMS.LastCode}
+	trusted, err := callAuthenticated(t, jwtKeys, db, login.AccessToken, pb.AuthService_TrustDevice_FullMethodName, confirmReq, func(ctx context.Context) (interface{}, error) {
+		return authService.TrustDevice(ctx, confirmReq)
+	})
+	require.NoError(t, err)
+	deviceToken := trusted.(*pb.TrustDeviceResponse).DeviceToken
+	require.NotEmpty(t, deviceToken)
+
+	// Токен работает только с того же устройства и с верным PIN-кодом
+	_, err = authService.LoginWithPin(ctx, &pb.LoginWithPinRequest{DeviceToken: deviceToken, Pin: "1234", DeviceId: "device-2"})
+	require.Error(t, err)
+	_, err = authService.LoginWithPin(ctx, &pb.LoginWithPinRequest{DeviceToken: deviceToken, Pin: "0000", DeviceId: "device-1"})
+	require.Error(t, err)
+
+	pinLogin, err := authService.LoginWithPin(ctx, &pb.LoginWithPinRequest{DeviceToken: deviceToken, Pin: "1234", DeviceId: "device-1"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, pinLogin.AccessToken)
+	assert.Equal(t, phone, pinLogin.User.Phone)
+
+	// Старый refresh token сессии больше не действует
+	_, err = authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	require.Error(t, err)
+
+	listReq := &pb.ListSessionsRequest{}
+	sessions, err := callAuthenticated(t, jwtKeys, db, pinLogin.AccessToken, pb.UserService_ListSessions_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return userService.ListSessions(ctx, listReq)
+	})
+	require.NoError(t, err)
+	var current *pb.Session
+	for _, session := range sessions.(*pb.ListSessionsResponse).Sessions {
+		if session.IsCurrent {
+			current = session
+		}
+	}
+	require.NotNil(t, current)
+	assert.True(t, current.IsTrusted)
+	assert.NotNil(t, current.TrustedUntil)
+
+	// После отзыва доверия вход по PIN-коду недоступен
+	untrustReq := &pb.UntrustSessionRequest{SessionId: current.Id}
+	_, err = callAuthenticated(t, jwtKeys, db, pinLogin.AccessToken, pb.UserService_UntrustSession_FullMethodName, untrustReq, func(ctx context.Context) (interface{}, error) {
+		return userService.UntrustSession(ctx, untrustReq)
+	})
+	require.NoError(t, err)
+
+	_, err = authService.LoginWithPin(ctx, &pb.LoginWithPinRequest{DeviceToken: deviceToken, Pin: "1234", DeviceId: "device-1"})
+	require.Error(t, err)
+}
+
+func TestUserService_PhoneChange(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	otpStore := otp.NewMemoryStore(jwtSecret, otp.DefaultConfig())
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otpStore)
+	userService := NewUserServiceServer(db, mockSMS, otpStore, nil)
+	userService.SetPhoneChangeConfirmOld(true)
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	newPhone := "+998907654321"
+	_, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+
+	current, err := authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: "password123", DeviceId: "device-1"})
+	require.NoError(t, err)
+	other, err := authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: "password123", DeviceId: "device-2"})
+	require.NoError(t, err)
+
+	// Без запроса подтверждать нечего
+	verifyReq := &pb.VerifyPhoneChangeRequest{NewPhone: newPhone, Code: "12345"}
+	verify := func(ctx context.Context) (interface{}, error) {
+		return userService.VerifyPhoneChange(ctx, verifyReq)
+	}
+	_, err = callAuthenticated(t, jwtKeys, db, current.AccessToken, pb.UserService_VerifyPhoneChange_FullMethodName, verifyReq, verify)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Коды уходят на новый и на текущий номер
+	requestReq := &pb.RequestPhoneChangeRequest{NewPhone: newPhone}
+	resp, err := callAuthenticated(t, jwtKeys, db, current.AccessToken, pb.UserService_RequestPhoneChange_FullMethodName, requestReq, func(ctx context.Context) (interface{}, error) {
+		return userService.RequestPhoneChange(ctx, requestReq)
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.(*pb.RequestPhoneChangeResponse).OldPhoneCodeRequired)
+	sent := mockSMS.Codes
+	require.Contains(t, sent, newPhone)
+	require.Contains(t, sent, phone)
+	newCode, oldCode := sent[newPhone], sent[phone]
+
+	// Запрос того же номера другим пользователем не заменяет и не блокирует коды
+	rivalPhone := "+998901234568"
+	_, err = authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Rival",
+		Phone:             rivalPhone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, rivalPhone),
+	})
+	require.NoError(t, err)
+	rival, err := authService.Login(ctx, &pb.LoginRequest{Phone: rivalPhone, Password: "password123", DeviceId: "device-3"})
+	require.NoError(t, err)
+	_, err = callAuthenticated(t, jwtKeys, db, rival.AccessToken, pb.UserService_RequestPhoneChange_FullMethodName, requestReq, func(ctx context.Context) (interface{}, error) {
+		return userService.RequestPhoneChange(ctx, requestReq)
+	})
+	require.NoError(t, err)
+
+	// Неверный код не меняет номер
+	verifyReq = &pb.VerifyPhoneChangeRequest{NewPhone: newPhone, Code: "00000", OldPhoneCode: oldCode}
+	_, err = callAuthenticated(t, jwtKeys, db, current.AccessToken, pb.UserService_VerifyPhoneChange_FullMethodName, verifyReq, verify)
+	require.Error(t, err)
+
+	// Неверный код старого номера не сжигает код нового
+	verifyReq = &pb.VerifyPhoneChangeRequest{NewPhone: newPhone, Code: newCode, OldPhoneCode: "00000"}
+	_, err = callAuthenticated(t, jwtKeys, db, current.AccessToken, pb.UserService_VerifyPhoneChange_FullMethodName, verifyReq, verify)
+	require.Error(t, err)
+
+	verifyReq = &pb.VerifyPhoneChangeRequest{NewPhone: newPhone, Code: newCode, OldPhoneCode: oldCode}
+	updated, err := callAuthenticated(t, jwtKeys, db, current.AccessToken, pb.UserService_VerifyPhoneChange_FullMethodName, verifyReq, verify)
+	require.NoError(t, err)
+	assert.Equal(t, newPhone, updated.(*pb.VerifyPhoneChangeResponse).User.Phone)
+
+	// Остальные сессии завершены, текущая продолжает работать
+	_, err = authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: other.RefreshToken})
+	require.Error(t, err)
+	_, err = authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: current.RefreshToken})
+	require.NoError(t, err)
+
+	var audited int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM audit_logs WHERE action = $1`, auditPhoneChanged).Scan(&audited))
+	assert.Equal(t, 1, audited)
+}
+
+func TestUserService_EmailChange(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	mockEmail := &email.MockSender{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	otpStore := otp.NewMemoryStore(jwtSecret, otp.DefaultConfig())
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otpStore)
+	userService := NewUserServiceServer(db, mockSMS, otpStore, mockEmail)
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	registered, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+
+	requestReq := &pb.RequestEmailChangeRequest{NewEmail: "User@Example.com"}
+	_, err = callAuthenticated(t, jwtKeys, db, registered.AccessToken, pb.UserService_RequestEmailChange_FullMethodName, requestReq, func(ctx context.Context) (interface{}, error) {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("accept-language", "ru-RU"))
+		return userService.RequestEmailChange(ctx, requestReq)
+	})
+	require.NoError(t, err)
+
+	// Письмо на русском с кодом подтверждения
+	sent, ok := mockEmail.Last()
+	require.True(t, ok)
+	assert.Equal(t, "user@example.com", sent.To)
+	assert.Equal(t, "Подтверждение email", sent.Subject)
+	code := regexp.MustCompile(`\d{5}`).FindString(sent.Text)
+	require.NotEmpty(t, code)
+
+	verifyReq := &pb.VerifyEmailChangeRequest{NewEmail: "user@example.com", Code: "00000"}
+	verify := func(ctx context.Context) (interface{}, error) {
+		return userService.VerifyEmailChange(ctx, verifyReq)
+	}
+	_, err = callAuthenticated(t, jwtKeys, db, registered.AccessToken, pb.UserService_VerifyEmailChange_FullMethodName, verifyReq, verify)
+	require.Error(t, err)
+
+	verifyReq = &pb.VerifyEmailChangeRequest{NewEmail: "user@example.com", Code: code}
+	updated, err := callAuthenticated(t, jwtKeys, db, registered.AccessToken, pb.UserService_VerifyEmailChange_FullMethodName, verifyReq, verify)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", updated.(*pb.VerifyEmailChangeResponse).User.Email)
+
+	// Код одноразовый
+	_, err = callAuthenticated(t, jwtKeys, db, registered.AccessToken, pb.UserService_VerifyEmailChange_FullMethodName, verifyReq, verify)
+	require.Error(t, err)
+}
+
+func TestAuthService_Mfa(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	otpStore := otp.NewMemoryStore(jwtSecret, otp.DefaultConfig())
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otpStore)
+	authService.SetMFAKey(jwtSecret)
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	registered, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Admin User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+	_, err = db.Exec("UPDATE users SET role = $1 WHERE id = $2", models.RoleAdmin, registered.User.Id)
+	require.NoError(t, err)
+
+	adminPanel := func(accessToken string) error {
+		_, err := callAuthenticated(t, jwtKeys, db, accessToken, pb.UserService_AdminListUsers_FullMethodName, &pb.AdminListUsersRequest{}, func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+
+	// Администратор без 2FA входит, но админ-панель закрыта до настройки
+	login, err := authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: "password123"})
+	require.NoError(t, err)
+	assert.True(t, login.MfaEnrollmentRequired)
+	assert.Equal(t, codes.PermissionDenied, status.Code(adminPanel(login.AccessToken)))
+
+	setupReq := &pb.SetupMfaRequest{}
+	setup, err := callAuthenticated(t, jwtKeys, db, login.AccessToken, pb.AuthService_SetupMfa_FullMethodName, setupReq, func(ctx context.Context) (interface{}, error) {
+		return authService.SetupMfa(ctx, setupReq)
+	})
+	require.NoError(t, err)
+	secret := setup.(*pb.SetupMfaResponse).Secret
+	assert.Contains(t, setup.(*pb.SetupMfaResponse).ProvisioningUri, "otpauth://totp/")
+
+	code, err := totp.CodeAt(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	confirmReq := &pb.ConfirmMfaRequest{Code: code}
+	confirmed, err := callAuthenticated(t, jwtKeys, db, login.AccessToken, pb.AuthService_ConfirmMfa_FullMethodName, confirmReq, func(ctx context.Context) (interface{}, error) {
+		return authService.ConfirmMfa(ctx, confirmReq)
+	})
+	require.NoError(t, err)
+	recoveryCodes := confirmed.(*pb.ConfirmMfaResponse).RecoveryCodes
+	require.Len(t, recoveryCodes, mfaRecoveryCodeCount)
+	require.NoError(t, adminPanel(confirmed.(*pb.ConfirmMfaResponse).AccessToken))
+
+	// Отметка 2FA сохраняется при обновлении токена
+	refreshed, err := authService.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	require.NoError(t, err)
+	require.NoError(t, adminPanel(refreshed.AccessToken))
+
+	// Теперь вход требует второй шаг
+	login, err = authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: "password123"})
+	require.NoError(t, err)
+	assert.True(t, login.MfaRequired)
+	assert.Empty(t, login.AccessToken)
+	require.NotEmpty(t, login.MfaToken)
+
+	// Токен второго шага не является access token
+	assert.Equal(t, codes.Unauthenticated, status.Code(adminPanel(login.MfaToken)))
+
+	// Уже использованный TOTP-код повторно не принимается
+	_, err = authService.VerifyMfaLogin(ctx, &pb.VerifyMfaLoginRequest{MfaToken: login.MfaToken, Code: code})
+	require.Error(t, err)
+
+	verified, err := authService.VerifyMfaLogin(ctx, &pb.VerifyMfaLoginRequest{MfaToken: login.MfaToken, Code: recoveryCodes[0]})
+	require.NoError(t, err)
+	require.NoError(t, adminPanel(verified.AccessToken))
+
+	// Токен второго шага одноразовый
+	_, err = authService.VerifyMfaLogin(ctx, &pb.VerifyMfaLoginRequest{MfaToken: login.MfaToken, Code: recoveryCodes[1]})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Код восстановления одноразовый
+	login, err = authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: "password123"})
+	require.NoError(t, err)
+	_, err = authService.VerifyMfaLogin(ctx, &pb.VerifyMfaLoginRequest{MfaToken: login.MfaToken, Code: recoveryCodes[0]})
+	require.Error(t, err)
+
+	// Исчерпав попытки, токен второго шага не принимает даже верный код; неудачи идут в блокировку входа
+	for _, wrong := range []string{"000000", "000001"} {
+		_, err = authService.VerifyMfaLogin(ctx, &pb.VerifyMfaLoginRequest{MfaToken: login.MfaToken, Code: wrong})
+		require.Error(t, err)
+	}
+	_, err = authService.VerifyMfaLogin(ctx, &pb.VerifyMfaLoginRequest{MfaToken: login.MfaToken, Code: recoveryCodes[1]})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	var failed int
+	var lastMethod string
+	require.NoError(t, db.QueryRow(`SELECT failed_attempts, last_method FROM login_lockouts WHERE user_id = $1`, registered.User.Id).Scan(&failed, &lastMethod))
+	assert.Equal(t, mfaChallengeMaxAttempts, failed)
+	assert.Equal(t, loginMethodMfa, lastMethod)
+
+	// Администратор не может отключить 2FA
+	disableReq := &pb.DisableMfaRequest{Code: recoveryCodes[1]}
+	_, err = callAuthenticated(t, jwtKeys, db, verified.AccessToken, pb.AuthService_DisableMfa_FullMethodName, disableReq, func(ctx context.Context) (interface{}, error) {
+		return authService.DisableMfa(ctx, disableReq)
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthService_AdminImpersonate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	otpStore := otp.NewMemoryStore(jwtSecret, otp.DefaultConfig())
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otpStore)
+	userService := NewUserServiceServer(db, mockSMS, otpStore, nil)
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	registered, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+	userID := registered.User.Id
+
+	adminID := uuid.NewString()
+	_, err = db.Exec(`
+		INSERT INTO users (id, full_name, phone, password_hash, role, is_active)
+		VALUES ($1, 'Support Admin', '+998907770009', 'x', $2, true)
+	`, adminID, models.RoleAdmin)
+	require.NoError(t, err)
+	adminToken, err := authService.signAccessToken(context.Background(), adminID, "+998907770009", models.RoleAdmin, "", true)
+	require.NoError(t, err)
+
+	impersonateReq := &pb.AdminImpersonateRequest{UserId: userID}
+	impersonate := func(ctx context.Context) (interface{}, error) {
+		return authService.AdminImpersonate(ctx, impersonateReq)
+	}
+	_, err = callAuthenticated(t, jwtKeys, db, adminToken, pb.AuthService_AdminImpersonate_FullMethodName, impersonateReq, impersonate)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "reason is required")
+
+	impersonateReq.Reason = "ticket #42"
+	resp, err := callAuthenticated(t, jwtKeys, db, adminToken, pb.AuthService_AdminImpersonate_FullMethodName, impersonateReq, impersonate)
+	require.NoError(t, err)
+	token := resp.(*pb.AdminImpersonateResponse).AccessToken
+
+	// Изменяющие вызовы попадают в журнал с обоими ID, чтение - нет
+	auditUnary, _ := ImpersonationAuditInterceptors(db)
+	callAudited := func(method string, req interface{}, call func(ctx context.Context) (interface{}, error)) error {
+		_, err := callAuthenticated(t, jwtKeys, db, token, method, req, func(ctx context.Context) (interface{}, error) {
+			return auditUnary(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				return call(ctx)
+			})
+		})
+		return err
+	}
+	profileReq := &pb.GetProfileRequest{}
+	require.NoError(t, callAudited(pb.UserService_GetProfile_FullMethodName, profileReq, func(ctx context.Context) (interface{}, error) {
+		return userService.GetProfile(ctx, profileReq)
+	}))
+	fullName := "Renamed User"
+	updateReq := &pb.UpdateProfileRequest{FullName: &fullName}
+	require.NoError(t, callAudited(pb.UserService_UpdateProfile_FullMethodName, updateReq, func(ctx context.Context) (interface{}, error) {
+		return userService.UpdateProfile(ctx, updateReq)
+	}))
+
+	var started, calls int
+	require.NoError(t, db.QueryRow(`
+		SELECT COUNT(*) FILTER (WHERE action = $3), COUNT(*) FILTER (WHERE action = $4)
+		FROM audit_logs WHERE user_id = $1 AND actor_id = $2
+	`, userID, adminID, auditImpersonationStarted, auditImpersonatedCall).Scan(&started, &calls))
+	assert.Equal(t, 1, started)
+	assert.Equal(t, 1, calls)
+
+	// Администратора нельзя имперсонировать
+	impersonateReq.UserId = adminID
+	_, err = callAuthenticated(t, jwtKeys, db, adminToken, pb.AuthService_AdminImpersonate_FullMethodName, impersonateReq, impersonate)
+	assert.Error(t, err)
+
+	// Понижение администратора отзывает его токен имперсонации
+	role := models.RoleCustomer
+	_, err = userService.AdminUpdateUser(ctx, &pb.AdminUpdateUserRequest{Id: adminID, Role: &role})
+	require.NoError(t, err)
+	err = callAudited(pb.UserService_GetProfile_FullMethodName, profileReq, func(ctx context.Context) (interface{}, error) {
+		return userService.GetProfile(ctx, profileReq)
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+
+	ctx := context.Background()
+	phone := "+998901234567"
+	registered, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Test User",
+		Phone:             phone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+	})
+	require.NoError(t, err)
+	mockSMS.SendSMSCalled = false
+
+	login := func(password string) error {
+		_, err := authService.Login(ctx, &pb.LoginRequest{Phone: phone, Password: password})
+		return err
+	}
+
+	for i := 1; i < loginFreeAttempts; i++ {
+		assert.Equal(t, codes.Unauthenticated, status.Code(login("wrongpassword")))
+	}
+	assert.False(t, mockSMS.SendSMSCalled)
+
+	// Последняя допустимая ошибка блокирует вход и предупреждает владельца
+	assert.Equal(t, codes.ResourceExhausted, status.Code(login("wrongpassword")))
+	assert.True(t, mockSMS.SendSMSCalled)
+	assert.Equal(t, phone, mockSMS.LastPhone)
+
+	// Во время блокировки не принимается даже верный пароль
+	assert.Equal(t, codes.ResourceExhausted, status.Code(login("password123")))
+
+	adminID := uuid.NewString()
+	_, err = db.Exec(`
+		INSERT INTO users (id, full_name, phone, password_hash, role, is_active)
+		VALUES ($1, 'Support Admin', '+998907770009', 'x', $2, true)
+	`, adminID, models.RoleAdmin)
+	require.NoError(t, err)
+	adminToken, err := authService.signAccessToken(ctx, adminID, "+998907770009", models.RoleAdmin, "", true)
+	require.NoError(t, err)
+
+	listReq := &pb.AdminListLoginLockoutsRequest{LockedOnly: true}
+	list, err := callAuthenticated(t, jwtKeys, db, adminToken, pb.AuthService_AdminListLoginLockouts_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return authService.AdminListLoginLockouts(ctx, listReq)
+	})
+	require.NoError(t, err)
+	lockouts := list.(*pb.AdminListLoginLockoutsResponse).Lockouts
+	require.Len(t, lockouts, 1)
+	assert.Equal(t, registered.User.Id, lockouts[0].UserId)
+	assert.Equal(t, int32(loginFreeAttempts), lockouts[0].FailedAttempts)
+	assert.Equal(t, loginMethodPassword, lockouts[0].LastMethod)
+	assert.NotNil(t, lockouts[0].LockedUntil)
+
+	// Снять блокировку может только администратор
+	clearReq := &pb.AdminClearLoginLockoutRequest{UserId: registered.User.Id}
+	clear := func(ctx context.Context) (interface{}, error) {
+		return authService.AdminClearLoginLockout(ctx, clearReq)
+	}
+	_, err = callAuthenticated(t, jwtKeys, db, registered.AccessToken, pb.AuthService_AdminClearLoginLockout_FullMethodName, clearReq, clear)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = callAuthenticated(t, jwtKeys, db, adminToken, pb.AuthService_AdminClearLoginLockout_FullMethodName, clearReq, clear)
+	require.NoError(t, err)
+	require.NoError(t, login("password123"))
+
+	_, err = callAuthenticated(t, jwtKeys, db, adminToken, pb.AuthService_AdminClearLoginLockout_FullMethodName, clearReq, clear)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	var audited int
+	require.NoError(t, db.QueryRow(`
+		SELECT COUNT(*) FROM audit_logs WHERE user_id = $1 AND actor_id = $2 AND action = $3
+	`, registered.User.Id, adminID, auditLoginLockoutCleared).Scan(&audited))
+	assert.Equal(t, 1, audited)
+}
+
+func TestLoginLockoutFor(t *testing.T) {
+	assert.Zero(t, loginLockoutFor(loginFreeAttempts-1))
+	assert.Equal(t, loginLockoutBase, loginLockoutFor(loginFreeAttempts))
+	assert.Equal(t, 2*loginLockoutBase, loginLockoutFor(loginFreeAttempts+1))
+	assert.Equal(t, 4*loginLockoutBase, loginLockoutFor(loginFreeAttempts+2))
+	assert.Equal(t, loginLockoutMax, loginLockoutFor(loginFreeAttempts+100))
+}
+
+// callAuthenticated прогоняет вызов через auth interceptor с указанным access token
+func callAuthenticated(t *testing.T, jwtKeys *jwtkeys.KeyRing, db *sql.DB, accessToken, method string, req interface{}, call func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	t.Helper()
+
+	unary, _ := middleware.NewAuthInterceptors(jwtKeys, db, Policies(), nil)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+accessToken))
+	return unary(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return call(ctx)
+	})
+}
+
+// testKeyRing создает связку ключей с новым Ed25519 ключом подписи
+func testKeyRing(t *testing.T) *jwtkeys.KeyRing {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys, err := jwtkeys.NewKeyRing(jwtkeys.NewEd25519Key(private))
+	require.NoError(t, err)
+	return keys
+}
+
+// verifyPhone проходит SendOTP + VerifyOTP и возвращает тикет для Register
+func verifyPhone(t *testing.T, authService *AuthServiceServer, mockSMS *sms.MockSMSService, phone string) string {
+	t.Helper()
+
+	ctx := context.Background()
+	_, err := authService.SendOTP(ctx, &pb.SendOTPRequest{Phone: phone})
+	require.NoError(t, err)
+
+	resp, err := authService.VerifyOTP(ctx, &pb.VerifyOTPRequest{Phone: phone, Code: mockSMS.LastCode})
+	require.NoError(t, err)
+	return resp.VerificationToken
+}
@@ -0,0 +1,427 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"mebellar-backend/internal/grpc/mapper"
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cartItemMaxQuantity caps the quantity of one cart line; merged carts are capped too.
+const cartItemMaxQuantity = 99
+
+type CartServiceServer struct {
+	pb.UnimplementedCartServiceServer
+	db *sql.DB
+}
+
+func NewCartServiceServer(db *sql.DB) *CartServiceServer {
+	return &CartServiceServer{db: db}
+}
+
+// cartOwner identifies a cart: a logged-in user, or a guest's device.
+type cartOwner struct {
+	UserID   string
+	DeviceID string
+}
+
+// column and key select the owner's row in carts.
+func (o cartOwner) column() string {
+	if o.UserID != "" {
+		return "user_id"
+	}
+	return "device_id"
+}
+
+func (o cartOwner) key() string {
+	if o.UserID != "" {
+		return o.UserID
+	}
+	return o.DeviceID
+}
+
+// cartOwnerFromContext resolves whose cart the call addresses. Cart methods authenticate
+// optionally; guests must send the x-device-id header.
+func cartOwnerFromContext(ctx context.Context) (cartOwner, error) {
+	if auth := middleware.GetAuthContext(ctx); auth != nil {
+		if auth.APIKeyID != "" {
+			return cartOwner{}, status.Error(codes.PermissionDenied, "API keys have no cart")
+		}
+		return cartOwner{UserID: auth.UserID}, nil
+	}
+	if deviceID := deviceFromContext(ctx).ID; deviceID != "" {
+		return cartOwner{DeviceID: deviceID}, nil
+	}
+	return cartOwner{}, status.Error(codes.InvalidArgument, "x-device-id header is required for a guest cart")
+}
+
+func (s *CartServiceServer) AddItem(ctx context.Context, req *pb.AddCartItemRequest) (*pb.CartResponse, error) {
+	owner, err := cartOwnerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetProductId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product_id")
+	}
+	quantity := req.GetQuantity()
+	if quantity == 0 {
+		quantity = 1
+	}
+	if quantity < 0 || quantity > cartItemMaxQuantity {
+		return nil, status.Errorf(codes.InvalidArgument, "quantity must be between 1 and %d", cartItemMaxQuantity)
+	}
+
+	// Only what could be ordered right now can be added: the line it merges into is checked as a whole
+	item := models.CartItem{ProductID: req.GetProductId(), VariantName: req.GetVariantName(), VariantValue: req.GetVariantValue()}
+	existing, err := s.cartLineQuantity(ctx, owner, item)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	item.Quantity = min(existing+int(quantity), cartItemMaxQuantity)
+	items := []models.CartItem{item}
+	if err := priceCartItems(ctx, s.db, items); err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if item = items[0]; !item.Available {
+		return nil, status.Error(codes.FailedPrecondition, "product is not available")
+	}
+
+	cartID, err := ensureCart(ctx, s.db, owner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cart error: %v", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO cart_items (cart_id, product_id, variant_name, variant_value, quantity, added_price)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (cart_id, product_id, variant_name, variant_value) DO UPDATE SET
+			quantity = LEAST(cart_items.quantity + EXCLUDED.quantity, $7),
+			added_price = EXCLUDED.added_price,
+			updated_at = NOW()
+	`, cartID, item.ProductID, item.VariantName, item.VariantValue, quantity, item.Price, cartItemMaxQuantity)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "insert item error: %v", err)
+	}
+	return s.cartResponse(ctx, cartID)
+}
+
+// UpdateQuantity sets the quantity of a line; 0 removes it. The current price becomes
+// the one the customer has seen.
+func (s *CartServiceServer) UpdateQuantity(ctx context.Context, req *pb.UpdateCartItemQuantityRequest) (*pb.CartResponse, error) {
+	owner, err := cartOwnerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetQuantity() < 0 || req.GetQuantity() > cartItemMaxQuantity {
+		return nil, status.Errorf(codes.InvalidArgument, "quantity must be between 0 and %d", cartItemMaxQuantity)
+	}
+	if req.GetQuantity() == 0 {
+		return s.RemoveItem(ctx, &pb.RemoveCartItemRequest{ItemId: req.GetItemId()})
+	}
+
+	cartID, item, err := s.findCartItem(ctx, owner, req.GetItemId())
+	if err != nil {
+		return nil, err
+	}
+	item.Quantity = int(req.GetQuantity())
+	items := []models.CartItem{item}
+	if err := priceCartItems(ctx, s.db, items); err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if item = items[0]; !item.Available {
+		return nil, status.Error(codes.FailedPrecondition, "product is not available")
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE cart_items SET quantity = $1, added_price = $2, updated_at = NOW() WHERE id = $3
+	`, req.GetQuantity(), item.Price, item.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update item error: %v", err)
+	}
+	return s.cartResponse(ctx, cartID)
+}
+
+func (s *CartServiceServer) RemoveItem(ctx context.Context, req *pb.RemoveCartItemRequest) (*pb.CartResponse, error) {
+	owner, err := cartOwnerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cartID, item, err := s.findCartItem(ctx, owner, req.GetItemId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM cart_items WHERE id = $1`, item.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete item error: %v", err)
+	}
+	return s.cartResponse(ctx, cartID)
+}
+
+// GetCart returns the cart priced live; a caller without a cart gets an empty one.
+func (s *CartServiceServer) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.CartResponse, error) {
+	owner, err := cartOwnerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cartID, err := findCart(ctx, s.db, owner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return s.cartResponse(ctx, cartID)
+}
+
+func (s *CartServiceServer) Clear(ctx context.Context, req *pb.ClearCartRequest) (*pb.CartResponse, error) {
+	owner, err := cartOwnerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cartID, err := findCart(ctx, s.db, owner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if cartID != "" {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM cart_items WHERE cart_id = $1`, cartID); err != nil {
+			return nil, status.Errorf(codes.Internal, "delete items error: %v", err)
+		}
+	}
+	return s.cartResponse(ctx, cartID)
+}
+
+// cartResponse loads and prices the cart. An empty cartID gives an empty cart.
+func (s *CartServiceServer) cartResponse(ctx context.Context, cartID string) (*pb.CartResponse, error) {
+	cart := models.Cart{ID: cartID}
+	if cartID != "" {
+		var err error
+		if cart.Items, err = loadCartItems(ctx, s.db, cartID); err != nil {
+			return nil, status.Errorf(codes.Internal, "query error: %v", err)
+		}
+		if err := priceCartItems(ctx, s.db, cart.Items); err != nil {
+			return nil, status.Errorf(codes.Internal, "query error: %v", err)
+		}
+	}
+	return &pb.CartResponse{Cart: mapper.ToPBCart(cart)}, nil
+}
+
+// findCartItem loads a line of the owner's cart. Lines of other carts are reported as not found.
+func (s *CartServiceServer) findCartItem(ctx context.Context, owner cartOwner, itemID string) (string, models.CartItem, error) {
+	var cartID string
+	var item models.CartItem
+	if _, err := uuid.Parse(itemID); err != nil {
+		return "", item, status.Error(codes.InvalidArgument, "invalid item_id")
+	}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT c.id, i.id, i.product_id, i.variant_name, i.variant_value, i.quantity, i.added_price, i.created_at
+		FROM cart_items i JOIN carts c ON c.id = i.cart_id
+		WHERE i.id = $1 AND c.`+owner.column()+` = $2
+	`, itemID, owner.key()).Scan(&cartID, &item.ID, &item.ProductID, &item.VariantName, &item.VariantValue, &item.Quantity, &item.AddedPrice, &item.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", item, status.Error(codes.NotFound, "cart item not found")
+	}
+	if err != nil {
+		return "", item, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return cartID, item, nil
+}
+
+// cartLineQuantity returns the quantity of the owner's line for the product and variant, 0 if there is none.
+func (s *CartServiceServer) cartLineQuantity(ctx context.Context, owner cartOwner, item models.CartItem) (int, error) {
+	var quantity int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT ci.quantity FROM cart_items ci JOIN carts c ON c.id = ci.cart_id
+		WHERE c.`+owner.column()+` = $1 AND ci.product_id = $2 AND ci.variant_name = $3 AND ci.variant_value = $4
+	`, owner.key(), item.ProductID, item.VariantName, item.VariantValue).Scan(&quantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return quantity, err
+}
+
+// findCart returns the owner's cart ID, or "" if the owner has none.
+func findCart(ctx context.Context, q queryer, owner cartOwner) (string, error) {
+	var cartID string
+	err := q.QueryRowContext(ctx, `SELECT id FROM carts WHERE `+owner.column()+` = $1`, owner.key()).Scan(&cartID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return cartID, err
+}
+
+// ensureCart returns the owner's cart ID, creating the cart on first use.
+func ensureCart(ctx context.Context, q queryer, owner cartOwner) (string, error) {
+	var userID, deviceID sql.NullString
+	if owner.UserID != "" {
+		userID = sql.NullString{String: owner.UserID, Valid: true}
+	} else {
+		deviceID = sql.NullString{String: owner.DeviceID, Valid: true}
+	}
+
+	var cartID string
+	err := q.QueryRowContext(ctx, `
+		INSERT INTO carts (user_id, device_id) VALUES ($1, $2)
+		ON CONFLICT (`+owner.column()+`) DO UPDATE SET updated_at = NOW()
+		RETURNING id
+	`, userID, deviceID).Scan(&cartID)
+	return cartID, err
+}
+
+// loadCartItems reads the lines of a cart, oldest first, without prices.
+func loadCartItems(ctx context.Context, q queryer, cartID string) ([]models.CartItem, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, product_id, variant_name, variant_value, quantity, added_price, created_at
+		FROM cart_items
+		WHERE cart_id = $1
+		ORDER BY created_at, id
+	`, cartID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.CartItem
+	for rows.Next() {
+		var item models.CartItem
+		if err := rows.Scan(&item.ID, &item.ProductID, &item.VariantName, &item.VariantValue, &item.Quantity, &item.AddedPrice, &item.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// priceCartItems fills the live price, name, image and availability of cart lines with the
+// rules of priceOrder and reserveStock: the product and its shop must be active, the variant
+// must exist and a tracked product must have the quantity in stock.
+func priceCartItems(ctx context.Context, q queryer, items []models.CartItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductID)
+	}
+	products, err := loadOrderProducts(ctx, q, ids)
+	if err != nil {
+		return err
+	}
+
+	shopIDs := make([]string, 0, len(products))
+	for _, product := range products {
+		if product.ShopID != "" {
+			shopIDs = append(shopIDs, product.ShopID)
+		}
+	}
+	activeShops, err := loadActiveShops(ctx, q, shopIDs)
+	if err != nil {
+		return err
+	}
+	stock, err := loadStock(ctx, q, ids)
+	if err != nil {
+		return err
+	}
+	// Lines drawing from the same level share its units, as in reserveStock
+	needed := make(map[string]int)
+
+	for i := range items {
+		item := &items[i]
+		product, ok := products[item.ProductID]
+		if !ok {
+			continue
+		}
+		item.ShopID = product.ShopID
+		item.ProductName = product.GetName("uz")
+		item.ProductImage = product.ImageFor(nil)
+		if !product.IsActive || !activeShops[product.ShopID] {
+			continue
+		}
+
+		line, err := resolveLine(product, item.VariantName, item.VariantValue)
+		if err != nil {
+			continue
+		}
+		item.VariantName, item.VariantValue = line.VariantName, line.VariantValue
+		item.ProductImage = line.ProductImage
+		item.Price = line.Price
+
+		level, tracked := stockFor(stock[item.ProductID], line.VariantName, line.VariantValue)
+		if tracked {
+			if level == nil || needed[level.ID]+item.Quantity > level.Available() {
+				continue
+			}
+			needed[level.ID] += item.Quantity
+		}
+		item.Available = true
+	}
+	return nil
+}
+
+// loadActiveShops returns which of the given shops accept orders.
+func loadActiveShops(ctx context.Context, q queryer, shopIDs []string) (map[string]bool, error) {
+	active := make(map[string]bool, len(shopIDs))
+	if len(shopIDs) == 0 {
+		return active, nil
+	}
+	rows, err := q.QueryContext(ctx, `SELECT id FROM shops WHERE id = ANY($1) AND COALESCE(is_active, true)`, pq.Array(shopIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		active[id] = true
+	}
+	return active, rows.Err()
+}
+
+// mergeGuestCart moves the device's guest cart into the user's cart on login.
+// Lines of the same product and variant add up; the guest cart is deleted.
+func mergeGuestCart(ctx context.Context, db *sql.DB, deviceID, userID string) error {
+	if deviceID == "" {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var guestCartID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM carts WHERE device_id = $1 FOR UPDATE`, deviceID).Scan(&guestCartID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	userCartID, err := ensureCart(ctx, tx, cartOwner{UserID: userID})
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO cart_items (cart_id, product_id, variant_name, variant_value, quantity, added_price, created_at)
+		SELECT $1, product_id, variant_name, variant_value, quantity, added_price, created_at
+		FROM cart_items
+		WHERE cart_id = $2
+		ON CONFLICT (cart_id, product_id, variant_name, variant_value) DO UPDATE SET
+			quantity = LEAST(cart_items.quantity + EXCLUDED.quantity, $3),
+			updated_at = NOW()
+	`, userCartID, guestCartID, cartItemMaxQuantity)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM carts WHERE id = $1`, guestCartID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
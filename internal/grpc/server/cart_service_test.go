@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"mebellar-backend/pkg/otp"
+	"mebellar-backend/pkg/pb"
+	"mebellar-backend/pkg/sms"
+	"mebellar-backend/pkg/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCartService(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+	cartService := NewCartServiceServer(db)
+
+	shopID, _ := createTestShop(t, db, "+998907770101")
+	sofaID := createTestProduct(t, db, shopID, 5000000, 4500000,
+		`[{"name": "Rang", "value": "Kulrang", "price_modifier": 250000}]`, `{"is_home_region_free": true}`)
+	tableID := createTestProduct(t, db, shopID, 1200000, nil, `[]`, `{"is_home_region_free": true}`)
+
+	// Гость без x-device-id не может иметь корзину
+	_, err := cartService.GetCart(context.Background(), &pb.GetCartRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	guest := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-device-id", "device-"+uuid.NewString()))
+	_, err = cartService.AddItem(guest, &pb.AddCartItemRequest{ProductId: sofaID, VariantName: "Rang", VariantValue: "Qizil"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "unknown variant")
+
+	// Одинаковые позиции складываются, цены считаются как в CreateOrder
+	for _, req := range []*pb.AddCartItemRequest{
+		{ProductId: sofaID, VariantName: "Rang", VariantValue: "Kulrang"},
+		{ProductId: sofaID, VariantName: "Rang", VariantValue: "Kulrang", Quantity: 2},
+		{ProductId: tableID},
+	} {
+		_, err := cartService.AddItem(guest, req)
+		require.NoError(t, err)
+	}
+	resp, err := cartService.GetCart(guest, &pb.GetCartRequest{})
+	require.NoError(t, err)
+	cart := resp.Cart
+	require.Len(t, cart.Items, 2)
+	sofa := cart.Items[0]
+	assert.Equal(t, int32(3), sofa.Quantity)
+	assert.Equal(t, 4750000.0, sofa.Price)
+	assert.Equal(t, shopID, sofa.ShopId)
+	assert.Equal(t, "Divan", sofa.ProductName)
+	assert.Equal(t, int32(4), cart.ItemsCount)
+	assert.Equal(t, 3*4750000.0+1200000.0, cart.Subtotal)
+	assert.False(t, cart.HasPriceChanges)
+
+	// Изменение цены и деактивация товара видны в корзине
+	_, err = db.Exec(`UPDATE products SET discount_price = 4000000 WHERE id = $1`, sofaID)
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE products SET is_active = false WHERE id = $1`, tableID)
+	require.NoError(t, err)
+	resp, err = cartService.GetCart(guest, &pb.GetCartRequest{})
+	require.NoError(t, err)
+	cart = resp.Cart
+	assert.True(t, cart.Items[0].PriceChanged)
+	assert.Equal(t, 4750000.0, cart.Items[0].AddedPrice)
+	assert.Equal(t, 4250000.0, cart.Items[0].Price)
+	assert.False(t, cart.Items[1].Available)
+	assert.True(t, cart.HasUnavailableItems)
+	assert.Equal(t, 3*4250000.0, cart.Subtotal)
+
+	// Изменение количества подтверждает новую цену
+	resp, err = cartService.UpdateQuantity(guest, &pb.UpdateCartItemQuantityRequest{ItemId: sofa.Id, Quantity: 1})
+	require.NoError(t, err)
+	assert.False(t, resp.Cart.HasPriceChanges)
+	assert.Equal(t, int32(1), resp.Cart.Items[0].Quantity)
+
+	// Остаток учитывается как в CreateOrder: больше, чем есть, не добавить, распроданное недоступно
+	_, err = db.Exec(`
+		INSERT INTO product_stock (product_id, variant_name, variant_value, on_hand) VALUES ($1, 'Rang', 'Kulrang', 1)
+	`, sofaID)
+	require.NoError(t, err)
+	_, err = cartService.AddItem(guest, &pb.AddCartItemRequest{ProductId: sofaID, VariantName: "Rang", VariantValue: "Kulrang", Quantity: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "line would exceed the stock")
+	_, err = cartService.UpdateQuantity(guest, &pb.UpdateCartItemQuantityRequest{ItemId: sofa.Id, Quantity: 2})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "more than in stock")
+	resp, err = cartService.GetCart(guest, &pb.GetCartRequest{})
+	require.NoError(t, err)
+	assert.True(t, resp.Cart.Items[0].Available)
+	_, err = db.Exec(`UPDATE product_stock SET on_hand = 0 WHERE product_id = $1`, sofaID)
+	require.NoError(t, err)
+	resp, err = cartService.GetCart(guest, &pb.GetCartRequest{})
+	require.NoError(t, err)
+	assert.False(t, resp.Cart.Items[0].Available, "sold out")
+	_, err = db.Exec(`UPDATE product_stock SET on_hand = 5 WHERE product_id = $1`, sofaID)
+	require.NoError(t, err)
+
+	// При регистрации с этого устройства гостевая корзина переходит в аккаунт
+	buyerPhone := "+998901112233"
+	buyer, err := authService.Register(guest, &pb.RegisterRequest{
+		FullName:          "Buyer",
+		Phone:             buyerPhone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, buyerPhone),
+	})
+	require.NoError(t, err)
+
+	resp, err = cartService.GetCart(guest, &pb.GetCartRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Cart.Items, "guest cart is gone")
+
+	userCart := func(token string) *pb.Cart {
+		req := &pb.GetCartRequest{}
+		resp, err := callAuthenticated(t, jwtKeys, db, token, pb.CartService_GetCart_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return cartService.GetCart(ctx, req)
+		})
+		require.NoError(t, err)
+		return resp.(*pb.CartResponse).Cart
+	}
+	cart = userCart(buyer.AccessToken)
+	require.Len(t, cart.Items, 2)
+	assert.Equal(t, sofa.ProductId, cart.Items[0].ProductId)
+	assert.Equal(t, int32(1), cart.Items[0].Quantity)
+
+	// Чужую позицию удалить нельзя
+	other := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-device-id", "device-"+uuid.NewString()))
+	_, err = cartService.RemoveItem(other, &pb.RemoveCartItemRequest{ItemId: cart.Items[0].Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	clearReq := &pb.ClearCartRequest{}
+	_, err = callAuthenticated(t, jwtKeys, db, buyer.AccessToken, pb.CartService_Clear_FullMethodName, clearReq, func(ctx context.Context) (interface{}, error) {
+		return cartService.Clear(ctx, clearReq)
+	})
+	require.NoError(t, err)
+	assert.Empty(t, userCart(buyer.AccessToken).Items)
+}
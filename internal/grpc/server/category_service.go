@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"mebellar-backend/internal/grpc/middleware"
 	"mebellar-backend/pkg/cache"
 	"mebellar-backend/pkg/logger"
 	"mebellar-backend/pkg/pb"
@@ -295,11 +294,6 @@ func (s *CategoryServiceServer) GetCategory(ctx context.Context, req *pb.GetCate
 }
 
 func (s *CategoryServiceServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CategoryResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	id := uuid.NewString()
 	nameJSON, _ := json.Marshal(localizedStringToMap(req.GetName()))
 
@@ -338,11 +332,6 @@ func (s *CategoryServiceServer) CreateCategory(ctx context.Context, req *pb.Crea
 }
 
 func (s *CategoryServiceServer) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.CategoryResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "category id is required")
 	}
@@ -412,11 +401,6 @@ func (s *CategoryServiceServer) UpdateCategory(ctx context.Context, req *pb.Upda
 }
 
 func (s *CategoryServiceServer) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.Empty, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "category id is required")
 	}
@@ -489,11 +473,6 @@ func (s *CategoryServiceServer) GetCategoryAttribute(ctx context.Context, req *p
 }
 
 func (s *CategoryServiceServer) CreateCategoryAttribute(ctx context.Context, req *pb.CreateCategoryAttributeRequest) (*pb.CategoryAttributeResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	if req.GetCategoryId() == "" || req.GetKey() == "" || req.GetType() == "" {
 		return nil, status.Error(codes.InvalidArgument, "category_id, key, and type are required")
 	}
@@ -531,11 +510,6 @@ func (s *CategoryServiceServer) CreateCategoryAttribute(ctx context.Context, req
 }
 
 func (s *CategoryServiceServer) UpdateCategoryAttribute(ctx context.Context, req *pb.UpdateCategoryAttributeRequest) (*pb.CategoryAttributeResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "attribute id is required")
 	}
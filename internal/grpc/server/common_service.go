@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"strings"
 
-	"mebellar-backend/internal/grpc/middleware"
 	"mebellar-backend/pkg/pb"
 
 	"github.com/google/uuid"
@@ -146,11 +145,6 @@ func (s *CommonServiceServer) GetRegion(ctx context.Context, req *pb.GetRegionRe
 }
 
 func (s *CommonServiceServer) CreateRegion(ctx context.Context, req *pb.CreateRegionRequest) (*pb.RegionResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	nameJSON, _ := json.Marshal(localizedStringToMap(req.GetName()))
 	legacyName := req.GetName().GetUz()
 	if legacyName == "" {
@@ -175,11 +169,6 @@ func (s *CommonServiceServer) CreateRegion(ctx context.Context, req *pb.CreateRe
 }
 
 func (s *CommonServiceServer) UpdateRegion(ctx context.Context, req *pb.UpdateRegionRequest) (*pb.RegionResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	if req.GetId() == 0 {
 		return nil, status.Error(codes.InvalidArgument, "region id is required")
 	}
@@ -345,11 +334,6 @@ func (s *CommonServiceServer) GetBanner(ctx context.Context, req *pb.GetBannerRe
 }
 
 func (s *CommonServiceServer) CreateBanner(ctx context.Context, req *pb.CreateBannerRequest) (*pb.BannerResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	id := uuid.NewString()
 	titleJSON, _ := json.Marshal(localizedStringToMap(req.GetTitle()))
 	subtitleJSON, _ := json.Marshal(localizedStringToMap(req.GetSubtitle()))
@@ -372,11 +356,6 @@ func (s *CommonServiceServer) CreateBanner(ctx context.Context, req *pb.CreateBa
 }
 
 func (s *CommonServiceServer) UpdateBanner(ctx context.Context, req *pb.UpdateBannerRequest) (*pb.BannerResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "banner id is required")
 	}
@@ -453,7 +432,7 @@ func (s *CommonServiceServer) DeleteBanner(ctx context.Context, req *pb.DeleteBa
 
 func (s *CommonServiceServer) ListCancellationReasons(ctx context.Context, req *pb.ListCancellationReasonsRequest) (*pb.ListCancellationReasonsResponse, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, text, is_active FROM cancellation_reasons WHERE is_active = true ORDER BY id
+		SELECT id, reason_text, is_active FROM cancellation_reasons WHERE is_active = true ORDER BY sort_order, id
 	`)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "query error: %v", err)
@@ -463,19 +442,17 @@ func (s *CommonServiceServer) ListCancellationReasons(ctx context.Context, req *
 	var reasons []*pb.CancellationReason
 	for rows.Next() {
 		var id string
-		var text []byte
+		var text string
 		var isActive bool
 
 		if err := rows.Scan(&id, &text, &isActive); err != nil {
 			continue
 		}
 
-		textMap := make(map[string]string)
-		json.Unmarshal(text, &textMap)
-
+		// reason_text is stored in a single language
 		reasons = append(reasons, &pb.CancellationReason{
 			Id:       id,
-			Text:     mapToLocalizedString(textMap),
+			Text:     &pb.LocalizedString{Uz: text, Ru: text, En: text},
 			IsActive: isActive,
 		})
 	}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// errEmailTaken is returned when the new email belongs to another account.
+var errEmailTaken = errors.New("email already in use")
+
+// emailChangeKey is the OTP store key of an email change, bound to both the user and the address.
+func emailChangeKey(userID, email string) string {
+	return userID + ":" + strings.ToLower(email)
+}
+
+// applyEmailChange sets the user's email and records the change in the audit log.
+func applyEmailChange(ctx context.Context, db *sql.DB, userID, oldEmail, newEmail string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var taken bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = LOWER($1) AND id != $2)
+	`, newEmail, userID).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errEmailTaken
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET email = $1, updated_at = NOW() WHERE id = $2`, newEmail, userID); err != nil {
+		return err
+	}
+	err = recordAudit(ctx, tx, auditEntry{
+		UserID:  userID,
+		Action:  auditEmailChanged,
+		Details: map[string]any{"old_email": oldEmail, "new_email": newEmail},
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
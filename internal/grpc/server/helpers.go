@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
 	"mebellar-backend/pkg/pb"
 
 	"google.golang.org/grpc/codes"
@@ -23,11 +24,6 @@ import (
 // - idValue: the ID value to delete (supports both string and int64)
 // - entityName: friendly name of the entity for error messages (e.g., "region", "banner")
 func DeleteEntity(ctx context.Context, db *sql.DB, tableName string, idValue interface{}, entityName string) (*pb.Empty, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	// Validate ID is not empty
 	switch v := idValue.(type) {
 	case string:
@@ -82,6 +78,59 @@ func VerifyShopOwnershipHelper(ctx context.Context, db *sql.DB, shopID, userID s
 	return nil
 }
 
+// ShopRoleHelper returns the user's role in a shop: owner for the seller who owns it,
+// the member role for active staff, or "" if the user has no access.
+//
+// Returns:
+// - error if shop is not found or database error occurs
+func ShopRoleHelper(ctx context.Context, db *sql.DB, shopID, userID string) (string, error) {
+	var ownerUserID string
+	err := db.QueryRowContext(ctx, `
+		SELECT sp.user_id FROM shops sh
+		JOIN seller_profiles sp ON sp.id = sh.seller_id
+		WHERE sh.id = $1
+	`, shopID).Scan(&ownerUserID)
+	if err == sql.ErrNoRows {
+		return "", status.Error(codes.NotFound, "shop not found")
+	}
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if ownerUserID == userID {
+		return models.ShopRoleOwner, nil
+	}
+
+	role, err := middleware.ShopMemberRole(ctx, db, shopID, userID)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return role, nil
+}
+
+// VerifyShopAccessHelper verifies that a user is the shop owner or an active staff member
+// whose role is at least minRole (owner > manager > operator > viewer).
+//
+// Returns:
+// - error if shop is not found, the user's role is too low, or database error occurs
+func VerifyShopAccessHelper(ctx context.Context, db *sql.DB, shopID, userID, minRole string) error {
+	role, err := ShopRoleHelper(ctx, db, shopID, userID)
+	if err != nil {
+		return err
+	}
+	return requireShopRole(role, minRole)
+}
+
+// requireShopRole maps a too low (or missing) shop role to PermissionDenied.
+func requireShopRole(role, minRole string) error {
+	if role == "" {
+		return status.Error(codes.PermissionDenied, "you don't have access to this shop")
+	}
+	if !models.ShopRoleAtLeast(role, minRole) {
+		return status.Errorf(codes.PermissionDenied, "%s shop role required", minRole)
+	}
+	return nil
+}
+
 // UpdateUserFieldHelper is a generic helper function for updating a single field in the users table.
 // It updates the field value and updated_at timestamp.
 //
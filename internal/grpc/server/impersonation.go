@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// impersonationTTL is the lifetime of an impersonation token; it cannot be refreshed.
+const impersonationTTL = 15 * time.Minute
+
+// signImpersonationToken signs an access token for the user on behalf of the admin actorID.
+// The token carries the RFC 8693 "act" claim and no session, so refresh and session RPCs don't apply.
+// The claim holds the admin's token version too: demoting or signing out the admin revokes the token.
+func (s *AuthServiceServer) signImpersonationToken(ctx context.Context, user *models.User, actorID string) (string, time.Time, error) {
+	version, err := s.versions.Load(ctx, user.ID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	actorVersion, err := s.versions.Load(ctx, actorID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(impersonationTTL)
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"phone":   user.Phone,
+		"role":    user.Role,
+		"sid":     "",
+		"mfa":     false,
+		"ver":     version,
+		"act":     map[string]any{"sub": actorID, "ver": actorVersion},
+		"typ":     middleware.TokenTypeAccess,
+		"exp":     expiresAt.Unix(),
+		"iat":     time.Now().Unix(),
+	}
+	token, err := s.keys.Sign(claims)
+	return token, expiresAt, err
+}
+
+// readOnlyMethod reports whether a gRPC method only reads data, going by the method name.
+func readOnlyMethod(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Stream"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImpersonationAuditInterceptors record every mutating call made with an impersonation token
+// in audit_logs, with the user and the acting admin. They must run after the auth interceptor.
+func ImpersonationAuditInterceptors(db *sql.DB) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		recordImpersonatedCall(ctx, db, info.FullMethod, err)
+		return resp, err
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		recordImpersonatedCall(ss.Context(), db, info.FullMethod, err)
+		return err
+	}
+
+	return unary, stream
+}
+
+// recordImpersonatedCall writes the audit entry for a finished call when it was impersonated and mutating.
+// Failed calls are recorded too, with their status code.
+func recordImpersonatedCall(ctx context.Context, db *sql.DB, fullMethod string, callErr error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil || auth.ActorID == "" || readOnlyMethod(fullMethod) {
+		return
+	}
+
+	err := recordAudit(context.WithoutCancel(ctx), db, auditEntry{
+		UserID:  auth.UserID,
+		ActorID: auth.ActorID,
+		Action:  auditImpersonatedCall,
+		Details: map[string]any{
+			"method": fullMethod,
+			"status": status.Code(callErr).String(),
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to audit impersonated call",
+			zap.String("user_id", auth.UserID),
+			zap.String("actor_id", auth.ActorID),
+			zap.String("method", fullMethod),
+			zap.Error(err),
+		)
+	}
+}
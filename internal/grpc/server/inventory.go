@@ -0,0 +1,388 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"mebellar-backend/internal/grpc/mapper"
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// productInStockSQL tells whether product p can be ordered: untracked products always can,
+// tracked ones while any of their stock levels has unreserved units.
+const productInStockSQL = `(NOT EXISTS (SELECT 1 FROM product_stock ps WHERE ps.product_id = p.id)
+	OR EXISTS (SELECT 1 FROM product_stock ps WHERE ps.product_id = p.id AND ps.on_hand > ps.reserved))`
+
+// stockMove is one change of a stock level, written to the ledger as it is applied.
+type stockMove struct {
+	StockID        string
+	Kind           string
+	OnHandChange   int
+	ReservedChange int
+	OrderID        *string
+	ActorID        *string
+	Note           string
+}
+
+// applyStockMove changes a stock level locked by the caller and records the movement.
+// The product_stock CHECK constraints reject a move that would oversell.
+func applyStockMove(ctx context.Context, tx *sql.Tx, move stockMove) (models.StockLevel, error) {
+	var level models.StockLevel
+	err := tx.QueryRowContext(ctx, `
+		UPDATE product_stock SET on_hand = on_hand + $2, reserved = reserved + $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, product_id, variant_name, variant_value, on_hand, reserved, updated_at
+	`, move.StockID, move.OnHandChange, move.ReservedChange).Scan(
+		&level.ID, &level.ProductID, &level.VariantName, &level.VariantValue, &level.OnHand, &level.Reserved, &level.UpdatedAt,
+	)
+	if err != nil {
+		return level, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO stock_movements (stock_id, kind, on_hand_change, reserved_change, on_hand_after, reserved_after, order_id, actor_id, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, move.StockID, move.Kind, move.OnHandChange, move.ReservedChange, level.OnHand, level.Reserved, move.OrderID, move.ActorID, move.Note)
+	return level, err
+}
+
+// lockStock locks the stock levels of the given products in a fixed order, so concurrent
+// orders for the same products queue up instead of overselling or deadlocking.
+func lockStock(ctx context.Context, tx *sql.Tx, productIDs []string) (map[string][]models.StockLevel, error) {
+	return queryStock(ctx, tx, stockLevelsSQL+` ORDER BY id FOR UPDATE`, productIDs)
+}
+
+// loadStock reads the stock levels of the products without locking them, for display.
+func loadStock(ctx context.Context, q queryer, productIDs []string) (map[string][]models.StockLevel, error) {
+	return queryStock(ctx, q, stockLevelsSQL, productIDs)
+}
+
+const stockLevelsSQL = `
+	SELECT id, product_id, variant_name, variant_value, on_hand, reserved, updated_at
+	FROM product_stock
+	WHERE product_id = ANY($1)`
+
+func queryStock(ctx context.Context, q queryer, query string, productIDs []string) (map[string][]models.StockLevel, error) {
+	rows, err := q.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stock := make(map[string][]models.StockLevel)
+	for rows.Next() {
+		var level models.StockLevel
+		if err := rows.Scan(&level.ID, &level.ProductID, &level.VariantName, &level.VariantValue, &level.OnHand, &level.Reserved, &level.UpdatedAt); err != nil {
+			return nil, err
+		}
+		stock[level.ProductID] = append(stock[level.ProductID], level)
+	}
+	return stock, rows.Err()
+}
+
+// stockFor picks the level an order line draws from: the variant's own level, else the
+// product-level one. tracked is false when the product has no levels at all; a tracked
+// product without a matching level has nothing to sell and gets a nil level.
+func stockFor(levels []models.StockLevel, variantName, variantValue string) (level *models.StockLevel, tracked bool) {
+	if len(levels) == 0 {
+		return nil, false
+	}
+	var whole *models.StockLevel
+	for i := range levels {
+		switch {
+		case levels[i].VariantName == variantName && levels[i].VariantValue == variantValue:
+			return &levels[i], true
+		case levels[i].VariantName == "" && levels[i].VariantValue == "":
+			whole = &levels[i]
+		}
+	}
+	return whole, true
+}
+
+// reserveStock holds stock for the lines of a new order. Lines of untracked products are skipped.
+func reserveStock(ctx context.Context, tx *sql.Tx, orderID string, actorID *string, items []pricedItem) error {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductID)
+	}
+	stock, err := lockStock(ctx, tx, ids)
+	if err != nil {
+		return status.Errorf(codes.Internal, "stock query error: %v", err)
+	}
+
+	// Several lines may draw from the same level
+	needed := make(map[string]int)
+	var levelIDs []string
+	for _, item := range items {
+		level, tracked := stockFor(stock[item.ProductID], item.VariantName, item.VariantValue)
+		if !tracked {
+			continue
+		}
+		if level == nil {
+			return status.Errorf(codes.FailedPrecondition, "product %s is out of stock", item.ProductID)
+		}
+		if _, ok := needed[level.ID]; !ok {
+			levelIDs = append(levelIDs, level.ID)
+		}
+		needed[level.ID] += item.Quantity
+		if needed[level.ID] > level.Available() {
+			return status.Errorf(codes.FailedPrecondition, "product %s is out of stock: %d available", item.ProductID, level.Available())
+		}
+	}
+
+	for _, levelID := range levelIDs {
+		_, err := applyStockMove(ctx, tx, stockMove{
+			StockID:        levelID,
+			Kind:           models.StockMovementReserve,
+			ReservedChange: needed[levelID],
+			OrderID:        &orderID,
+			ActorID:        actorID,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "stock reserve error: %v", err)
+		}
+	}
+	return nil
+}
+
+// settleStock ends the reservations of an order: a release returns the units to sale,
+// a commit takes them out of on_hand. Orders without tracked products have nothing to settle.
+func settleStock(ctx context.Context, tx *sql.Tx, orderID, kind string, actorID *string) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT stock_id, SUM(reserved_change)
+		FROM stock_movements
+		WHERE order_id = $1
+		GROUP BY stock_id
+		HAVING SUM(reserved_change) > 0
+	`, orderID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "stock query error: %v", err)
+	}
+	held := make(map[string]int)
+	var levelIDs []string
+	for rows.Next() {
+		var levelID string
+		var quantity int
+		if err := rows.Scan(&levelID, &quantity); err != nil {
+			rows.Close()
+			return status.Errorf(codes.Internal, "stock scan error: %v", err)
+		}
+		held[levelID] = quantity
+		levelIDs = append(levelIDs, levelID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return status.Errorf(codes.Internal, "stock query error: %v", err)
+	}
+	if len(levelIDs) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM product_stock WHERE id = ANY($1) ORDER BY id FOR UPDATE`, pq.Array(levelIDs)); err != nil {
+		return status.Errorf(codes.Internal, "stock lock error: %v", err)
+	}
+	for _, levelID := range levelIDs {
+		move := stockMove{
+			StockID:        levelID,
+			Kind:           kind,
+			ReservedChange: -held[levelID],
+			OrderID:        &orderID,
+			ActorID:        actorID,
+		}
+		if kind == models.StockMovementCommit {
+			move.OnHandChange = -held[levelID]
+		}
+		if _, err := applyStockMove(ctx, tx, move); err != nil {
+			return status.Errorf(codes.Internal, "stock %s error: %v", kind, err)
+		}
+	}
+	return nil
+}
+
+// AdjustStock changes the stock of a product or one of its variants; the first adjustment
+// starts tracking it. A product is tracked either as a whole or per variant, not both.
+func (s *ProductServiceServer) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.StockLevelResponse, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	productID := req.GetProductId()
+	if _, err := uuid.Parse(productID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product_id")
+	}
+	if req.OnHand == nil && req.GetDelta() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "delta or on_hand is required")
+	}
+	if req.OnHand != nil && req.GetOnHand() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "on_hand cannot be negative")
+	}
+	variantName := strings.TrimSpace(req.GetVariantName())
+	variantValue := strings.TrimSpace(req.GetVariantValue())
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "tx begin error: %v", err)
+	}
+	defer tx.Rollback()
+
+	products, err := loadOrderProducts(ctx, tx, []string{productID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	product, ok := products[productID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	if variantName != "" || variantValue != "" {
+		if _, ok := product.FindVariant(variantName, variantValue); !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "product has no variant %s: %s", variantName, variantValue)
+		}
+	}
+
+	stock, err := lockStock(ctx, tx, []string{productID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "stock query error: %v", err)
+	}
+	for _, level := range stock[productID] {
+		if (level.VariantName == "") != (variantName == "") {
+			return nil, status.Error(codes.FailedPrecondition, "stock of this product is tracked per variant or as a whole, not both")
+		}
+	}
+
+	var level models.StockLevel
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO product_stock (product_id, variant_name, variant_value)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (product_id, variant_name, variant_value) DO UPDATE SET updated_at = product_stock.updated_at
+		RETURNING id, on_hand, reserved
+	`, productID, variantName, variantValue).Scan(&level.ID, &level.OnHand, &level.Reserved)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "stock insert error: %v", err)
+	}
+
+	change := int(req.GetDelta())
+	if req.OnHand != nil {
+		change = int(req.GetOnHand()) - level.OnHand
+	}
+	if level.OnHand+change < level.Reserved {
+		return nil, status.Errorf(codes.FailedPrecondition, "on_hand cannot drop below the %d units reserved by open orders", level.Reserved)
+	}
+
+	level, err = applyStockMove(ctx, tx, stockMove{
+		StockID:      level.ID,
+		Kind:         models.StockMovementAdjustment,
+		OnHandChange: change,
+		ActorID:      &auth.UserID,
+		Note:         strings.TrimSpace(req.GetNote()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "stock update error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "commit error: %v", err)
+	}
+	return &pb.StockLevelResponse{Stock: mapper.ToPBStockLevel(level)}, nil
+}
+
+func (s *ProductServiceServer) GetProductStock(ctx context.Context, req *pb.GetProductStockRequest) (*pb.GetProductStockResponse, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, product_id, variant_name, variant_value, on_hand, reserved, updated_at
+		FROM product_stock
+		WHERE product_id = $1
+		ORDER BY variant_name, variant_value
+	`, req.GetProductId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &pb.GetProductStockResponse{}
+	for rows.Next() {
+		var level models.StockLevel
+		if err := rows.Scan(&level.ID, &level.ProductID, &level.VariantName, &level.VariantValue, &level.OnHand, &level.Reserved, &level.UpdatedAt); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		resp.Levels = append(resp.Levels, mapper.ToPBStockLevel(level))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	resp.Tracked = len(resp.Levels) > 0
+	return resp, nil
+}
+
+// ListStockMovements pages through the stock ledger of a product, newest first.
+func (s *ProductServiceServer) ListStockMovements(ctx context.Context, req *pb.ListStockMovementsRequest) (*pb.ListStockMovementsResponse, error) {
+	page := req.GetPage()
+	if page <= 0 {
+		page = 1
+	}
+	limit := req.GetLimit()
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	var total int32
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM stock_movements m JOIN product_stock s ON s.id = m.stock_id WHERE s.product_id = $1
+	`, req.GetProductId()).Scan(&total)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "count error: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT m.id, s.product_id, s.variant_name, s.variant_value, m.kind, m.on_hand_change, m.reserved_change,
+			m.on_hand_after, m.reserved_after, m.order_id, m.actor_id, m.note, m.created_at
+		FROM stock_movements m
+		JOIN product_stock s ON s.id = m.stock_id
+		WHERE s.product_id = $1
+		ORDER BY m.created_at DESC, m.id
+		LIMIT $2 OFFSET $3
+	`, req.GetProductId(), limit, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &pb.ListStockMovementsResponse{Total: total, Page: page, Limit: limit}
+	for rows.Next() {
+		var m models.StockMovement
+		var orderID, actorID sql.NullString
+		err := rows.Scan(&m.ID, &m.ProductID, &m.VariantName, &m.VariantValue, &m.Kind, &m.OnHandChange, &m.ReservedChange,
+			&m.OnHandAfter, &m.ReservedAfter, &orderID, &actorID, &m.Note, &m.CreatedAt)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		if orderID.Valid {
+			m.OrderID = &orderID.String
+		}
+		if actorID.Valid {
+			m.ActorID = &actorID.String
+		}
+		resp.Movements = append(resp.Movements, mapper.ToPBStockMovement(m))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return resp, nil
+}
+
+// stockSettlement maps an order status to how its reservations end, if they do.
+func stockSettlement(newStatus string) (string, bool) {
+	switch newStatus {
+	case models.OrderStatusCancelled:
+		return models.StockMovementRelease, true
+	case models.OrderStatusCompleted:
+		return models.StockMovementCommit, true
+	}
+	return "", false
+}
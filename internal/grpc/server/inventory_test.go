@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/otp"
+	"mebellar-backend/pkg/pb"
+	"mebellar-backend/pkg/sms"
+	"mebellar-backend/pkg/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestInventory(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, &sms.MockSMSService{}, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+	orderService := NewOrderServiceServer(db, nil)
+	productService := NewProductServiceServer(db)
+
+	shopID, ownerID := createTestShop(t, db, "+998907770101")
+	sellerToken, err := authService.signAccessToken(ctx, ownerID, "+998907770101", models.RoleSeller, "", false)
+	require.NoError(t, err)
+	sofaID := createTestProduct(t, db, shopID, 5000000, nil,
+		`[{"name": "Rang", "value": "Kulrang"}, {"name": "Rang", "value": "Qizil"}]`, `{"is_home_region_free": true}`)
+	tableID := createTestProduct(t, db, shopID, 1200000, nil, `[]`, `{"is_home_region_free": true}`)
+
+	var reasonID int
+	require.NoError(t, db.QueryRow(`
+		INSERT INTO cancellation_reasons (reason_text, sort_order) VALUES ($1, 100) RETURNING id
+	`, "Omborda qolmadi "+uuid.NewString()[:8]).Scan(&reasonID))
+
+	adjust := func(req *pb.AdjustStockRequest) (*pb.StockLevel, error) {
+		resp, err := callAuthenticated(t, jwtKeys, db, sellerToken, pb.ProductService_AdjustStock_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return productService.AdjustStock(ctx, req)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*pb.StockLevelResponse).Stock, nil
+	}
+	stockOf := func() *pb.StockLevel {
+		req := &pb.GetProductStockRequest{ProductId: sofaID}
+		resp, err := callAuthenticated(t, jwtKeys, db, sellerToken, pb.ProductService_GetProductStock_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return productService.GetProductStock(ctx, req)
+		})
+		require.NoError(t, err)
+		levels := resp.(*pb.GetProductStockResponse).Levels
+		require.Len(t, levels, 1)
+		return levels[0]
+	}
+	order := func(productID, variantValue string, quantity int32) (string, error) {
+		item := &pb.OrderItemInput{ProductId: productID, Quantity: quantity}
+		if variantValue != "" {
+			item.VariantName, item.VariantValue = "Rang", variantValue
+		}
+		resp, err := orderService.CreateOrder(ctx, &pb.CreateOrderRequest{
+			ShopId:      shopID,
+			ClientName:  "Guest",
+			ClientPhone: "+998901112233",
+			Items:       []*pb.OrderItemInput{item},
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.Order.Id, nil
+	}
+	setStatus := func(orderID string, st pb.OrderStatus) {
+		req := &pb.UpdateOrderStatusRequest{Id: orderID, Status: st, CancellationReasonId: strconv.Itoa(reasonID)}
+		_, err := callAuthenticated(t, jwtKeys, db, sellerToken, pb.OrderService_UpdateOrderStatus_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return orderService.UpdateOrderStatus(ctx, req)
+		})
+		require.NoError(t, err)
+	}
+
+	// Остаток ведется по вариантам; смешивать с остатком товара целиком нельзя
+	level, err := adjust(&pb.AdjustStockRequest{ProductId: sofaID, VariantName: "Rang", VariantValue: "Kulrang", OnHand: proto.Int32(3), Note: "Inventarizatsiya"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), level.Available)
+	_, err = adjust(&pb.AdjustStockRequest{ProductId: sofaID, Delta: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = adjust(&pb.AdjustStockRequest{ProductId: sofaID, VariantName: "Rang", VariantValue: "Yashil", Delta: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Параллельные заказы не продают больше, чем есть
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var placed []string
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if orderID, err := order(sofaID, "Kulrang", 1); err == nil {
+				mu.Lock()
+				placed = append(placed, orderID)
+				mu.Unlock()
+			} else {
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, placed, 3)
+	level = stockOf()
+	assert.Equal(t, int32(3), level.Reserved)
+	assert.Zero(t, level.Available)
+
+	// Вариант без остатка у отслеживаемого товара недоступен, неотслеживаемый товар продается
+	_, err = order(sofaID, "Qizil", 1)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = order(tableID, "", 5)
+	assert.NoError(t, err)
+
+	// Нельзя списать зарезервированное
+	_, err = adjust(&pb.AdjustStockRequest{ProductId: sofaID, VariantName: "Rang", VariantValue: "Kulrang", Delta: -1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// В каталоге товар без остатка помечен, а с in_stock_only исключен
+	list, err := productService.ListProducts(ctx, &pb.ListProductsRequest{Filters: &pb.ProductFilters{ShopId: shopID}})
+	require.NoError(t, err)
+	inStock := map[string]bool{}
+	for _, p := range list.Products {
+		inStock[p.Id] = p.InStock
+	}
+	assert.Equal(t, map[string]bool{sofaID: false, tableID: true}, inStock)
+	list, err = productService.ListProducts(ctx, &pb.ListProductsRequest{Filters: &pb.ProductFilters{ShopId: shopID, InStockOnly: true}})
+	require.NoError(t, err)
+	require.Len(t, list.Products, 1)
+	assert.Equal(t, tableID, list.Products[0].Id)
+
+	// Отмена возвращает резерв, завершение списывает со склада
+	setStatus(placed[0], pb.OrderStatus_ORDER_STATUS_CANCELLED)
+	for _, st := range []pb.OrderStatus{pb.OrderStatus_ORDER_STATUS_CONFIRMED, pb.OrderStatus_ORDER_STATUS_SHIPPING, pb.OrderStatus_ORDER_STATUS_COMPLETED} {
+		setStatus(placed[1], st)
+	}
+	level = stockOf()
+	assert.Equal(t, int32(2), level.OnHand)
+	assert.Equal(t, int32(1), level.Reserved)
+	assert.Equal(t, int32(1), level.Available)
+
+	// Журнал движений: новые сверху
+	req := &pb.ListStockMovementsRequest{ProductId: sofaID}
+	resp, err := callAuthenticated(t, jwtKeys, db, sellerToken, pb.ProductService_ListStockMovements_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+		return productService.ListStockMovements(ctx, req)
+	})
+	require.NoError(t, err)
+	movements := resp.(*pb.ListStockMovementsResponse)
+	assert.Equal(t, int32(6), movements.Total)
+	assert.Equal(t, models.StockMovementCommit, movements.Movements[0].Kind)
+	assert.Equal(t, int32(-1), movements.Movements[0].OnHandChange)
+	assert.Equal(t, placed[1], movements.Movements[0].OrderId)
+	assert.Equal(t, models.StockMovementRelease, movements.Movements[1].Kind)
+	last := movements.Movements[len(movements.Movements)-1]
+	assert.Equal(t, models.StockMovementAdjustment, last.Kind)
+	assert.Equal(t, "Inventarizatsiya", last.Note)
+	assert.Equal(t, ownerID, last.ActorId)
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"mebellar-backend/pkg/logger"
+	"mebellar-backend/pkg/sms"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// loginFreeAttempts is the number of wrong passwords, PINs or two-factor codes accepted before the account is locked.
+	loginFreeAttempts = 5
+	// loginLockoutBase is the first lockout; every further failure doubles it up to loginLockoutMax.
+	loginLockoutBase = 30 * time.Second
+	loginLockoutMax  = time.Hour
+	// loginFailureWindow is how long failures are remembered without a new one.
+	loginFailureWindow = 24 * time.Hour
+	// loginAlertInterval limits SMS warnings about failed logins to one per account.
+	loginAlertInterval = 24 * time.Hour
+)
+
+// Login methods counted in login_lockouts.last_method.
+const (
+	loginMethodPassword = "password"
+	loginMethodPin      = "pin"
+	loginMethodMfa      = "mfa"
+)
+
+// loginLockedError is returned while the account is locked after repeated failures.
+// RetryAfter is the remaining lockout, rounded up to whole seconds.
+type loginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *loginLockedError) Error() string {
+	return fmt.Sprintf("login is locked, retry in %s", e.RetryAfter)
+}
+
+// loginLockoutFor returns how long the account is locked after the given number of consecutive failures.
+func loginLockoutFor(failed int) time.Duration {
+	if failed < loginFreeAttempts {
+		return 0
+	}
+	lockout := loginLockoutBase
+	for i := loginFreeAttempts; i < failed && lockout < loginLockoutMax; i++ {
+		lockout *= 2
+	}
+	return min(lockout, loginLockoutMax)
+}
+
+// checkLoginLockout returns a *loginLockedError while the account is locked.
+// It runs before the password, PIN or two-factor code is checked, so a locked account gives nothing away.
+func checkLoginLockout(ctx context.Context, db *sql.DB, userID string) error {
+	var seconds int
+	err := db.QueryRowContext(ctx, `
+		SELECT CEIL(EXTRACT(EPOCH FROM locked_until - NOW()))::int FROM login_lockouts
+		WHERE user_id = $1 AND locked_until > NOW()
+	`, userID).Scan(&seconds)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return &loginLockedError{RetryAfter: time.Duration(max(seconds, 1)) * time.Second}
+}
+
+// recordLoginFailure counts a wrong password, PIN or two-factor code and locks the account once loginFreeAttempts
+// is reached. On the first lockout within loginAlertInterval the owner is warned by SMS.
+// It returns the *loginLockedError when this failure locked the account.
+func recordLoginFailure(ctx context.Context, db *sql.DB, sender sms.SMSService, userID, method string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var failed int
+	var alerted bool
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO login_lockouts (user_id, failed_attempts, last_method, last_failed_at)
+		VALUES ($1, 1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			failed_attempts = CASE
+				WHEN login_lockouts.last_failed_at < NOW() - $3 * INTERVAL '1 second' THEN 1
+				ELSE login_lockouts.failed_attempts + 1
+			END,
+			last_method = EXCLUDED.last_method,
+			last_failed_at = NOW(),
+			updated_at = NOW()
+		RETURNING failed_attempts, COALESCE(alert_sent_at > NOW() - $4 * INTERVAL '1 second', false)
+	`, userID, method, int(loginFailureWindow.Seconds()), int(loginAlertInterval.Seconds())).Scan(&failed, &alerted)
+	if err != nil {
+		return err
+	}
+
+	lockout := loginLockoutFor(failed)
+	if lockout == 0 {
+		return tx.Commit()
+	}
+
+	alert := !alerted && sender != nil
+	var phone string
+	err = tx.QueryRowContext(ctx, `
+		UPDATE login_lockouts l
+		SET locked_until = NOW() + $2 * INTERVAL '1 second',
+		    alert_sent_at = CASE WHEN $3 THEN NOW() ELSE l.alert_sent_at END
+		FROM users u
+		WHERE l.user_id = $1 AND u.id = l.user_id
+		RETURNING u.phone
+	`, userID, int(lockout.Seconds()), alert).Scan(&phone)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Warn("Login locked after failed attempts",
+		zap.String("user_id", userID),
+		zap.String("method", method),
+		zap.Int("failed_attempts", failed),
+		zap.Duration("lockout", lockout),
+	)
+
+	// The warning is best effort: the lockout already holds without it
+	if alert {
+		message := "Mebellar: hisobingizga kirish uchun bir necha marta noto'g'ri parol, PIN yoki tasdiqlash kodi kiritildi. " +
+			"Kirish vaqtincha bloklandi. Agar bu siz bo'lmasangiz, parolingizni o'zgartiring."
+		if err := sender.SendSMS(phone, message); err != nil {
+			logger.Error("Failed to send login lockout alert",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+		}
+	}
+	return &loginLockedError{RetryAfter: lockout}
+}
+
+// clearLoginFailures forgets failed attempts after a successful login or an admin unlock.
+// It reports whether there was anything to clear.
+func clearLoginFailures(ctx context.Context, db execer, userID string) (bool, error) {
+	result, err := db.ExecContext(ctx, `DELETE FROM login_lockouts WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, err
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
@@ -0,0 +1,367 @@
+package server
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/totp"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	// mfaIssuer is the account issuer shown in authenticator apps.
+	mfaIssuer = "Mebellar"
+	// mfaChallengeTTL is how long the second login step may take after the password was accepted.
+	mfaChallengeTTL = 5 * time.Minute
+	// mfaChallengeMaxAttempts is how many codes one challenge accepts before the user must log in again.
+	mfaChallengeMaxAttempts = 3
+	// mfaRecoveryCodeCount is the number of recovery codes issued when MFA is enabled.
+	mfaRecoveryCodeCount = 10
+	// tokenTypeMFAChallenge is the "typ" claim of challenge tokens; the auth interceptor rejects them.
+	tokenTypeMFAChallenge = "mfa_challenge"
+)
+
+var (
+	// errMfaNotEnabled is returned when the user has no confirmed TOTP secret.
+	errMfaNotEnabled = errors.New("two-factor authentication is not enabled")
+	// errMfaAlreadyEnabled is returned when setting up MFA a second time.
+	errMfaAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// errMfaSetupNotStarted is returned when confirming MFA without a pending secret.
+	errMfaSetupNotStarted = errors.New("two-factor setup was not started")
+	// errMfaInvalidCode is returned for wrong, expired or already used codes.
+	errMfaInvalidCode = errors.New("invalid two-factor code")
+	// errMfaChallengeClosed is returned for challenges that expired, were used or ran out of attempts.
+	errMfaChallengeClosed = errors.New("two-factor challenge is no longer valid")
+)
+
+// mfaChallenge is a parsed challenge token.
+type mfaChallenge struct {
+	ID         string
+	UserID     string
+	DeviceName string
+	DeviceID   string
+}
+
+// mfaRequiredForRole reports whether accounts with the role must use TOTP.
+func mfaRequiredForRole(role string) bool {
+	return role == models.RoleAdmin || role == models.RoleModerator
+}
+
+// mfaCipher derives the AES-256-GCM cipher used for TOTP secrets at rest.
+func mfaCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) == 0 {
+		return nil, errors.New("MFA key is not configured")
+	}
+	sum := sha256.Sum256(key)
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealMfaSecret encrypts a TOTP secret for user_mfa.secret_enc; the nonce is prepended.
+func sealMfaSecret(key []byte, secret string) (string, error) {
+	gcm, err := mfaCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+// openMfaSecret decrypts a value produced by sealMfaSecret.
+func openMfaSecret(key []byte, sealed string) (string, error) {
+	gcm, err := mfaCipher(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("sealed MFA secret is too short")
+	}
+	secret, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+// hashRecoveryCode returns the value stored in user_mfa.recovery_codes.
+// Codes are compared case-insensitively and without spaces.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// mfaEnabled reports whether the user has confirmed TOTP.
+func mfaEnabled(ctx context.Context, db *sql.DB, userID string) (bool, error) {
+	var enabled bool
+	err := db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM user_mfa WHERE user_id = $1 AND enabled_at IS NOT NULL)
+	`, userID).Scan(&enabled)
+	return enabled, err
+}
+
+// beginMfaSetup stores a new pending TOTP secret for the user and returns it.
+// An unconfirmed secret from an earlier attempt is replaced.
+func beginMfaSetup(ctx context.Context, db *sql.DB, key []byte, userID string) (string, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := sealMfaSecret(key, secret)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := db.ExecContext(ctx, `
+		INSERT INTO user_mfa (user_id, secret_enc)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret_enc = EXCLUDED.secret_enc, last_used_step = 0, recovery_codes = '{}', updated_at = NOW()
+		WHERE user_mfa.enabled_at IS NULL
+	`, userID, sealed)
+	if err != nil {
+		return "", err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return "", errMfaAlreadyEnabled
+	}
+	return secret, nil
+}
+
+// confirmMfaSetup enables MFA once the first code of the pending secret is correct
+// and returns the plain recovery codes; only their hashes are stored.
+func confirmMfaSetup(ctx context.Context, db *sql.DB, key []byte, userID, code string) ([]string, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var sealed string
+	var enabledAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT secret_enc, enabled_at FROM user_mfa WHERE user_id = $1 FOR UPDATE
+	`, userID).Scan(&sealed, &enabledAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errMfaSetupNotStarted
+	}
+	if err != nil {
+		return nil, err
+	}
+	if enabledAt.Valid {
+		return nil, errMfaAlreadyEnabled
+	}
+
+	secret, err := openMfaSecret(key, sealed)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := totp.Validate(secret, code, time.Now(), 0)
+	if !ok {
+		return nil, errMfaInvalidCode
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(mfaRecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = hashRecoveryCode(c)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE user_mfa
+		SET enabled_at = NOW(), last_used_step = $1, recovery_codes = $2, updated_at = NOW()
+		WHERE user_id = $3
+	`, step, pq.Array(hashes), userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifyMfaCode checks a TOTP or recovery code of a user with MFA enabled.
+// The accepted TOTP step and used recovery codes are burned so each code works once.
+func verifyMfaCode(ctx context.Context, db *sql.DB, key []byte, userID, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return errMfaInvalidCode
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sealed string
+	var lastStep int64
+	var recovery pq.StringArray
+	err = tx.QueryRowContext(ctx, `
+		SELECT secret_enc, last_used_step, recovery_codes FROM user_mfa
+		WHERE user_id = $1 AND enabled_at IS NOT NULL FOR UPDATE
+	`, userID).Scan(&sealed, &lastStep, &recovery)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errMfaNotEnabled
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(code) == totp.Digits {
+		secret, err := openMfaSecret(key, sealed)
+		if err != nil {
+			return err
+		}
+		step, ok := totp.Validate(secret, code, time.Now(), lastStep)
+		if !ok {
+			return errMfaInvalidCode
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE user_mfa SET last_used_step = $1, updated_at = NOW() WHERE user_id = $2
+		`, step, userID); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	i := slices.Index(recovery, hashRecoveryCode(code))
+	if i < 0 {
+		return errMfaInvalidCode
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE user_mfa SET recovery_codes = $1, updated_at = NOW() WHERE user_id = $2
+	`, pq.Array(slices.Delete(recovery, i, i+1)), userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// disableMfa removes the user's TOTP secret and drops the MFA mark from their sessions.
+func disableMfa(ctx context.Context, db *sql.DB, userID string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE user_sessions SET mfa_verified = false WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// markSessionMFA records that the session passed the second factor, so refreshed
+// access tokens keep the mfa claim.
+func markSessionMFA(ctx context.Context, db *sql.DB, userID, sessionID string) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE user_sessions SET mfa_verified = true
+		WHERE id = $1 AND user_id = $2 AND (expires_at IS NULL OR expires_at > NOW())
+	`, sessionID, userID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return errSessionNotFound
+	}
+	return nil
+}
+
+// signMfaChallenge signs the token that links the password step of a login to VerifyMfaLogin.
+// Device fields from the login request body are carried over to the session created later.
+// The challenge is stored under its "jti" so attempts can be counted and it can be used only once.
+func (s *AuthServiceServer) signMfaChallenge(ctx context.Context, userID string, device sessionDevice) (string, error) {
+	challengeID := uuid.NewString()
+	expiresAt := time.Now().Add(mfaChallengeTTL)
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM mfa_challenges WHERE user_id = $1 AND expires_at < NOW()`, userID); err != nil {
+		return "", err
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO mfa_challenges (id, user_id, expires_at) VALUES ($1, $2, $3)
+	`, challengeID, userID, expiresAt)
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwt.MapClaims{
+		"jti":         challengeID,
+		"user_id":     userID,
+		"device_name": device.Name,
+		"device_id":   device.ID,
+		"typ":         tokenTypeMFAChallenge,
+		"exp":         expiresAt.Unix(),
+		"iat":         time.Now().Unix(),
+	}
+	return s.keys.Sign(claims)
+}
+
+// parseMfaChallenge validates a challenge token and returns the challenge with the login device name and ID.
+func (s *AuthServiceServer) parseMfaChallenge(tokenString string) (*mfaChallenge, error) {
+	token, err := s.keys.Parse(strings.TrimSpace(tokenString))
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid MFA challenge token")
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	challenge := &mfaChallenge{}
+	challenge.ID, _ = claims["jti"].(string)
+	challenge.UserID, _ = claims["user_id"].(string)
+	if typ, _ := claims["typ"].(string); typ != tokenTypeMFAChallenge || challenge.UserID == "" || challenge.ID == "" {
+		return nil, errors.New("invalid MFA challenge token")
+	}
+	challenge.DeviceName, _ = claims["device_name"].(string)
+	challenge.DeviceID, _ = claims["device_id"].(string)
+	return challenge, nil
+}
+
+// takeMfaChallengeAttempt counts a code submitted for the challenge before it is checked, so
+// parallel requests cannot exceed mfaChallengeMaxAttempts. It returns errMfaChallengeClosed when
+// the challenge expired, was used or has no attempts left.
+func takeMfaChallengeAttempt(ctx context.Context, db *sql.DB, challengeID string) error {
+	var attempts int
+	err := db.QueryRowContext(ctx, `
+		UPDATE mfa_challenges SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2 AND expires_at > NOW()
+		RETURNING attempts
+	`, challengeID, mfaChallengeMaxAttempts).Scan(&attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errMfaChallengeClosed
+	}
+	return err
+}
+
+// closeMfaChallenge deletes the challenge after a successful login so it cannot be replayed.
+func closeMfaChallenge(ctx context.Context, db *sql.DB, challengeID string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM mfa_challenges WHERE id = $1`, challengeID)
+	return err
+}
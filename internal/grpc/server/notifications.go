@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/email"
+	"mebellar-backend/pkg/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// notificationTimeout bounds background email delivery after the RPC has returned.
+const notificationTimeout = time.Minute
+
+// languageFromContext returns the client language (uz, ru, en) from the accept-language header.
+func languageFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("accept-language"); len(values) > 0 {
+			return email.NormalizeLanguage(values[0])
+		}
+	}
+	return email.DefaultLanguage
+}
+
+// notifyOrderCreated emails the seller about a new order and, when the order is linked
+// to an account with an email, sends the buyer a receipt. The client phone is not trusted
+// for this: anyone can enter it, so unlinked guest orders get no receipt.
+// Delivery is best-effort and runs in the background.
+func notifyOrderCreated(ctx context.Context, db *sql.DB, sender email.Sender, order models.Order) {
+	if sender == nil {
+		return
+	}
+	lang := languageFromContext(ctx)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+
+		var shopName []byte
+		var sellerEmail, buyerEmail string
+		err := db.QueryRowContext(ctx, `
+			SELECT sh.name, COALESCE(u.email, ''),
+			       COALESCE((SELECT email FROM users WHERE id = $2 AND COALESCE(is_active, true)), '')
+			FROM shops sh
+			JOIN seller_profiles sp ON sp.id = sh.seller_id
+			JOIN users u ON u.id = sp.user_id
+			WHERE sh.id = $1
+		`, order.ShopID, order.UserID).Scan(&shopName, &sellerEmail, &buyerEmail)
+		if err != nil {
+			logger.Error("Failed to load order notification recipients",
+				zap.String("order_id", order.ID),
+				zap.Error(err),
+			)
+			return
+		}
+
+		data := orderEmailData(order, shopDisplayName(shopName))
+		// The seller's language is unknown, the receipt follows the buyer's app language
+		sendTemplate(ctx, sender, sellerEmail, email.TemplateSellerNewOrder, email.DefaultLanguage, data)
+		sendTemplate(ctx, sender, buyerEmail, email.TemplateOrderReceipt, lang, data)
+	}()
+}
+
+func orderEmailData(order models.Order, shopName string) email.OrderData {
+	data := email.OrderData{
+		OrderID:       order.ID,
+		ShopName:      shopName,
+		ClientName:    order.ClientName,
+		ClientPhone:   order.ClientPhone,
+		ClientAddress: order.ClientAddress,
+		DeliveryPrice: order.DeliveryPrice,
+		TotalAmount:   order.TotalAmount,
+		CreatedAt:     order.CreatedAt.Format("02.01.2006 15:04"),
+	}
+	for _, item := range order.Items {
+		data.Items = append(data.Items, email.OrderItemData{
+			Name:     item.ProductName,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		})
+	}
+	return data
+}
+
+// sendTemplate renders and sends a templated email, logging failures. Empty recipients are skipped.
+func sendTemplate(ctx context.Context, sender email.Sender, to, template, lang string, data any) {
+	if to == "" {
+		return
+	}
+	msg, err := email.Render(template, lang, data)
+	if err == nil {
+		msg.To = to
+		err = sender.Send(ctx, msg)
+	}
+	if err != nil {
+		logger.Error("Failed to send email",
+			zap.String("template", template),
+			zap.Error(err),
+		)
+	}
+}
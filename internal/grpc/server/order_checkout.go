@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"mebellar-backend/internal/grpc/mapper"
+	"mebellar-backend/pkg/pb"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// shopItems are the basket items sold by one shop.
+type shopItems struct {
+	ShopID string
+	Items  []*pb.OrderItemInput
+}
+
+// Checkout turns a basket from several shops into one order per shop. All orders are priced
+// and written in one transaction under a shared checkout ID, so either every shop gets its
+// order or none does.
+func (s *OrderServiceServer) Checkout(ctx context.Context, req *pb.CheckoutRequest) (*pb.CheckoutResponse, error) {
+	if strings.TrimSpace(req.GetClientName()) == "" || strings.TrimSpace(req.GetClientPhone()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_name and client_phone are required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "tx begin error: %v", err)
+	}
+	defer tx.Rollback()
+
+	groups, err := groupItemsByShop(ctx, tx, req.GetItems())
+	if err != nil {
+		return nil, err
+	}
+
+	// Delivery is charged per shop; the grand total is checked once against what the client saw
+	regionID := strings.TrimSpace(req.GetRegionId())
+	orders := make([]*pricedOrder, 0, len(groups))
+	summary := &pricedOrder{}
+	for _, group := range groups {
+		priced, err := priceOrder(ctx, tx, group.ShopID, regionID, group.Items)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, priced)
+		summary.Items = append(summary.Items, priced.Items...)
+		summary.Subtotal += priced.Subtotal
+		summary.DeliveryPrice += priced.DeliveryPrice
+		summary.TotalAmount += priced.TotalAmount
+	}
+	summary.Subtotal = roundMoney(summary.Subtotal)
+	summary.DeliveryPrice = roundMoney(summary.DeliveryPrice)
+	summary.TotalAmount = roundMoney(summary.TotalAmount)
+	if err := checkExpectedTotal(req.GetTotalAmount(), summary); err != nil {
+		return nil, err
+	}
+
+	// Lock the stock of the whole basket at once, in id order: locking it shop by shop would let
+	// two checkouts of the same shops in a different order deadlock
+	productIDs := make([]string, 0, len(summary.Items))
+	for _, item := range summary.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	if _, err := lockStock(ctx, tx, productIDs); err != nil {
+		return nil, status.Errorf(codes.Internal, "stock query error: %v", err)
+	}
+
+	checkoutID := uuid.NewString()
+	buyerID := orderBuyerID(ctx)
+	orderIDs := make([]string, 0, len(orders))
+	for _, priced := range orders {
+		orderID, err := insertOrder(ctx, tx, newOrder{
+			CheckoutID:    &checkoutID,
+			BuyerID:       buyerID,
+			ClientName:    req.GetClientName(),
+			ClientPhone:   req.GetClientPhone(),
+			ClientAddress: req.GetClientAddress(),
+			ClientNote:    req.GetClientNote(),
+			Priced:        priced,
+		})
+		if err != nil {
+			return nil, err
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "commit error: %v", err)
+	}
+
+	resp := &pb.CheckoutResponse{
+		CheckoutId:    checkoutID,
+		Subtotal:      summary.Subtotal,
+		DeliveryPrice: summary.DeliveryPrice,
+		TotalAmount:   summary.TotalAmount,
+	}
+	for _, orderID := range orderIDs {
+		order, err := s.fetchOrder(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		s.announceOrderCreated(ctx, order)
+		resp.Orders = append(resp.Orders, mapper.ToPBOrder(order))
+	}
+	return resp, nil
+}
+
+// groupItemsByShop splits a basket by the products' shop, keeping shops in the order
+// they first appear. Availability and prices are checked later by priceOrder.
+func groupItemsByShop(ctx context.Context, q queryer, items []*pb.OrderItemInput) ([]shopItems, error) {
+	if len(items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one item is required")
+	}
+
+	ids := make([]string, 0, len(items))
+	for i, item := range items {
+		if _, err := uuid.Parse(item.GetProductId()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "items[%d]: invalid product_id", i)
+		}
+		ids = append(ids, item.GetProductId())
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT id, COALESCE(shop_id::text, '') FROM products WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	shopOf := make(map[string]string, len(ids))
+	for rows.Next() {
+		var productID, shopID string
+		if err := rows.Scan(&productID, &shopID); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		shopOf[productID] = shopID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+
+	var groups []shopItems
+	index := make(map[string]int)
+	for i, item := range items {
+		shopID := shopOf[item.GetProductId()]
+		if shopID == "" {
+			return nil, status.Errorf(codes.FailedPrecondition, "items[%d]: product %s is not available", i, item.GetProductId())
+		}
+		n, ok := index[shopID]
+		if !ok {
+			n = len(groups)
+			index[shopID] = n
+			groups = append(groups, shopItems{ShopID: shopID})
+		}
+		groups[n].Items = append(groups[n].Items, item)
+	}
+	return groups, nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"mebellar-backend/internal/grpc/mapper"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordOrderStatusChange appends an entry to the order's timeline. It must run in the
+// transaction that changes the status, so history and orders.status never disagree.
+func recordOrderStatusChange(ctx context.Context, db execer, change models.OrderStatusChange) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO order_status_history (order_id, old_status, new_status, actor_id, actor_role, note, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, change.OrderID, change.OldStatus, change.NewStatus, change.ActorID, change.ActorRole, change.Note, change.Reason)
+	return err
+}
+
+// fetchOrderHistory loads the order's timeline, oldest first.
+func fetchOrderHistory(ctx context.Context, q queryer, orderID string) ([]models.OrderStatusChange, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, order_id, old_status, new_status, actor_id, actor_role, note, reason, created_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []models.OrderStatusChange
+	for rows.Next() {
+		var change models.OrderStatusChange
+		if err := rows.Scan(
+			&change.ID, &change.OrderID, &change.OldStatus, &change.NewStatus, &change.ActorID,
+			&change.ActorRole, &change.Note, &change.Reason, &change.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
+func (s *OrderServiceServer) GetOrderHistory(ctx context.Context, req *pb.GetOrderHistoryRequest) (*pb.GetOrderHistoryResponse, error) {
+	orderID := strings.TrimSpace(req.GetOrderId())
+	if orderID == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	history, err := fetchOrderHistory(ctx, s.db, orderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+
+	resp := &pb.GetOrderHistoryResponse{}
+	for _, change := range history {
+		resp.History = append(resp.History, mapper.ToPBOrderStatusChange(change))
+	}
+	return resp, nil
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// orderPriceTolerance absorbs rounding differences between the client's total and ours.
+const orderPriceTolerance = 0.01
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// pricedItem is an order line resolved against products: name, image and unit price
+// are snapshots taken from the database, never from the client.
+type pricedItem struct {
+	ProductID    string
+	ProductName  string
+	ProductImage string
+	VariantName  string
+	VariantValue string
+	Quantity     int
+	Price        float64
+}
+
+// pricedOrder holds the server-computed amounts of one shop's order.
+type pricedOrder struct {
+	ShopID        string
+	Items         []pricedItem
+	Subtotal      float64
+	DeliveryPrice float64
+	TotalAmount   float64
+}
+
+// priceOrder resolves items against products of shopID and computes the totals.
+// Every product must be active and belong to the shop, and the shop must be active.
+// Delivery is charged once per order: the highest delivery price among its products for regionID.
+func priceOrder(ctx context.Context, q queryer, shopID, regionID string, items []*pb.OrderItemInput) (*pricedOrder, error) {
+	if len(items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one item is required")
+	}
+	if _, err := uuid.Parse(shopID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid shop_id")
+	}
+
+	var shopActive bool
+	err := q.QueryRowContext(ctx, `SELECT COALESCE(is_active, true) FROM shops WHERE id = $1`, shopID).Scan(&shopActive)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "shop not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if !shopActive {
+		return nil, status.Error(codes.FailedPrecondition, "shop is not accepting orders")
+	}
+
+	ids := make([]string, 0, len(items))
+	for i, item := range items {
+		if _, err := uuid.Parse(item.GetProductId()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "items[%d]: invalid product_id", i)
+		}
+		if item.GetQuantity() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "items[%d]: quantity must be positive", i)
+		}
+		ids = append(ids, item.GetProductId())
+	}
+
+	products, err := loadOrderProducts(ctx, q, ids)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+
+	order := &pricedOrder{ShopID: shopID}
+	for i, item := range items {
+		product, ok := products[item.GetProductId()]
+		if !ok || !product.IsActive || product.ShopID != shopID {
+			return nil, status.Errorf(codes.FailedPrecondition, "items[%d]: product %s is not available in this shop", i, item.GetProductId())
+		}
+
+		line, err := resolveLine(product, item.GetVariantName(), item.GetVariantValue())
+		if errors.Is(err, errNoSuchVariant) {
+			return nil, status.Errorf(codes.FailedPrecondition, "items[%d]: product %s has no variant %s: %s", i, product.ID, line.VariantName, line.VariantValue)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "items[%d]: product %s has no valid price", i, product.ID)
+		}
+		line.Quantity = int(item.GetQuantity())
+
+		delivery, _, _ := product.DeliverySettings.GetRegionPrice(regionID)
+		order.DeliveryPrice = math.Max(order.DeliveryPrice, delivery)
+		order.Subtotal += line.Price * float64(line.Quantity)
+		order.Items = append(order.Items, line)
+	}
+
+	order.Subtotal = roundMoney(order.Subtotal)
+	order.DeliveryPrice = roundMoney(order.DeliveryPrice)
+	order.TotalAmount = roundMoney(order.Subtotal + order.DeliveryPrice)
+	return order, nil
+}
+
+// Errors of resolveLine.
+var (
+	errNoSuchVariant = errors.New("variant not found")
+	errNoValidPrice  = errors.New("no valid price")
+)
+
+// resolveLine applies the pricing rules to one line of a product: the variant, if given,
+// must exist and the unit price (discount plus the variant's modifier) must be positive.
+// The returned item has no quantity; its variant fields are set even on error.
+func resolveLine(product models.Product, variantName, variantValue string) (pricedItem, error) {
+	line := pricedItem{
+		ProductID:    product.ID,
+		VariantName:  strings.TrimSpace(variantName),
+		VariantValue: strings.TrimSpace(variantValue),
+	}
+
+	var variant map[string]interface{}
+	if line.VariantName != "" || line.VariantValue != "" {
+		var ok bool
+		if variant, ok = product.FindVariant(line.VariantName, line.VariantValue); !ok {
+			return line, errNoSuchVariant
+		}
+	}
+
+	line.Price = roundMoney(product.UnitPrice(variant))
+	if line.Price <= 0 {
+		return line, errNoValidPrice
+	}
+	line.ProductName = product.GetName("uz")
+	line.ProductImage = product.ImageFor(variant)
+	return line, nil
+}
+
+// loadOrderProducts loads the products referenced by an order, keyed by ID.
+func loadOrderProducts(ctx context.Context, q queryer, ids []string) (map[string]models.Product, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, COALESCE(shop_id::text, ''), name, price, discount_price, images, variants, delivery_settings, COALESCE(is_active, true)
+		FROM products
+		WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make(map[string]models.Product, len(ids))
+	for rows.Next() {
+		var p models.Product
+		var discount sql.NullFloat64
+		if err := rows.Scan(&p.ID, &p.ShopID, &p.Name, &p.Price, &discount, &p.Images, &p.Variants, &p.DeliverySettings, &p.IsActive); err != nil {
+			return nil, err
+		}
+		if discount.Valid {
+			p.DiscountPrice = &discount.Float64
+		}
+		products[p.ID] = p
+	}
+	return products, rows.Err()
+}
+
+// checkExpectedTotal returns a FailedPrecondition status carrying pb.OrderPriceChanged
+// when the client's expected total no longer matches. A zero expected total skips the check.
+func checkExpectedTotal(expected float64, order *pricedOrder) error {
+	if expected == 0 || math.Abs(expected-order.TotalAmount) < orderPriceTolerance {
+		return nil
+	}
+
+	details := &pb.OrderPriceChanged{
+		ExpectedTotal: expected,
+		TotalAmount:   order.TotalAmount,
+		DeliveryPrice: order.DeliveryPrice,
+	}
+	for _, item := range order.Items {
+		details.Items = append(details.Items, &pb.OrderItem{
+			ProductId:    item.ProductID,
+			ProductName:  item.ProductName,
+			ProductImage: item.ProductImage,
+			Quantity:     int32(item.Quantity),
+			Price:        item.Price,
+			VariantName:  item.VariantName,
+			VariantValue: item.VariantValue,
+		})
+	}
+
+	st := status.New(codes.FailedPrecondition, fmt.Sprintf("prices have changed: expected total %.2f, actual %.2f", expected, order.TotalAmount))
+	if withDetails, err := st.WithDetails(details); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// roundMoney rounds an amount to tiyin, matching NUMERIC(15, 2) columns.
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"mebellar-backend/internal/grpc/mapper"
 	"mebellar-backend/internal/grpc/middleware"
 	"mebellar-backend/models"
+	"mebellar-backend/pkg/email"
 	"mebellar-backend/pkg/pb"
 	"mebellar-backend/pkg/websocket"
 
@@ -23,31 +25,24 @@ import (
 type OrderServiceServer struct {
 	pb.UnimplementedOrderServiceServer
 	db          *sql.DB
+	email       email.Sender
 	broadcaster *orderBroadcaster
 }
 
-func NewOrderServiceServer(db *sql.DB) *OrderServiceServer {
+func NewOrderServiceServer(db *sql.DB, emailSender email.Sender) *OrderServiceServer {
 	return &OrderServiceServer{
 		db:          db,
+		email:       emailSender,
 		broadcaster: newOrderBroadcaster(),
 	}
 }
 
 func (s *OrderServiceServer) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.OrderResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
+	// Guest checkout: the shop always comes from the request, X-Shop-ID is not verified for public methods
 	shopID := strings.TrimSpace(req.GetShopId())
-	if shopID == "" && auth != nil {
-		shopID = auth.ShopID
-	}
 	if shopID == "" {
 		return nil, status.Error(codes.InvalidArgument, "shop_id is required")
 	}
-	if len(req.GetItems()) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "at least one item is required")
-	}
-
-	orderID := uuid.NewString()
-	now := time.Now()
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -55,24 +50,25 @@ func (s *OrderServiceServer) CreateOrder(ctx context.Context, req *pb.CreateOrde
 	}
 	defer tx.Rollback()
 
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO orders (id, shop_id, client_name, client_phone, client_address, total_amount, delivery_price, status, client_note, seller_note, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, '', $10, $11)
-	`, orderID, shopID, req.GetClientName(), req.GetClientPhone(), req.GetClientAddress(),
-		req.GetTotalAmount(), req.GetDeliveryPrice(), models.OrderStatusNew, req.GetClientNote(), now, now)
+	// Prices, names and images come from products; the client only sends what it expects to pay
+	priced, err := priceOrder(ctx, tx, shopID, strings.TrimSpace(req.GetRegionId()), req.GetItems())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "insert order error: %v", err)
+		return nil, err
+	}
+	if err := checkExpectedTotal(req.GetTotalAmount(), priced); err != nil {
+		return nil, err
 	}
 
-	for _, item := range req.GetItems() {
-		itemID := uuid.NewString()
-		_, err := tx.ExecContext(ctx, `
-			INSERT INTO order_items (id, order_id, product_id, product_name, product_image, quantity, price, created_at)
-			VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8)
-		`, itemID, orderID, item.GetProductId(), item.GetProductName(), item.GetProductImage(), item.GetQuantity(), item.GetPrice(), now)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "insert item error: %v", err)
-		}
+	orderID, err := insertOrder(ctx, tx, newOrder{
+		BuyerID:       orderBuyerID(ctx),
+		ClientName:    req.GetClientName(),
+		ClientPhone:   req.GetClientPhone(),
+		ClientAddress: req.GetClientAddress(),
+		ClientNote:    req.GetClientNote(),
+		Priced:        priced,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -83,16 +79,79 @@ func (s *OrderServiceServer) CreateOrder(ctx context.Context, req *pb.CreateOrde
 	if err != nil {
 		return nil, err
 	}
+	s.announceOrderCreated(ctx, order)
+
+	return &pb.OrderResponse{Order: mapper.ToPBOrder(order)}, nil
+}
+
+// newOrder is a priced order ready to be written by insertOrder.
+type newOrder struct {
+	CheckoutID    *string
+	BuyerID       *string
+	ClientName    string
+	ClientPhone   string
+	ClientAddress string
+	ClientNote    string
+	Priced        *pricedOrder
+}
+
+// orderBuyerID returns the account placing an order: CreateOrder and Checkout authenticate
+// optionally, so it is nil for guests. API keys never buy.
+func orderBuyerID(ctx context.Context) *string {
+	if auth := middleware.GetAuthContext(ctx); auth != nil && auth.APIKeyID == "" {
+		return &auth.UserID
+	}
+	return nil
+}
+
+// insertOrder writes the order, its items and the creation entry of its timeline, and reserves
+// stock for the items. It returns the new ID.
+func insertOrder(ctx context.Context, tx *sql.Tx, o newOrder) (string, error) {
+	orderID := uuid.NewString()
+	now := time.Now()
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO orders (id, shop_id, checkout_id, user_id, client_name, client_phone, client_address, total_amount, delivery_price, status, client_note, seller_note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, '', $12, $13)
+	`, orderID, o.Priced.ShopID, o.CheckoutID, o.BuyerID, o.ClientName, o.ClientPhone, o.ClientAddress,
+		o.Priced.TotalAmount, o.Priced.DeliveryPrice, models.OrderStatusNew, o.ClientNote, now, now)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "insert order error: %v", err)
+	}
 
+	for _, item := range o.Priced.Items {
+		itemID := uuid.NewString()
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO order_items (id, order_id, product_id, product_name, product_image, quantity, price, variant_name, variant_value, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, itemID, orderID, item.ProductID, item.ProductName, item.ProductImage, item.Quantity, item.Price, item.VariantName, item.VariantValue, now)
+		if err != nil {
+			return "", status.Errorf(codes.Internal, "insert item error: %v", err)
+		}
+	}
+
+	creation := models.OrderStatusChange{OrderID: orderID, NewStatus: models.OrderStatusNew, ActorRole: models.OrderActorBuyer, ActorID: o.BuyerID}
+	if err := recordOrderStatusChange(ctx, tx, creation); err != nil {
+		return "", status.Errorf(codes.Internal, "insert history error: %v", err)
+	}
+	if err := reserveStock(ctx, tx, orderID, o.BuyerID, o.Priced.Items); err != nil {
+		return "", err
+	}
+	return orderID, nil
+}
+
+// announceOrderCreated tells the shop about a committed order: stream subscribers,
+// the legacy WebSocket hub and email notifications.
+func (s *OrderServiceServer) announceOrderCreated(ctx context.Context, order models.Order) {
 	// Broadcast to gRPC stream subscribers
-	s.broadcaster.Publish(shopID, &pb.OrderEvent{
+	s.broadcaster.Publish(order.ShopID, &pb.OrderEvent{
 		Type:  pb.OrderEventType_ORDER_EVENT_TYPE_CREATED,
 		Order: mapper.ToPBOrder(order),
 	})
 
 	// Also broadcast to WebSocket hub for backward compatibility
 	if len(order.Items) > 0 {
-		websocket.BroadcastNewOrder(shopID, websocket.NewOrderPayload{
+		websocket.BroadcastNewOrder(order.ShopID, websocket.NewOrderPayload{
 			OrderID:      order.ID,
 			ClientName:   order.ClientName,
 			ClientPhone:  order.ClientPhone,
@@ -104,9 +163,10 @@ func (s *OrderServiceServer) CreateOrder(ctx context.Context, req *pb.CreateOrde
 		})
 	}
 
-	return &pb.OrderResponse{Order: mapper.ToPBOrder(order)}, nil
+	notifyOrderCreated(ctx, s.db, s.email, order)
 }
 
+// GetOrder is limited by its policy to the buyer, the shop's staff and admins.
 func (s *OrderServiceServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.OrderResponse, error) {
 	if strings.TrimSpace(req.GetId()) == "" {
 		return nil, status.Error(codes.InvalidArgument, "id is required")
@@ -119,22 +179,103 @@ func (s *OrderServiceServer) GetOrder(ctx context.Context, req *pb.GetOrderReque
 }
 
 func (s *OrderServiceServer) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.OrderResponse, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
 	if strings.TrimSpace(req.GetId()) == "" {
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
+	if req.GetStatus() == pb.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
 	newStatus := mapper.ToModelOrderStatus(req.GetStatus())
 	if !models.IsValidOrderStatus(newStatus) {
 		return nil, status.Error(codes.InvalidArgument, "invalid status")
 	}
 
-	_, err := s.db.ExecContext(ctx, `
-		UPDATE orders SET status = $1, seller_note = COALESCE($2, seller_note), updated_at = NOW()
-		WHERE id = $3
-	`, newStatus, req.GetSellerNote(), req.GetId())
+	actor, err := orderActor(ctx, s.db, auth, req.GetId(), models.ShopRoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "tx begin error: %v", err)
+	}
+	defer tx.Rollback()
+
+	var oldStatus string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, req.GetId()).Scan(&oldStatus)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if !models.CanTransitionOrder(oldStatus, newStatus) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order status cannot change from %s to %s", oldStatus, newStatus)
+	}
+	if !models.CanActorTransitionOrder(actor, oldStatus, newStatus) {
+		if newStatus == models.OrderStatusCancelled {
+			return nil, status.Error(codes.FailedPrecondition, "the order can no longer be cancelled by the buyer")
+		}
+		return nil, status.Error(codes.PermissionDenied, "buyers can only cancel their orders")
+	}
+
+	var reason, rejectionNote, sellerNote string
+	if newStatus == models.OrderStatusCancelled {
+		reason, err = cancellationReason(ctx, tx, req.GetCancellationReasonId(), actor != models.OrderActorBuyer)
+		if err != nil {
+			return nil, err
+		}
+		rejectionNote = strings.TrimSpace(req.GetRejectionNote())
+	}
+	if actor != models.OrderActorBuyer {
+		sellerNote = strings.TrimSpace(req.GetSellerNote())
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE orders SET
+			status = $1,
+			seller_note = COALESCE(NULLIF($2, ''), seller_note),
+			cancellation_reason = COALESCE(NULLIF($3, ''), cancellation_reason),
+			rejection_note = COALESCE(NULLIF($4, ''), rejection_note),
+			confirmed_at = CASE WHEN $1 = $5 THEN NOW() ELSE confirmed_at END,
+			completed_at = CASE WHEN $1 = $6 THEN NOW() ELSE completed_at END,
+			updated_at = NOW()
+		WHERE id = $7
+	`, newStatus, sellerNote, reason, rejectionNote, models.OrderStatusConfirmed, models.OrderStatusCompleted, req.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "update error: %v", err)
 	}
 
+	note := sellerNote
+	if newStatus == models.OrderStatusCancelled {
+		note = rejectionNote
+	}
+	err = recordOrderStatusChange(ctx, tx, models.OrderStatusChange{
+		OrderID:   req.GetId(),
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+		ActorID:   &auth.UserID,
+		ActorRole: actor,
+		Note:      note,
+		Reason:    reason,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "insert history error: %v", err)
+	}
+	// Cancelling returns reserved stock to sale, completing takes it out of the warehouse
+	if kind, ok := stockSettlement(newStatus); ok {
+		if err := settleStock(ctx, tx, req.GetId(), kind, &auth.UserID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "commit error: %v", err)
+	}
+
 	order, err := s.fetchOrder(ctx, req.GetId())
 	if err != nil {
 		return nil, err
@@ -149,13 +290,39 @@ func (s *OrderServiceServer) UpdateOrderStatus(ctx context.Context, req *pb.Upda
 	// Also broadcast to WebSocket hub for backward compatibility
 	websocket.BroadcastOrderUpdate(order.ShopID, websocket.OrderUpdatePayload{
 		OrderID:   order.ID,
-		OldStatus: "", // We don't track old status in this context
+		OldStatus: oldStatus,
 		NewStatus: order.Status,
 	})
 
 	return &pb.OrderResponse{Order: mapper.ToPBOrder(order)}, nil
 }
 
+// cancellationReason resolves a reason from cancellation_reasons to its text.
+// The shop must give one when cancelling; for buyers it is optional.
+func cancellationReason(ctx context.Context, q queryer, reasonID string, required bool) (string, error) {
+	reasonID = strings.TrimSpace(reasonID)
+	if reasonID == "" {
+		if required {
+			return "", status.Error(codes.InvalidArgument, "cancellation_reason_id is required to cancel an order")
+		}
+		return "", nil
+	}
+	id, err := strconv.Atoi(reasonID)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid cancellation_reason_id")
+	}
+
+	var text string
+	err = q.QueryRowContext(ctx, `SELECT reason_text FROM cancellation_reasons WHERE id = $1 AND is_active = true`, id).Scan(&text)
+	if err == sql.ErrNoRows {
+		return "", status.Error(codes.InvalidArgument, "unknown cancellation reason")
+	}
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return text, nil
+}
+
 func (s *OrderServiceServer) DeleteOrder(ctx context.Context, req *pb.DeleteOrderRequest) (*pb.Empty, error) {
 	if strings.TrimSpace(req.GetId()) == "" {
 		return nil, status.Error(codes.InvalidArgument, "id is required")
@@ -167,14 +334,32 @@ func (s *OrderServiceServer) DeleteOrder(ctx context.Context, req *pb.DeleteOrde
 		return nil, err
 	}
 
-	_, err = s.db.ExecContext(ctx, `DELETE FROM order_items WHERE order_id = $1`, req.GetId())
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "tx begin error: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Stock still held by the order goes back to sale
+	var actorID *string
+	if auth := middleware.GetAuthContext(ctx); auth != nil {
+		actorID = &auth.UserID
+	}
+	if err := settleStock(ctx, tx, req.GetId(), models.StockMovementRelease, actorID); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM order_items WHERE order_id = $1`, req.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "delete items error: %v", err)
 	}
-	_, err = s.db.ExecContext(ctx, `DELETE FROM orders WHERE id = $1`, req.GetId())
+	_, err = tx.ExecContext(ctx, `DELETE FROM orders WHERE id = $1`, req.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "delete order error: %v", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "commit error: %v", err)
+	}
 
 	// Broadcast to gRPC stream subscribers
 	s.broadcaster.Publish(order.ShopID, &pb.OrderEvent{
@@ -202,35 +387,47 @@ func (s *OrderServiceServer) ListOrders(ctx context.Context, req *pb.ListOrdersR
 		return nil, status.Error(codes.InvalidArgument, "shop_id is required")
 	}
 
-	page := req.GetPage()
+	return s.listOrders(ctx, "shop_id = $1", shopID, req.GetStatuses(), req.GetPage(), req.GetLimit())
+}
+
+// ListMyOrders lists the caller's own orders across all shops, newest first.
+// Guest orders placed with the caller's phone are linked to the account first.
+func (s *OrderServiceServer) ListMyOrders(ctx context.Context, req *pb.ListMyOrdersRequest) (*pb.ListOrdersResponse, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if _, err := linkGuestOrders(ctx, s.db, auth.UserID); err != nil {
+		return nil, status.Errorf(codes.Internal, "link orders error: %v", err)
+	}
+	return s.listOrders(ctx, "user_id = $1", auth.UserID, req.GetStatuses(), req.GetPage(), req.GetLimit())
+}
+
+// listOrders pages through orders matching condition, whose only placeholder $1 is bound to key.
+func (s *OrderServiceServer) listOrders(ctx context.Context, condition, key string, statuses []pb.OrderStatus, page, limit int32) (*pb.ListOrdersResponse, error) {
 	if page <= 0 {
 		page = 1
 	}
-	limit := req.GetLimit()
 	if limit <= 0 || limit > 50 {
 		limit = 20
 	}
 	offset := (page - 1) * limit
 
-	args := []interface{}{shopID}
-	countQuery := `SELECT COUNT(*) FROM orders WHERE shop_id = $1`
-	dataQuery := `
-		SELECT id, shop_id, client_name, client_phone, COALESCE(client_address, ''), total_amount, delivery_price, status, COALESCE(client_note, ''), COALESCE(seller_note, ''), created_at, updated_at, completed_at
-		FROM orders
-		WHERE shop_id = $1
-	`
+	args := []interface{}{key}
+	countQuery := `SELECT COUNT(*) FROM orders WHERE ` + condition
+	dataQuery := `SELECT ` + orderColumns + ` FROM orders WHERE ` + condition
 	argIndex := 2
 
-	if len(req.GetStatuses()) > 0 {
-		placeholders := make([]string, len(req.GetStatuses()))
-		for i, st := range req.GetStatuses() {
+	if len(statuses) > 0 {
+		placeholders := make([]string, len(statuses))
+		for i, st := range statuses {
 			placeholders[i] = fmt.Sprintf("$%d", argIndex)
 			args = append(args, mapper.ToModelOrderStatus(st))
 			argIndex++
 		}
-		condition := " AND status IN (" + strings.Join(placeholders, ",") + ")"
-		countQuery += condition
-		dataQuery += condition
+		statusCondition := " AND status IN (" + strings.Join(placeholders, ",") + ")"
+		countQuery += statusCondition
+		dataQuery += statusCondition
 	}
 
 	dataQuery += " ORDER BY created_at DESC"
@@ -251,19 +448,11 @@ func (s *OrderServiceServer) ListOrders(ctx context.Context, req *pb.ListOrdersR
 	var orders []models.Order
 	var orderIDs []string
 	for rows.Next() {
-		var o models.Order
-		var completedAt sql.NullTime
-		if err := rows.Scan(
-			&o.ID, &o.ShopID, &o.ClientName, &o.ClientPhone, &o.ClientAddress,
-			&o.TotalAmount, &o.DeliveryPrice, &o.Status, &o.ClientNote, &o.SellerNote,
-			&o.CreatedAt, &o.UpdatedAt, &completedAt,
-		); err != nil {
+		o, err := scanOrder(rows)
+		if err != nil {
 			log.Printf("order scan error: %v", err)
 			continue
 		}
-		if completedAt.Valid {
-			o.CompletedAt = &completedAt.Time
-		}
 		orders = append(orders, o)
 		orderIDs = append(orderIDs, o.ID)
 	}
@@ -293,8 +482,18 @@ func (s *OrderServiceServer) ListOrders(ctx context.Context, req *pb.ListOrdersR
 
 func (s *OrderServiceServer) StreamOrders(req *pb.StreamOrdersRequest, stream pb.OrderService_StreamOrdersServer) error {
 	auth := middleware.GetAuthContext(stream.Context())
+	if auth == nil {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if auth.Role == models.RoleAdmin || auth.Role == models.RoleModerator {
+		if !auth.MFA {
+			return status.Error(codes.PermissionDenied, "two-factor authentication required")
+		}
+	} else if err := shopAccess(shopIDField, models.ShopRoleViewer)(stream.Context(), s.db, auth, req); err != nil {
+		return err
+	}
 	shopID := strings.TrimSpace(req.GetShopId())
-	if shopID == "" && auth != nil {
+	if shopID == "" {
 		shopID = auth.ShopID
 	}
 	if shopID == "" {
@@ -332,34 +531,71 @@ func (s *OrderServiceServer) StreamOrders(req *pb.StreamOrdersRequest, stream pb
 	}
 }
 
-// fetchOrder loads order with items.
-func (s *OrderServiceServer) fetchOrder(ctx context.Context, orderID string) (models.Order, error) {
+// linkGuestOrders links guest orders placed with the user's phone to the account.
+// Only a phone verified by SMS (phone_verified_at) proves ownership, so unverified
+// accounts link nothing.
+// It returns the number of orders linked.
+func linkGuestOrders(ctx context.Context, db execer, userID string) (int64, error) {
+	result, err := db.ExecContext(ctx, `
+		UPDATE orders o SET user_id = u.id
+		FROM users u
+		WHERE u.id = $1 AND u.phone_verified_at IS NOT NULL AND o.user_id IS NULL AND o.client_phone = u.phone
+	`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// orderColumns are the orders columns read by scanOrder, in order.
+const orderColumns = `id, shop_id, checkout_id, user_id, client_name, client_phone, COALESCE(client_address, ''), total_amount, delivery_price, status,
+	COALESCE(client_note, ''), COALESCE(seller_note, ''), COALESCE(cancellation_reason, ''), COALESCE(rejection_note, ''),
+	created_at, updated_at, completed_at, confirmed_at`
+
+// scanOrder reads a row selected with orderColumns.
+func scanOrder(row interface{ Scan(dest ...any) error }) (models.Order, error) {
 	var o models.Order
-	var completedAt sql.NullTime
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, shop_id, client_name, client_phone, COALESCE(client_address, ''), total_amount, delivery_price, status, COALESCE(client_note, ''), COALESCE(seller_note, ''), created_at, updated_at, completed_at
-		FROM orders
-		WHERE id = $1
-	`, orderID).Scan(
-		&o.ID, &o.ShopID, &o.ClientName, &o.ClientPhone, &o.ClientAddress,
-		&o.TotalAmount, &o.DeliveryPrice, &o.Status, &o.ClientNote, &o.SellerNote,
-		&o.CreatedAt, &o.UpdatedAt, &completedAt,
+	var checkoutID, userID sql.NullString
+	var completedAt, confirmedAt sql.NullTime
+	err := row.Scan(
+		&o.ID, &o.ShopID, &checkoutID, &userID, &o.ClientName, &o.ClientPhone, &o.ClientAddress,
+		&o.TotalAmount, &o.DeliveryPrice, &o.Status,
+		&o.ClientNote, &o.SellerNote, &o.CancellationReason, &o.RejectionNote,
+		&o.CreatedAt, &o.UpdatedAt, &completedAt, &confirmedAt,
 	)
+	if checkoutID.Valid {
+		o.CheckoutID = &checkoutID.String
+	}
+	if userID.Valid {
+		o.UserID = &userID.String
+	}
+	if completedAt.Valid {
+		o.CompletedAt = &completedAt.Time
+	}
+	if confirmedAt.Valid {
+		o.ConfirmedAt = &confirmedAt.Time
+	}
+	return o, err
+}
+
+// fetchOrder loads order with items and its status timeline.
+func (s *OrderServiceServer) fetchOrder(ctx context.Context, orderID string) (models.Order, error) {
+	o, err := scanOrder(s.db.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1`, orderID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return o, status.Error(codes.NotFound, "order not found")
 		}
 		return o, status.Errorf(codes.Internal, "query error: %v", err)
 	}
-	if completedAt.Valid {
-		o.CompletedAt = &completedAt.Time
-	}
 
 	items, err := s.fetchItemsForOrders(ctx, []string{orderID})
 	if err == nil {
 		o.Items = items[orderID]
 		o.ItemsCount = len(o.Items)
 	}
+	if o.History, err = fetchOrderHistory(ctx, s.db, orderID); err != nil {
+		return o, status.Errorf(codes.Internal, "query error: %v", err)
+	}
 	return o, nil
 }
 
@@ -371,7 +607,7 @@ func (s *OrderServiceServer) fetchItemsForOrders(ctx context.Context, orderIDs [
 		args[i] = id
 	}
 	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
-		SELECT id, order_id, product_id, product_name, product_image, quantity, price, created_at
+		SELECT id, order_id, product_id, product_name, product_image, quantity, price, variant_name, variant_value, created_at
 		FROM order_items
 		WHERE order_id IN (%s)
 		ORDER BY created_at ASC
@@ -386,7 +622,7 @@ func (s *OrderServiceServer) fetchItemsForOrders(ctx context.Context, orderIDs [
 		var item models.OrderItem
 		var productID sql.NullString
 		if err := rows.Scan(
-			&item.ID, &item.OrderID, &productID, &item.ProductName, &item.ProductImage, &item.Quantity, &item.Price, &item.CreatedAt,
+			&item.ID, &item.OrderID, &productID, &item.ProductName, &item.ProductImage, &item.Quantity, &item.Price, &item.VariantName, &item.VariantValue, &item.CreatedAt,
 		); err != nil {
 			continue
 		}
@@ -0,0 +1,487 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"testing"
+
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/otp"
+	"mebellar-backend/pkg/pb"
+	"mebellar-backend/pkg/sms"
+	"mebellar-backend/pkg/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createTestShop создает продавца с магазином и возвращает ID магазина и владельца
+func createTestShop(t *testing.T, db *sql.DB, phone string) (shopID, ownerID string) {
+	t.Helper()
+
+	ownerID, shopID = uuid.NewString(), uuid.NewString()
+	sellerID := uuid.NewString()
+	_, err := db.Exec(`
+		INSERT INTO users (id, full_name, phone, password_hash, role, is_active)
+		VALUES ($1, 'Seller', $2, 'x', $3, true)
+	`, ownerID, phone, models.RoleSeller)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO seller_profiles (id, user_id) VALUES ($1, $2)`, sellerID, ownerID)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO shops (id, seller_id, name, slug) VALUES ($1, $2, '{"uz": "Mebel Uy"}', $3)`, shopID, sellerID, "shop-"+shopID[:8])
+	require.NoError(t, err)
+	return shopID, ownerID
+}
+
+// createTestProduct создает активный товар магазина
+func createTestProduct(t *testing.T, db *sql.DB, shopID string, price float64, discount interface{}, variants, delivery string) string {
+	t.Helper()
+
+	var productID string
+	require.NoError(t, db.QueryRow(`
+		INSERT INTO products (shop_id, name, price, discount_price, images, variants, delivery_settings, is_active)
+		VALUES ($1, '{"uz": "Divan", "ru": "Диван"}', $2, $3, ARRAY['https://cdn.example.com/sofa.jpg'], $4, $5, true)
+		RETURNING id
+	`, shopID, price, discount, variants, delivery).Scan(&productID))
+	return productID
+}
+
+func TestOrderService_CreateOrderPricing(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	orderService := NewOrderServiceServer(db, nil)
+	shopID, _ := createTestShop(t, db, "+998907770101")
+	otherShopID, _ := createTestShop(t, db, "+998907770102")
+
+	sofaID := createTestProduct(t, db, shopID, 5000000, 4500000,
+		`[{"name": "Rang", "value": "Kulrang", "price_modifier": 250000, "images": ["https://cdn.example.com/sofa-grey.jpg"]}]`,
+		`{"is_home_region_free": false, "home_region_price": 100000, "regional_prices": [{"ids": ["3"], "price": 300000}]}`)
+	tableID := createTestProduct(t, db, shopID, 1200000, nil, `[]`, `{"is_home_region_free": true}`)
+	foreignID := createTestProduct(t, db, otherShopID, 10, nil, `[]`, `{"is_home_region_free": true}`)
+
+	items := []*pb.OrderItemInput{
+		{ProductId: sofaID, Quantity: 1, Price: 1, ProductName: "Arzon divan", VariantName: "Rang", VariantValue: "Kulrang"},
+		{ProductId: tableID, Quantity: 2, Price: 1},
+	}
+
+	// Цены клиента игнорируются: скидка + модификатор варианта, доставка по региону
+	resp, err := orderService.CreateOrder(ctx, &pb.CreateOrderRequest{
+		ShopId:      shopID,
+		ClientName:  "Guest",
+		ClientPhone: "+998901112233",
+		RegionId:    "3",
+		Items:       items,
+	})
+	require.NoError(t, err)
+	order := resp.Order
+	assert.Equal(t, 300000.0, order.DeliveryPrice)
+	assert.Equal(t, 4750000.0+2*1200000.0+300000.0, order.TotalAmount)
+	require.Len(t, order.Items, 2)
+	assert.Equal(t, "Divan", order.Items[0].ProductName)
+	assert.Equal(t, "https://cdn.example.com/sofa-grey.jpg", order.Items[0].ProductImage)
+	assert.Equal(t, 4750000.0, order.Items[0].Price)
+	assert.Equal(t, "Kulrang", order.Items[0].VariantValue)
+
+	// Устаревшая сумма клиента: FailedPrecondition с актуальными ценами
+	_, err = orderService.CreateOrder(ctx, &pb.CreateOrderRequest{
+		ShopId:      shopID,
+		ClientName:  "Guest",
+		ClientPhone: "+998901112233",
+		TotalAmount: 1,
+		Items:       items,
+	})
+	st := status.Convert(err)
+	require.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Len(t, st.Details(), 1)
+	changed, ok := st.Details()[0].(*pb.OrderPriceChanged)
+	require.True(t, ok)
+	assert.Equal(t, 1.0, changed.ExpectedTotal)
+	assert.Equal(t, 100000.0, changed.DeliveryPrice)
+	assert.Equal(t, 4750000.0+2*1200000.0+100000.0, changed.TotalAmount)
+	assert.Len(t, changed.Items, 2)
+
+	// Чужой товар, несуществующий вариант и неактивный товар не принимаются
+	for name, item := range map[string]*pb.OrderItemInput{
+		"чужой магазин": {ProductId: foreignID, Quantity: 1},
+		"нет варианта":  {ProductId: sofaID, Quantity: 1, VariantName: "Rang", VariantValue: "Qizil"},
+	} {
+		_, err := orderService.CreateOrder(ctx, &pb.CreateOrderRequest{ShopId: shopID, Items: []*pb.OrderItemInput{item}})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), name)
+	}
+	_, err = db.Exec(`UPDATE products SET is_active = false WHERE id = $1`, tableID)
+	require.NoError(t, err)
+	_, err = orderService.CreateOrder(ctx, &pb.CreateOrderRequest{ShopId: shopID, Items: []*pb.OrderItemInput{{ProductId: tableID, Quantity: 1}}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = orderService.CreateOrder(ctx, &pb.CreateOrderRequest{ShopId: shopID, Items: []*pb.OrderItemInput{{ProductId: sofaID, Quantity: 0}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOrderService_Checkout(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	orderService := NewOrderServiceServer(db, nil)
+	sofaShopID, _ := createTestShop(t, db, "+998907770101")
+	tableShopID, _ := createTestShop(t, db, "+998907770102")
+
+	sofaID := createTestProduct(t, db, sofaShopID, 5000000, nil, `[]`, `{"is_home_region_free": false, "home_region_price": 100000}`)
+	pillowID := createTestProduct(t, db, sofaShopID, 150000, nil, `[]`, `{"is_home_region_free": false, "home_region_price": 20000}`)
+	tableID := createTestProduct(t, db, tableShopID, 1200000, nil, `[]`, `{"is_home_region_free": false, "home_region_price": 50000}`)
+
+	sofaEvents, cancelSofa := orderService.broadcaster.Subscribe(sofaShopID)
+	defer cancelSofa()
+	tableEvents, cancelTable := orderService.broadcaster.Subscribe(tableShopID)
+	defer cancelTable()
+
+	// Корзина из двух магазинов: по заказу на магазин, доставка считается для каждого
+	resp, err := orderService.Checkout(ctx, &pb.CheckoutRequest{
+		ClientName:  "Guest",
+		ClientPhone: "+998901112233",
+		TotalAmount: 5000000 + 2*150000 + 100000 + 1200000 + 50000,
+		Items: []*pb.OrderItemInput{
+			{ProductId: sofaID, Quantity: 1},
+			{ProductId: tableID, Quantity: 1},
+			{ProductId: pillowID, Quantity: 2},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Orders, 2)
+	assert.NotEmpty(t, resp.CheckoutId)
+	assert.Equal(t, 5000000.0+2*150000.0+1200000.0, resp.Subtotal)
+	assert.Equal(t, 150000.0, resp.DeliveryPrice)
+	assert.Equal(t, 6650000.0, resp.TotalAmount)
+
+	sofaOrder, tableOrder := resp.Orders[0], resp.Orders[1]
+	assert.Equal(t, sofaShopID, sofaOrder.ShopId)
+	assert.Len(t, sofaOrder.Items, 2)
+	assert.Equal(t, 100000.0, sofaOrder.DeliveryPrice)
+	assert.Equal(t, tableShopID, tableOrder.ShopId)
+	assert.Equal(t, 50000.0, tableOrder.DeliveryPrice)
+	assert.Equal(t, resp.CheckoutId, sofaOrder.CheckoutId)
+	assert.Equal(t, resp.CheckoutId, tableOrder.CheckoutId)
+
+	// Каждый магазин получает событие о своем заказе
+	for events, orderID := range map[<-chan *pb.OrderEvent]string{sofaEvents: sofaOrder.Id, tableEvents: tableOrder.Id} {
+		select {
+		case evt := <-events:
+			assert.Equal(t, pb.OrderEventType_ORDER_EVENT_TYPE_CREATED, evt.Type)
+			assert.Equal(t, orderID, evt.Order.Id)
+		default:
+			t.Errorf("no CREATED event for order %s", orderID)
+		}
+	}
+
+	// Встречные checkout'ы одних и тех же товаров не блокируют друг друга
+	for _, productID := range []string{sofaID, tableID} {
+		_, err = db.Exec(`INSERT INTO product_stock (product_id, on_hand) VALUES ($1, 100)`, productID)
+		require.NoError(t, err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		items := []*pb.OrderItemInput{{ProductId: sofaID, Quantity: 1}, {ProductId: tableID, Quantity: 1}}
+		if i%2 == 1 {
+			items[0], items[1] = items[1], items[0]
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := orderService.Checkout(ctx, &pb.CheckoutRequest{ClientName: "Guest", ClientPhone: "+998901112233", Items: items})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Устаревшая общая сумма отклоняется
+	_, err = orderService.Checkout(ctx, &pb.CheckoutRequest{
+		ClientName:  "Guest",
+		ClientPhone: "+998901112233",
+		TotalAmount: 1,
+		Items:       []*pb.OrderItemInput{{ProductId: sofaID, Quantity: 1}, {ProductId: tableID, Quantity: 1}},
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Недоступный товар одного магазина отменяет весь checkout
+	_, err = db.Exec(`UPDATE products SET is_active = false WHERE id = $1`, tableID)
+	require.NoError(t, err)
+	_, err = orderService.Checkout(ctx, &pb.CheckoutRequest{
+		ClientName:  "Guest",
+		ClientPhone: "+998901112233",
+		Items:       []*pb.OrderItemInput{{ProductId: sofaID, Quantity: 1}, {ProductId: tableID, Quantity: 1}},
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	var sofaOrders int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM orders WHERE shop_id = $1`, sofaShopID).Scan(&sofaOrders))
+	assert.Equal(t, 1, sofaOrders)
+
+	_, err = orderService.Checkout(ctx, &pb.CheckoutRequest{ClientName: "Guest", ClientPhone: "+998901112233"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOrderService_UpdateOrderStatus(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+	orderService := NewOrderServiceServer(db, nil)
+
+	shopID, ownerID := createTestShop(t, db, "+998907770101")
+	productID := createTestProduct(t, db, shopID, 1200000, nil, `[]`, `{"is_home_region_free": true}`)
+	sellerToken, err := authService.signAccessToken(ctx, ownerID, "+998907770101", models.RoleSeller, "", false)
+	require.NoError(t, err)
+
+	buyerPhone := "+998901112233"
+	buyer, err := authService.Register(ctx, &pb.RegisterRequest{
+		FullName:          "Buyer",
+		Phone:             buyerPhone,
+		Password:          "password123",
+		VerificationToken: verifyPhone(t, authService, mockSMS, buyerPhone),
+	})
+	require.NoError(t, err)
+
+	var reasonID int
+	require.NoError(t, db.QueryRow(`
+		INSERT INTO cancellation_reasons (reason_text, sort_order) VALUES ($1, 100) RETURNING id
+	`, "Omborda qolmadi "+uuid.NewString()[:8]).Scan(&reasonID))
+
+	createOrder := func() string {
+		resp, err := orderService.CreateOrder(ctx, &pb.CreateOrderRequest{
+			ShopId:      shopID,
+			ClientName:  "Buyer",
+			ClientPhone: buyerPhone,
+			Items:       []*pb.OrderItemInput{{ProductId: productID, Quantity: 1}},
+		})
+		require.NoError(t, err)
+		return resp.Order.Id
+	}
+	update := func(token string, req *pb.UpdateOrderStatusRequest) (*pb.Order, error) {
+		resp, err := callAuthenticated(t, jwtKeys, db, token, pb.OrderService_UpdateOrderStatus_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return orderService.UpdateOrderStatus(ctx, req)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*pb.OrderResponse).Order, nil
+	}
+
+	// Продавец проводит заказ по цепочке, время подтверждения и завершения записывается
+	orderID := createOrder()
+	_, err = update(buyer.AccessToken, &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_CONFIRMED})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "buyer cannot confirm")
+	_, err = update(sellerToken, &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_COMPLETED})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "new cannot jump to completed")
+
+	order, err := update(sellerToken, &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_CONFIRMED})
+	require.NoError(t, err)
+	assert.NotNil(t, order.ConfirmedAt)
+	_, err = update(buyer.AccessToken, &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_CANCELLED})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "buyer cannot cancel a confirmed order")
+
+	_, err = update(sellerToken, &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_SHIPPING})
+	require.NoError(t, err)
+	order, err = update(sellerToken, &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_COMPLETED})
+	require.NoError(t, err)
+	assert.NotNil(t, order.CompletedAt)
+	_, err = update(sellerToken, &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_NEW})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "completed is final")
+
+	// История видна покупателю: создание и три смены статуса продавцом
+	historyReq := &pb.GetOrderHistoryRequest{OrderId: orderID}
+	resp, err := callAuthenticated(t, jwtKeys, db, buyer.AccessToken, pb.OrderService_GetOrderHistory_FullMethodName, historyReq, func(ctx context.Context) (interface{}, error) {
+		return orderService.GetOrderHistory(ctx, historyReq)
+	})
+	require.NoError(t, err)
+	history := resp.(*pb.GetOrderHistoryResponse).History
+	require.Len(t, history, 4)
+	assert.Equal(t, pb.OrderStatus_ORDER_STATUS_UNSPECIFIED, history[0].OldStatus)
+	assert.Equal(t, models.OrderActorBuyer, history[0].ActorRole)
+	assert.Equal(t, pb.OrderStatus_ORDER_STATUS_SHIPPING, history[3].OldStatus)
+	assert.Equal(t, pb.OrderStatus_ORDER_STATUS_COMPLETED, history[3].NewStatus)
+	assert.Equal(t, ownerID, history[3].ActorId)
+	assert.Equal(t, models.OrderActorSeller, history[3].ActorRole)
+	assert.Len(t, order.History, 4, "timeline is embedded in the order")
+
+	// Продавец отменяет только с причиной из справочника
+	orderID = createOrder()
+	cancel := &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_CANCELLED, RejectionNote: "Keyingi oy keladi"}
+	_, err = update(sellerToken, cancel)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	cancel.CancellationReasonId = strconv.Itoa(reasonID)
+	order, err = update(sellerToken, cancel)
+	require.NoError(t, err)
+	assert.Contains(t, order.CancellationReason, "Omborda qolmadi")
+	assert.Equal(t, "Keyingi oy keladi", order.RejectionNote)
+	last := order.History[len(order.History)-1]
+	assert.Equal(t, order.CancellationReason, last.Reason)
+	assert.Equal(t, "Keyingi oy keladi", last.Note)
+
+	// Покупатель может отменить новый заказ
+	orderID = createOrder()
+	order, err = update(buyer.AccessToken, &pb.UpdateOrderStatusRequest{Id: orderID, Status: pb.OrderStatus_ORDER_STATUS_CANCELLED})
+	require.NoError(t, err)
+	assert.Equal(t, pb.OrderStatus_ORDER_STATUS_CANCELLED, order.Status)
+}
+
+func TestOrderService_BuyerOrders(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	mockSMS := &sms.MockSMSService{}
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+	orderService := NewOrderServiceServer(db, nil)
+
+	shopID, ownerID := createTestShop(t, db, "+998907770101")
+	productID := createTestProduct(t, db, shopID, 1200000, nil, `[]`, `{"is_home_region_free": true}`)
+	sellerToken, err := authService.signAccessToken(ctx, ownerID, "+998907770101", models.RoleSeller, "", false)
+	require.NoError(t, err)
+
+	register := func(phone string) *pb.RegisterResponse {
+		resp, err := authService.Register(ctx, &pb.RegisterRequest{
+			FullName:          "Buyer",
+			Phone:             phone,
+			Password:          "password123",
+			VerificationToken: verifyPhone(t, authService, mockSMS, phone),
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	orderReq := func(phone string) *pb.CreateOrderRequest {
+		return &pb.CreateOrderRequest{
+			ShopId:      shopID,
+			ClientName:  "Buyer",
+			ClientPhone: phone,
+			Items:       []*pb.OrderItemInput{{ProductId: productID, Quantity: 1}},
+		}
+	}
+
+	// Гостевой заказ до регистрации привязывается к аккаунту с тем же номером
+	buyerPhone := "+998901112233"
+	guest, err := orderService.CreateOrder(ctx, orderReq(buyerPhone))
+	require.NoError(t, err)
+	assert.Empty(t, guest.Order.UserId)
+
+	buyer := register(buyerPhone)
+	stranger := register("+998901112244")
+
+	// Заказ авторизованного покупателя записывается на него, даже на другой номер
+	req := orderReq("+998905556677")
+	resp, err := callAuthenticated(t, jwtKeys, db, buyer.AccessToken, pb.OrderService_CreateOrder_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+		return orderService.CreateOrder(ctx, req)
+	})
+	require.NoError(t, err)
+	gift := resp.(*pb.OrderResponse).Order
+	assert.Equal(t, buyer.User.Id, gift.UserId)
+
+	_, err = callAuthenticated(t, jwtKeys, db, "invalid-token", pb.OrderService_CreateOrder_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+		return orderService.CreateOrder(ctx, req)
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "invalid token is not treated as guest")
+
+	listMine := func(token string, req *pb.ListMyOrdersRequest) *pb.ListOrdersResponse {
+		resp, err := callAuthenticated(t, jwtKeys, db, token, pb.OrderService_ListMyOrders_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return orderService.ListMyOrders(ctx, req)
+		})
+		require.NoError(t, err)
+		return resp.(*pb.ListOrdersResponse)
+	}
+
+	mine := listMine(buyer.AccessToken, &pb.ListMyOrdersRequest{})
+	require.Equal(t, int32(2), mine.Total)
+	assert.Equal(t, gift.Id, mine.Orders[0].Id, "newest first")
+	assert.Equal(t, guest.Order.Id, mine.Orders[1].Id)
+	assert.Len(t, mine.Orders[1].Items, 1)
+	assert.Zero(t, listMine(stranger.AccessToken, &pb.ListMyOrdersRequest{}).Total)
+
+	// Фильтр по статусу и пагинация
+	cancelReq := &pb.UpdateOrderStatusRequest{Id: gift.Id, Status: pb.OrderStatus_ORDER_STATUS_CANCELLED}
+	_, err = callAuthenticated(t, jwtKeys, db, buyer.AccessToken, pb.OrderService_UpdateOrderStatus_FullMethodName, cancelReq, func(ctx context.Context) (interface{}, error) {
+		return orderService.UpdateOrderStatus(ctx, cancelReq)
+	})
+	require.NoError(t, err)
+	cancelled := listMine(buyer.AccessToken, &pb.ListMyOrdersRequest{Statuses: []pb.OrderStatus{pb.OrderStatus_ORDER_STATUS_CANCELLED}})
+	require.Equal(t, int32(1), cancelled.Total)
+	assert.Equal(t, gift.Id, cancelled.Orders[0].Id)
+	page := listMine(buyer.AccessToken, &pb.ListMyOrdersRequest{Page: 2, Limit: 1})
+	assert.Equal(t, int32(2), page.Total)
+	require.Len(t, page.Orders, 1)
+	assert.Equal(t, guest.Order.Id, page.Orders[0].Id)
+
+	// GetOrder: покупатель и продавец видят заказ, посторонний нет
+	getOrder := func(token, id string) (*pb.Order, error) {
+		req := &pb.GetOrderRequest{Id: id}
+		resp, err := callAuthenticated(t, jwtKeys, db, token, pb.OrderService_GetOrder_FullMethodName, req, func(ctx context.Context) (interface{}, error) {
+			return orderService.GetOrder(ctx, req)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*pb.OrderResponse).Order, nil
+	}
+	order, err := getOrder(buyer.AccessToken, gift.Id)
+	require.NoError(t, err)
+	assert.Equal(t, buyer.User.Id, order.UserId)
+	_, err = getOrder(sellerToken, gift.Id)
+	assert.NoError(t, err)
+	_, err = getOrder(stranger.AccessToken, gift.Id)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Старый аккаунт с неподтвержденным номером не получает чужие гостевые заказы, пока не подтвердит номер кодом
+	legacyPhone := "+998901112255"
+	legacyID := uuid.NewString()
+	_, err = db.Exec(`
+		INSERT INTO users (id, full_name, phone, password_hash, role, is_active)
+		VALUES ($1, 'Legacy', $2, 'x', $3, true)
+	`, legacyID, legacyPhone, models.RoleCustomer)
+	require.NoError(t, err)
+	legacyToken, err := authService.signAccessToken(ctx, legacyID, legacyPhone, models.RoleCustomer, "", false)
+	require.NoError(t, err)
+	legacyOrder, err := orderService.CreateOrder(ctx, orderReq(legacyPhone))
+	require.NoError(t, err)
+	_, err = getOrder(legacyToken, legacyOrder.Order.Id)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "unverified phone")
+	assert.Zero(t, listMine(legacyToken, &pb.ListMyOrdersRequest{}).Total)
+
+	_, err = authService.RequestLoginCode(ctx, &pb.RequestLoginCodeRequest{Phone: legacyPhone})
+	require.NoError(t, err)
+	_, err = authService.LoginWithCode(ctx, &pb.LoginWithCodeRequest{Phone: legacyPhone, Code: mockSMS.LastCode})
+	require.NoError(t, err)
+	mine = listMine(legacyToken, &pb.ListMyOrdersRequest{})
+	require.Equal(t, int32(1), mine.Total)
+	assert.Equal(t, legacyOrder.Order.Id, mine.Orders[0].Id)
+}
+
+func TestOrderTransitions(t *testing.T) {
+	tests := []struct {
+		actor, from, to string
+		want            bool
+	}{
+		{models.OrderActorSeller, models.OrderStatusNew, models.OrderStatusConfirmed, true},
+		{models.OrderActorSeller, models.OrderStatusConfirmed, models.OrderStatusShipping, true},
+		{models.OrderActorSeller, models.OrderStatusShipping, models.OrderStatusCompleted, true},
+		{models.OrderActorSeller, models.OrderStatusShipping, models.OrderStatusCancelled, true},
+		{models.OrderActorSeller, models.OrderStatusNew, models.OrderStatusShipping, false},
+		{models.OrderActorSeller, models.OrderStatusCompleted, models.OrderStatusNew, false},
+		{models.OrderActorAdmin, models.OrderStatusCancelled, models.OrderStatusShipping, false},
+		{models.OrderActorBuyer, models.OrderStatusNew, models.OrderStatusCancelled, true},
+		{models.OrderActorBuyer, models.OrderStatusConfirmed, models.OrderStatusCancelled, false},
+		{models.OrderActorBuyer, models.OrderStatusNew, models.OrderStatusConfirmed, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, models.CanActorTransitionOrder(tt.actor, tt.from, tt.to), "%s: %s -> %s", tt.actor, tt.from, tt.to)
+	}
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// phoneChangeTTL is how long a requested phone change waits for its SMS codes.
+const phoneChangeTTL = 10 * time.Minute
+
+var (
+	// errNoPhoneChange is returned when the user has no pending, unexpired phone change.
+	errNoPhoneChange = errors.New("no pending phone change")
+	// errPhoneTaken is returned when the new number was registered in the meantime.
+	errPhoneTaken = errors.New("phone number already in use")
+)
+
+// phoneChangeKey is the OTP store key of a phone change code, bound to both the user and the
+// number so requests of other users for the same number don't replace or block it.
+func phoneChangeKey(userID, phone string) string {
+	return userID + ":" + phone
+}
+
+// pendingPhoneChange is a row of phone_change_requests.
+type pendingPhoneChange struct {
+	NewPhone        string
+	ConfirmOldPhone bool
+}
+
+// savePhoneChange stores the user's pending phone change, replacing an earlier one.
+func savePhoneChange(ctx context.Context, db *sql.DB, userID string, change pendingPhoneChange) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO phone_change_requests (user_id, new_phone, confirm_old_phone, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET new_phone = EXCLUDED.new_phone, confirm_old_phone = EXCLUDED.confirm_old_phone,
+		    expires_at = EXCLUDED.expires_at, created_at = NOW()
+	`, userID, change.NewPhone, change.ConfirmOldPhone, time.Now().Add(phoneChangeTTL))
+	return err
+}
+
+// loadPhoneChange returns the user's pending phone change if it has not expired.
+func loadPhoneChange(ctx context.Context, db *sql.DB, userID string) (*pendingPhoneChange, error) {
+	var change pendingPhoneChange
+	err := db.QueryRowContext(ctx, `
+		SELECT new_phone, confirm_old_phone FROM phone_change_requests
+		WHERE user_id = $1 AND expires_at > NOW()
+	`, userID).Scan(&change.NewPhone, &change.ConfirmOldPhone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errNoPhoneChange
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// applyPhoneChange swaps the user's phone number, signs out every other session
+// and records the change in the audit log, all in one transaction.
+func applyPhoneChange(ctx context.Context, db *sql.DB, userID, currentSessionID, oldPhone, newPhone string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var taken bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM users WHERE phone = $1 AND id != $2)
+	`, newPhone, userID).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errPhoneTaken
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users SET phone = $1, phone_verified_at = NOW(), updated_at = NOW() WHERE id = $2
+	`, newPhone, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM phone_change_requests WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	// The new phone is verified now: guest orders placed with it become the user's
+	if _, err := linkGuestOrders(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	revoked, err := revokeAllSessions(ctx, tx, userID, currentSessionID)
+	if err != nil {
+		return err
+	}
+
+	err = recordAudit(ctx, tx, auditEntry{
+		UserID: userID,
+		Action: auditPhoneChanged,
+		Details: map[string]any{
+			"old_phone":        oldPhone,
+			"new_phone":        newPhone,
+			"revoked_sessions": revoked,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// markPhoneVerified records that the user proved the phone with an SMS code. The phone is
+// matched too so a number changed in the meantime is not marked.
+func markPhoneVerified(ctx context.Context, db execer, userID, phone string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE users SET phone_verified_at = NOW()
+		WHERE id = $1 AND phone = $2 AND phone_verified_at IS NULL
+	`, userID, phone)
+	return err
+}
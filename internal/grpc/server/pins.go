@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mebellar-backend/pkg/sms"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pinMaxAttempts is the number of wrong PINs after which the PIN is locked
+// and can only be reset through an SMS code.
+const pinMaxAttempts = 5
+
+var (
+	// errPinNotSet is returned when the user has no PIN.
+	errPinNotSet = errors.New("PIN is not set")
+	// errPinLocked is returned once the PIN was locked after too many failures.
+	errPinLocked = errors.New("PIN is locked")
+	// errPinFormat is returned for PINs that are not 4-6 digits.
+	errPinFormat = errors.New("PIN must be 4-6 digits")
+)
+
+// pinMismatchError is returned for a wrong PIN; AttemptsLeft is 0 when the PIN just got locked.
+type pinMismatchError struct {
+	AttemptsLeft int
+}
+
+func (e *pinMismatchError) Error() string {
+	return fmt.Sprintf("invalid PIN, %d attempts left", e.AttemptsLeft)
+}
+
+// validatePin checks the PIN format.
+func validatePin(pin string) error {
+	if len(pin) < 4 || len(pin) > 6 || strings.Trim(pin, "0123456789") != "" {
+		return errPinFormat
+	}
+	return nil
+}
+
+// storePin hashes the PIN and stores it for the user, clearing failed attempts and the lock.
+func storePin(ctx context.Context, db *sql.DB, userID, pin string) error {
+	if err := validatePin(pin); err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_pins (user_id, pin_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET pin_hash = EXCLUDED.pin_hash, failed_attempts = 0, locked_at = NULL, updated_at = NOW()
+	`, userID, string(hash))
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE users SET has_pin = true, updated_at = NOW() WHERE id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// hasStoredPin reports whether the user has a PIN hash stored.
+func hasStoredPin(ctx context.Context, db *sql.DB, userID string) (bool, error) {
+	var exists bool
+	err := db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM user_pins WHERE user_id = $1)`, userID).Scan(&exists)
+	return exists, err
+}
+
+// checkPin verifies the user's PIN. Wrong PINs are counted under a row lock and the
+// PIN is locked after pinMaxAttempts failures; a correct PIN resets the counter.
+// Failures also count towards the account's login lockout, shared with passwords.
+func checkPin(ctx context.Context, db *sql.DB, sender sms.SMSService, userID, pin string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var hash string
+	var failed int
+	var lockedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT pin_hash, failed_attempts, locked_at FROM user_pins
+		WHERE user_id = $1 FOR UPDATE
+	`, userID).Scan(&hash, &failed, &lockedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errPinNotSet
+	}
+	if err != nil {
+		return err
+	}
+	if lockedAt.Valid {
+		return errPinLocked
+	}
+	// A locked PIN needs an SMS reset anyway; otherwise the account-wide login lockout applies
+	if err := checkLoginLockout(ctx, db, userID); err != nil {
+		return err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(pin)) == nil {
+		if failed > 0 {
+			if _, err := tx.ExecContext(ctx, `UPDATE user_pins SET failed_attempts = 0, updated_at = NOW() WHERE user_id = $1`, userID); err != nil {
+				return err
+			}
+		}
+		if _, err := clearLoginFailures(ctx, tx, userID); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	failed++
+	_, err = tx.ExecContext(ctx, `
+		UPDATE user_pins
+		SET failed_attempts = $1, locked_at = CASE WHEN $1 >= $2 THEN NOW() ELSE NULL END, updated_at = NOW()
+		WHERE user_id = $3
+	`, failed, pinMaxAttempts, userID)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if err := recordLoginFailure(ctx, db, sender, userID, loginMethodPin); err != nil {
+		return err
+	}
+	return &pinMismatchError{AttemptsLeft: max(pinMaxAttempts-failed, 0)}
+}
+
+// pinErrorToGRPC maps PIN errors to client-facing gRPC errors.
+func pinErrorToGRPC(err error) error {
+	var mismatch *pinMismatchError
+	var locked *loginLockedError
+	switch {
+	case errors.As(err, &locked):
+		return status.Errorf(codes.ResourceExhausted, "too many failed attempts, try again in %s", locked.RetryAfter)
+	case errors.Is(err, errPinFormat):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errPinNotSet):
+		return status.Error(codes.FailedPrecondition, "PIN not set")
+	case errors.Is(err, errPinLocked):
+		return status.Error(codes.FailedPrecondition, "PIN is locked, reset it with an SMS code")
+	case errors.As(err, &mismatch):
+		if mismatch.AttemptsLeft == 0 {
+			return status.Error(codes.FailedPrecondition, "too many wrong PINs, PIN is locked, reset it with an SMS code")
+		}
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "PIN error: %v", err)
+	}
+}
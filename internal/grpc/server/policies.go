@@ -0,0 +1,311 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	public        = middleware.Public()
+	authenticated = middleware.Authenticated()
+	// Moderators have read-only access to the admin panel.
+	// Admin panel methods require a TOTP-verified session.
+	staffRead  = middleware.RequireRoles(models.RoleAdmin, models.RoleModerator).WithMFA()
+	adminOnly  = middleware.RequireRoles(models.RoleAdmin).WithMFA()
+	sellerOnly = middleware.RequireRoles(models.RoleSeller, models.RoleAdmin)
+	// Account deletion and credential changes: never through an admin impersonation token.
+	ownAccount = authenticated.WithoutImpersonation()
+)
+
+// Policies is the access table enforced by the auth interceptor.
+// Every method registered on the gRPC server must be listed here, otherwise startup fails.
+// Shop API keys may only call methods with a scope (WithScope).
+func Policies() middleware.Policies {
+	return middleware.Policies{
+		// Auth service
+		pb.AuthService_SendOTP_FullMethodName:                public,
+		pb.AuthService_Login_FullMethodName:                  public,
+		pb.AuthService_RequestLoginCode_FullMethodName:       public,
+		pb.AuthService_LoginWithCode_FullMethodName:          public,
+		pb.AuthService_Register_FullMethodName:               public,
+		pb.AuthService_VerifyOTP_FullMethodName:              public,
+		pb.AuthService_RefreshToken_FullMethodName:           public,
+		pb.AuthService_ForgotPassword_FullMethodName:         public,
+		pb.AuthService_ResetPassword_FullMethodName:          public,
+		pb.AuthService_LoginWithPin_FullMethodName:           public,
+		pb.AuthService_VerifyMfaLogin_FullMethodName:         public,
+		pb.AuthService_Logout_FullMethodName:                 ownAccount,
+		pb.AuthService_RequestDeviceTrust_FullMethodName:     ownAccount,
+		pb.AuthService_TrustDevice_FullMethodName:            ownAccount,
+		pb.AuthService_SetupMfa_FullMethodName:               ownAccount,
+		pb.AuthService_ConfirmMfa_FullMethodName:             ownAccount,
+		pb.AuthService_DisableMfa_FullMethodName:             ownAccount,
+		pb.AuthService_AdminImpersonate_FullMethodName:       adminOnly,
+		pb.AuthService_AdminListLoginLockouts_FullMethodName: adminOnly,
+		pb.AuthService_AdminClearLoginLockout_FullMethodName: adminOnly,
+
+		// User service - own profile
+		pb.UserService_GetProfile_FullMethodName:          authenticated,
+		pb.UserService_UpdateProfile_FullMethodName:       authenticated,
+		pb.UserService_DeleteAccount_FullMethodName:       ownAccount,
+		pb.UserService_RequestPhoneChange_FullMethodName:  ownAccount,
+		pb.UserService_VerifyPhoneChange_FullMethodName:   ownAccount,
+		pb.UserService_RequestEmailChange_FullMethodName:  ownAccount,
+		pb.UserService_VerifyEmailChange_FullMethodName:   ownAccount,
+		pb.UserService_SetPin_FullMethodName:              ownAccount,
+		pb.UserService_VerifyPin_FullMethodName:           authenticated,
+		pb.UserService_ChangePin_FullMethodName:           ownAccount,
+		pb.UserService_RequestPinReset_FullMethodName:     ownAccount,
+		pb.UserService_ResetPin_FullMethodName:            ownAccount,
+		pb.UserService_UploadAvatar_FullMethodName:        authenticated,
+		pb.UserService_ListSessions_FullMethodName:        authenticated,
+		pb.UserService_RevokeSession_FullMethodName:       ownAccount,
+		pb.UserService_RevokeOtherSessions_FullMethodName: ownAccount,
+		pb.UserService_UntrustSession_FullMethodName:      ownAccount,
+
+		// User service - admin panel
+		pb.UserService_AdminListUsers_FullMethodName:  staffRead,
+		pb.UserService_AdminGetUser_FullMethodName:    staffRead,
+		pb.UserService_AdminUpdateUser_FullMethodName: adminOnly,
+		pb.UserService_AdminDeleteUser_FullMethodName: adminOnly,
+
+		// Order service - create order is public (guest checkout); a logged-in buyer's token links the order
+		pb.OrderService_CreateOrder_FullMethodName:       public.WithOptionalAuth(),
+		pb.OrderService_Checkout_FullMethodName:          public.WithOptionalAuth(),
+		pb.OrderService_GetOrder_FullMethodName:          authenticated.WithOwner(orderParticipantAccess(shopIDFromID, models.ShopRoleViewer), models.RoleAdmin).WithScope(models.APIScopeOrdersRead),
+		pb.OrderService_UpdateOrderStatus_FullMethodName: authenticated.WithOwner(orderParticipantAccess(shopIDFromID, models.ShopRoleOperator), models.RoleAdmin).WithScope(models.APIScopeOrdersWrite),
+		pb.OrderService_GetOrderHistory_FullMethodName:   authenticated.WithOwner(orderParticipantAccess(orderIDField, models.ShopRoleViewer), models.RoleAdmin).WithScope(models.APIScopeOrdersRead),
+		pb.OrderService_DeleteOrder_FullMethodName:       authenticated.WithOwner(orderShopAccess(models.ShopRoleManager), models.RoleAdmin),
+		pb.OrderService_ListOrders_FullMethodName:        authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleViewer), models.RoleAdmin, models.RoleModerator).WithScope(models.APIScopeOrdersRead),
+		pb.OrderService_StreamOrders_FullMethodName:      authenticated.WithScope(models.APIScopeOrdersRead), // shop checked in handler
+		pb.OrderService_ListMyOrders_FullMethodName:      authenticated,
+
+		// Cart service - guests have a cart per device (x-device-id), a token switches to the user's cart
+		pb.CartService_AddItem_FullMethodName:        public.WithOptionalAuth(),
+		pb.CartService_UpdateQuantity_FullMethodName: public.WithOptionalAuth(),
+		pb.CartService_RemoveItem_FullMethodName:     public.WithOptionalAuth(),
+		pb.CartService_GetCart_FullMethodName:        public.WithOptionalAuth(),
+		pb.CartService_Clear_FullMethodName:          public.WithOptionalAuth(),
+
+		// Product service
+		pb.ProductService_GetProduct_FullMethodName:                       public,
+		pb.ProductService_ListProducts_FullMethodName:                     public,
+		pb.ProductService_ListNewArrivals_FullMethodName:                  public,
+		pb.ProductService_ListPopularProducts_FullMethodName:              public,
+		pb.ProductService_ListProductsGroupedBySubcategory_FullMethodName: public,
+		pb.ProductService_ListSellerProducts_FullMethodName:               authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleViewer)).WithScope(models.APIScopeProductsRead),
+		pb.ProductService_CreateProduct_FullMethodName:                    authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleManager), models.RoleAdmin).WithScope(models.APIScopeProductsWrite),
+		pb.ProductService_UpdateProduct_FullMethodName:                    authenticated.WithOwner(productShopAccess(models.ShopRoleManager), models.RoleAdmin).WithScope(models.APIScopeProductsWrite),
+		pb.ProductService_DeleteProduct_FullMethodName:                    authenticated.WithOwner(productShopAccess(models.ShopRoleManager), models.RoleAdmin).WithScope(models.APIScopeProductsWrite),
+		pb.ProductService_ToggleProductStatus_FullMethodName:              authenticated.WithOwner(productShopAccess(models.ShopRoleManager), models.RoleAdmin).WithScope(models.APIScopeProductsWrite),
+		pb.ProductService_AdjustStock_FullMethodName:                      authenticated.WithOwner(productStockAccess(models.ShopRoleManager), models.RoleAdmin).WithScope(models.APIScopeProductsWrite),
+		pb.ProductService_GetProductStock_FullMethodName:                  authenticated.WithOwner(productStockAccess(models.ShopRoleViewer), models.RoleAdmin, models.RoleModerator).WithScope(models.APIScopeProductsRead),
+		pb.ProductService_ListStockMovements_FullMethodName:               authenticated.WithOwner(productStockAccess(models.ShopRoleViewer), models.RoleAdmin, models.RoleModerator).WithScope(models.APIScopeProductsRead),
+		pb.ProductService_UploadProductImage_FullMethodName:               authenticated, // shop checked in handler
+		pb.ProductService_UploadProductImages_FullMethodName:              authenticated, // shop checked in handler
+
+		// Category service
+		pb.CategoryService_ListCategories_FullMethodName:          public,
+		pb.CategoryService_ListFlatCategories_FullMethodName:      public,
+		pb.CategoryService_GetCategory_FullMethodName:             public,
+		pb.CategoryService_ListCategoryAttributes_FullMethodName:  public,
+		pb.CategoryService_GetCategoryAttribute_FullMethodName:    public,
+		pb.CategoryService_CreateCategory_FullMethodName:          adminOnly,
+		pb.CategoryService_UpdateCategory_FullMethodName:          adminOnly,
+		pb.CategoryService_DeleteCategory_FullMethodName:          adminOnly,
+		pb.CategoryService_CreateCategoryAttribute_FullMethodName: adminOnly,
+		pb.CategoryService_UpdateCategoryAttribute_FullMethodName: adminOnly,
+		pb.CategoryService_DeleteCategoryAttribute_FullMethodName: adminOnly,
+
+		// Shop service
+		pb.ShopService_GetShopBySlug_FullMethodName:          public,
+		pb.ShopService_GetPublicSellerProfile_FullMethodName: public,
+		pb.ShopService_GetMyShops_FullMethodName:             authenticated,
+		pb.ShopService_GetShop_FullMethodName:                authenticated.WithOwner(shopAccess(shopIDFromID, models.ShopRoleViewer), models.RoleAdmin, models.RoleModerator),
+		pb.ShopService_CreateShop_FullMethodName:             sellerOnly,
+		pb.ShopService_UpdateShop_FullMethodName:             authenticated.WithOwner(shopAccess(shopIDFromID, models.ShopRoleOwner), models.RoleAdmin),
+		pb.ShopService_DeleteShop_FullMethodName:             authenticated.WithOwner(shopAccess(shopIDFromID, models.ShopRoleOwner), models.RoleAdmin).WithoutImpersonation(),
+		pb.ShopService_GetSellerProfile_FullMethodName:       authenticated,
+		pb.ShopService_UpgradeToSeller_FullMethodName:        authenticated,
+		pb.ShopService_UpdateSellerProfile_FullMethodName:    authenticated,
+		pb.ShopService_UpdateLegalInfo_FullMethodName:        authenticated,
+		pb.ShopService_DeleteSellerAccount_FullMethodName:    ownAccount,
+		pb.ShopService_UploadShopImage_FullMethodName:        authenticated, // shop checked in handler
+		pb.ShopService_InviteShopMember_FullMethodName:       authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleManager), models.RoleAdmin),
+		pb.ShopService_ListShopMembers_FullMethodName:        authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleManager), models.RoleAdmin, models.RoleModerator),
+		pb.ShopService_UpdateShopMemberRole_FullMethodName:   authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleManager), models.RoleAdmin),
+		pb.ShopService_RemoveShopMember_FullMethodName:       authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleManager), models.RoleAdmin),
+		pb.ShopService_AcceptShopInvite_FullMethodName:       authenticated,
+		pb.ShopService_CreateApiKey_FullMethodName:           authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleManager)).WithoutImpersonation(),
+		pb.ShopService_ListApiKeys_FullMethodName:            authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleManager), models.RoleAdmin, models.RoleModerator),
+		pb.ShopService_RevokeApiKey_FullMethodName:           authenticated.WithOwner(shopAccess(shopIDField, models.ShopRoleManager), models.RoleAdmin),
+		pb.ShopService_AdminListShops_FullMethodName:         staffRead,
+		pb.ShopService_AdminGetShop_FullMethodName:           staffRead,
+		pb.ShopService_AdminUpdateShop_FullMethodName:        adminOnly,
+		pb.ShopService_AdminDeleteShop_FullMethodName:        adminOnly,
+
+		// Common service
+		pb.CommonService_ListRegions_FullMethodName:             public,
+		pb.CommonService_GetRegion_FullMethodName:               public,
+		pb.CommonService_ListBanners_FullMethodName:             public,
+		pb.CommonService_GetBanner_FullMethodName:               public,
+		pb.CommonService_ListCancellationReasons_FullMethodName: public,
+		pb.CommonService_CreateRegion_FullMethodName:            adminOnly,
+		pb.CommonService_UpdateRegion_FullMethodName:            adminOnly,
+		pb.CommonService_DeleteRegion_FullMethodName:            adminOnly,
+		pb.CommonService_CreateBanner_FullMethodName:            adminOnly,
+		pb.CommonService_UpdateBanner_FullMethodName:            adminOnly,
+		pb.CommonService_DeleteBanner_FullMethodName:            adminOnly,
+
+		// gRPC reflection (grpcurl, grpcui)
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      authenticated,
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": authenticated,
+	}
+}
+
+// shopIDField reads shop_id from requests such as CreateProductRequest.
+func shopIDField(req interface{}) string {
+	if r, ok := req.(interface{ GetShopId() string }); ok {
+		return r.GetShopId()
+	}
+	return ""
+}
+
+// shopIDFromID reads the id field of requests such as GetShopRequest or DeleteProductRequest.
+func shopIDFromID(req interface{}) string {
+	if r, ok := req.(interface{ GetId() string }); ok {
+		return r.GetId()
+	}
+	return ""
+}
+
+// shopAccess checks that the caller is the owner or a member with at least minRole
+// of the shop addressed by the request, falling back to the X-Shop-ID header when
+// the request has no shop. The header shop and the caller's role in it were already
+// resolved by the interceptor and need no extra query.
+func shopAccess(shopIDOf func(req interface{}) string, minRole string) middleware.OwnerCheck {
+	return func(ctx context.Context, db *sql.DB, auth *middleware.AuthContext, req interface{}) error {
+		shopID := shopIDOf(req)
+		if shopID == "" {
+			shopID = auth.ShopID
+		}
+		if shopID == "" {
+			return status.Error(codes.InvalidArgument, "shop_id is required")
+		}
+		if auth.APIKeyID != "" && shopID != auth.ShopID {
+			return status.Error(codes.PermissionDenied, "API key is scoped to another shop")
+		}
+		if shopID == auth.ShopID && auth.ShopRole != "" {
+			return requireShopRole(auth.ShopRole, minRole)
+		}
+		return VerifyShopAccessHelper(ctx, db, shopID, auth.UserID, minRole)
+	}
+}
+
+// productShopAccess checks the caller's role in the shop of the product addressed by id.
+func productShopAccess(minRole string) middleware.OwnerCheck {
+	return func(ctx context.Context, db *sql.DB, auth *middleware.AuthContext, req interface{}) error {
+		return rowShopAccess(ctx, db, auth, "products", "product", shopIDFromID(req), minRole)
+	}
+}
+
+// productStockAccess checks the caller's role in the shop of the product addressed by product_id.
+func productStockAccess(minRole string) middleware.OwnerCheck {
+	return func(ctx context.Context, db *sql.DB, auth *middleware.AuthContext, req interface{}) error {
+		var productID string
+		if r, ok := req.(interface{ GetProductId() string }); ok {
+			productID = r.GetProductId()
+		}
+		return rowShopAccess(ctx, db, auth, "products", "product", productID, minRole)
+	}
+}
+
+// orderShopAccess checks the caller's role in the shop the order addressed by id was placed in.
+func orderShopAccess(minRole string) middleware.OwnerCheck {
+	return func(ctx context.Context, db *sql.DB, auth *middleware.AuthContext, req interface{}) error {
+		return rowShopAccess(ctx, db, auth, "orders", "order", shopIDFromID(req), minRole)
+	}
+}
+
+// orderParticipantAccess lets the buyer of the order addressed by the request through,
+// as well as its shop staff with at least minRole. Handlers narrow what a buyer may do.
+func orderParticipantAccess(orderIDOf func(req interface{}) string, minRole string) middleware.OwnerCheck {
+	return func(ctx context.Context, db *sql.DB, auth *middleware.AuthContext, req interface{}) error {
+		_, err := orderActor(ctx, db, auth, orderIDOf(req), minRole)
+		return err
+	}
+}
+
+// orderIDField reads the order_id field of requests such as GetOrderHistoryRequest.
+func orderIDField(req interface{}) string {
+	if r, ok := req.(interface{ GetOrderId() string }); ok {
+		return r.GetOrderId()
+	}
+	return ""
+}
+
+// orderActor tells whether the caller acts on the order as an admin, as shop staff
+// with at least minRole, or as the buyer who placed it.
+func orderActor(ctx context.Context, db *sql.DB, auth *middleware.AuthContext, orderID, minRole string) (string, error) {
+	if auth.Role == models.RoleAdmin && auth.APIKeyID == "" {
+		return models.OrderActorAdmin, nil
+	}
+	err := rowShopAccess(ctx, db, auth, "orders", "order", orderID, minRole)
+	if err == nil {
+		return models.OrderActorSeller, nil
+	}
+	if status.Code(err) != codes.PermissionDenied || auth.APIKeyID != "" {
+		return "", err
+	}
+	buyer, buyerErr := isOrderBuyer(ctx, db, orderID, auth.UserID)
+	if buyerErr != nil {
+		return "", status.Errorf(codes.Internal, "query error: %v", buyerErr)
+	}
+	if !buyer {
+		return "", err
+	}
+	return models.OrderActorBuyer, nil
+}
+
+// isOrderBuyer reports whether the user placed the order: it is linked to the account,
+// or it is a guest order not yet linked and made for the user's verified phone.
+func isOrderBuyer(ctx context.Context, db *sql.DB, orderID, userID string) (bool, error) {
+	var buyer bool
+	err := db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM orders o JOIN users u ON u.id = $2
+			WHERE o.id = $1 AND (o.user_id = u.id OR
+				(o.user_id IS NULL AND u.phone_verified_at IS NOT NULL AND o.client_phone = u.phone))
+		)
+	`, orderID, userID).Scan(&buyer)
+	return buyer, err
+}
+
+func rowShopAccess(ctx context.Context, db *sql.DB, auth *middleware.AuthContext, tableName, entityName, id, minRole string) error {
+	if id == "" {
+		return status.Errorf(codes.InvalidArgument, "%s id is required", entityName)
+	}
+
+	var shopID string
+	err := db.QueryRowContext(ctx, "SELECT shop_id FROM "+tableName+" WHERE id = $1", id).Scan(&shopID)
+	if err == sql.ErrNoRows {
+		return status.Errorf(codes.NotFound, "%s not found", entityName)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if auth.APIKeyID != "" && shopID != auth.ShopID {
+		return status.Errorf(codes.NotFound, "%s not found", entityName)
+	}
+	if shopID == auth.ShopID && auth.ShopRole != "" {
+		return requireShopRole(auth.ShopRole, minRole)
+	}
+	return VerifyShopAccessHelper(ctx, db, shopID, auth.UserID, minRole)
+}
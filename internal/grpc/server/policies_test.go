@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/cache"
+	"mebellar-backend/pkg/pb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicies_CoverAllMethods(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	pb.RegisterAuthServiceServer(grpcServer, NewAuthServiceServer(nil, nil, nil, nil))
+	pb.RegisterUserServiceServer(grpcServer, NewUserServiceServer(nil, nil, nil, nil))
+	pb.RegisterOrderServiceServer(grpcServer, NewOrderServiceServer(nil, nil))
+	pb.RegisterCartServiceServer(grpcServer, NewCartServiceServer(nil))
+	pb.RegisterProductServiceServer(grpcServer, NewProductServiceServer(nil))
+	pb.RegisterCategoryServiceServer(grpcServer, NewCategoryServiceServer(nil, cache.NewMemoryCache()))
+	pb.RegisterShopServiceServer(grpcServer, NewShopServiceServer(nil, nil))
+	pb.RegisterCommonServiceServer(grpcServer, NewCommonServiceServer(nil))
+	reflection.Register(grpcServer)
+
+	require.NoError(t, Policies().Validate(grpcServer.GetServiceInfo()))
+
+	// Метод без политики - ошибка запуска
+	policies := Policies()
+	delete(policies, pb.AuthService_Login_FullMethodName)
+	err := policies.Validate(grpcServer.GetServiceInfo())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), pb.AuthService_Login_FullMethodName)
+}
+
+func TestPolicies_Roles(t *testing.T) {
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(nil, jwtKeys, nil, nil)
+	unary, _ := middleware.NewAuthInterceptors(jwtKeys, nil, Policies(), nil)
+
+	call := func(role string, mfa bool, method string) error {
+		ctx := context.Background()
+		if role != "" {
+			token, err := authService.signAccessToken(context.Background(), "user-1", "+998901234567", role, "", mfa)
+			require.NoError(t, err)
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+
+	tests := []struct {
+		name   string
+		role   string
+		mfa    bool
+		method string
+		code   codes.Code
+	}{
+		{"public without token", "", false, pb.CategoryService_ListCategories_FullMethodName, codes.OK},
+		{"authenticated without token", "", false, pb.UserService_GetProfile_FullMethodName, codes.Unauthenticated},
+		{"customer profile", models.RoleCustomer, false, pb.UserService_GetProfile_FullMethodName, codes.OK},
+		{"customer admin panel", models.RoleCustomer, true, pb.UserService_AdminListUsers_FullMethodName, codes.PermissionDenied},
+		{"moderator reads", models.RoleModerator, true, pb.UserService_AdminListUsers_FullMethodName, codes.OK},
+		{"moderator reads without 2fa", models.RoleModerator, false, pb.UserService_AdminListUsers_FullMethodName, codes.PermissionDenied},
+		{"moderator cannot delete", models.RoleModerator, true, pb.CommonService_DeleteBanner_FullMethodName, codes.PermissionDenied},
+		{"moderator cannot update user", models.RoleModerator, true, pb.UserService_AdminUpdateUser_FullMethodName, codes.PermissionDenied},
+		{"admin deletes", models.RoleAdmin, true, pb.CategoryService_DeleteCategory_FullMethodName, codes.OK},
+		{"admin deletes without 2fa", models.RoleAdmin, false, pb.CategoryService_DeleteCategory_FullMethodName, codes.PermissionDenied},
+		{"admin profile without 2fa", models.RoleAdmin, false, pb.UserService_GetProfile_FullMethodName, codes.OK},
+		{"admin sets up 2fa", models.RoleAdmin, false, pb.AuthService_SetupMfa_FullMethodName, codes.OK},
+		{"customer creates shop", models.RoleCustomer, false, pb.ShopService_CreateShop_FullMethodName, codes.PermissionDenied},
+		{"unknown method", models.RoleAdmin, true, "/unknown.Service/Method", codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(call(tt.role, tt.mfa, tt.method)))
+		})
+	}
+}
+
+func TestPolicies_OwnerExemptRequiresMFA(t *testing.T) {
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(nil, jwtKeys, nil, nil)
+	unary, _ := middleware.NewAuthInterceptors(jwtKeys, nil, Policies(), nil)
+
+	call := func(role string, mfa bool, method string, req interface{}) error {
+		token, err := authService.signAccessToken(context.Background(), "user-1", "+998901234567", role, "", mfa)
+		require.NoError(t, err)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+		_, err = unary(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+
+	// Чужие ресурсы администратор и модератор видят только после второго фактора
+	updateShop := &pb.UpdateShopRequest{Id: "7f1c2a4e-3b9d-4c1e-9a57-0d2b6e8f4a11"}
+	assert.NoError(t, call(models.RoleAdmin, true, pb.ShopService_UpdateShop_FullMethodName, updateShop))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(models.RoleAdmin, false, pb.ShopService_UpdateShop_FullMethodName, updateShop)))
+
+	listOrders := &pb.ListOrdersRequest{ShopId: updateShop.Id}
+	assert.NoError(t, call(models.RoleModerator, true, pb.OrderService_ListOrders_FullMethodName, listOrders))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(models.RoleModerator, false, pb.OrderService_ListOrders_FullMethodName, listOrders)))
+}
+
+func TestPolicies_OrderReadsMatch(t *testing.T) {
+	// Заказ содержит свою историю, поэтому доступ к ним одинаковый: покупатель, сотрудники магазина, администратор
+	policies := Policies()
+	order := policies[pb.OrderService_GetOrder_FullMethodName]
+	history := policies[pb.OrderService_GetOrderHistory_FullMethodName]
+	assert.Equal(t, []string{models.RoleAdmin}, order.OwnerExempt)
+	assert.Equal(t, order.OwnerExempt, history.OwnerExempt)
+	assert.Equal(t, order.Scope, history.Scope)
+}
+
+func TestPolicies_Impersonation(t *testing.T) {
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(nil, jwtKeys, nil, nil)
+	unary, _ := middleware.NewAuthInterceptors(jwtKeys, nil, Policies(), nil)
+
+	user := &models.User{ID: "user-1", Phone: "+998901234567", Role: models.RoleCustomer}
+	token, expiresAt, err := authService.signImpersonationToken(context.Background(), user, "admin-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(impersonationTTL), expiresAt, time.Minute)
+
+	call := func(method string) (*middleware.AuthContext, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+		var auth *middleware.AuthContext
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			auth = middleware.GetAuthContext(ctx)
+			return nil, nil
+		})
+		return auth, err
+	}
+
+	// Администратор видит действия от имени пользователя, актор доступен в контексте
+	auth, err := call(pb.UserService_UpdateProfile_FullMethodName)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", auth.UserID)
+	assert.Equal(t, "admin-1", auth.ActorID)
+
+	// Удаление аккаунта и смена учетных данных запрещены
+	for _, method := range []string{
+		pb.UserService_DeleteAccount_FullMethodName,
+		pb.ShopService_DeleteSellerAccount_FullMethodName,
+		pb.UserService_RequestPhoneChange_FullMethodName,
+		pb.AuthService_SetupMfa_FullMethodName,
+		pb.AuthService_Logout_FullMethodName,
+	} {
+		_, err := call(method)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), method)
+	}
+
+	assert.True(t, readOnlyMethod(pb.UserService_GetProfile_FullMethodName))
+	assert.True(t, readOnlyMethod(pb.OrderService_ListOrders_FullMethodName))
+	assert.False(t, readOnlyMethod(pb.UserService_UpdateProfile_FullMethodName))
+}
+
+func TestPolicies_ShopHeader(t *testing.T) {
+	jwtKeys := testKeyRing(t)
+	authService := NewAuthServiceServer(nil, jwtKeys, nil, nil)
+
+	// Владелец магазина берется из кэша, БД не нужна
+	shopID := "7f1c2a4e-3b9d-4c1e-9a57-0d2b6e8f4a11"
+	shopCache := cache.NewMemoryCache()
+	require.NoError(t, shopCache.Set("shop_owner:"+shopID, "owner-1", time.Minute))
+	unary, _ := middleware.NewAuthInterceptors(jwtKeys, nil, Policies(), shopCache)
+
+	call := func(userID, role, shopHeader string) (*middleware.AuthContext, error) {
+		token, err := authService.signAccessToken(context.Background(), userID, "+998901234567", role, "", false)
+		require.NoError(t, err)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"authorization", "Bearer "+token,
+			"x-shop-id", shopHeader,
+		))
+		var auth *middleware.AuthContext
+		_, err = unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: pb.UserService_GetProfile_FullMethodName}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			auth = middleware.GetAuthContext(ctx)
+			return nil, nil
+		})
+		return auth, err
+	}
+
+	auth, err := call("owner-1", models.RoleSeller, shopID)
+	require.NoError(t, err)
+	assert.Equal(t, shopID, auth.ShopID)
+	assert.Equal(t, models.ShopRoleOwner, auth.ShopRole)
+
+	auth, err = call("admin-1", models.RoleAdmin, shopID)
+	require.NoError(t, err)
+	assert.Equal(t, shopID, auth.ShopID)
+	assert.Empty(t, auth.ShopRole)
+
+	_, err = call("owner-1", models.RoleSeller, "not-a-uuid")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
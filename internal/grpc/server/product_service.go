@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
 	"mebellar-backend/pkg/pb"
 
 	"github.com/google/uuid"
@@ -158,11 +159,6 @@ func (s *ProductServiceServer) ListSellerProducts(ctx context.Context, req *pb.L
 		return nil, status.Error(codes.InvalidArgument, "shop_id is required")
 	}
 
-	// Verify shop ownership
-	if err := s.verifyShopOwnership(ctx, shopID, auth.UserID); err != nil {
-		return nil, err
-	}
-
 	filters := req.GetFilters()
 	if filters == nil {
 		filters = &pb.ProductFilters{}
@@ -177,22 +173,12 @@ func (s *ProductServiceServer) CreateProduct(ctx context.Context, req *pb.Create
 	if auth == nil {
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
-	if auth.Role != "seller" && auth.Role != "admin" {
-		return nil, status.Error(codes.PermissionDenied, "seller or admin role required")
-	}
 
 	shopID := req.GetShopId()
 	if shopID == "" {
 		return nil, status.Error(codes.InvalidArgument, "shop_id is required")
 	}
 
-	// Verify shop ownership (unless admin)
-	if auth.Role != "admin" {
-		if err := s.verifyShopOwnership(ctx, shopID, auth.UserID); err != nil {
-			return nil, err
-		}
-	}
-
 	productID := uuid.NewString()
 	nameJSON, _ := json.Marshal(localizedStringToMap(req.GetName()))
 	descJSON, _ := json.Marshal(localizedStringToMap(req.GetDescription()))
@@ -234,23 +220,6 @@ func (s *ProductServiceServer) UpdateProduct(ctx context.Context, req *pb.Update
 		return nil, status.Error(codes.InvalidArgument, "product id is required")
 	}
 
-	// Get existing product to verify ownership
-	var shopID string
-	err := s.db.QueryRowContext(ctx, "SELECT shop_id FROM products WHERE id = $1", productID).Scan(&shopID)
-	if err == sql.ErrNoRows {
-		return nil, status.Error(codes.NotFound, "product not found")
-	}
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "query error: %v", err)
-	}
-
-	// Verify ownership (unless admin)
-	if auth.Role != "admin" {
-		if err := s.verifyShopOwnership(ctx, shopID, auth.UserID); err != nil {
-			return nil, err
-		}
-	}
-
 	// Build dynamic update query
 	updates := []string{}
 	args := []interface{}{}
@@ -329,7 +298,7 @@ func (s *ProductServiceServer) UpdateProduct(ctx context.Context, req *pb.Update
 	args = append(args, productID)
 	query := fmt.Sprintf("UPDATE products SET %s WHERE id = $%d", strings.Join(updates, ", "), argIdx)
 
-	_, err = s.db.ExecContext(ctx, query, args...)
+	_, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update product: %v", err)
 	}
@@ -348,23 +317,7 @@ func (s *ProductServiceServer) DeleteProduct(ctx context.Context, req *pb.Delete
 		return nil, status.Error(codes.InvalidArgument, "product id is required")
 	}
 
-	// Get shop_id and verify ownership
-	var shopID string
-	err := s.db.QueryRowContext(ctx, "SELECT shop_id FROM products WHERE id = $1", productID).Scan(&shopID)
-	if err == sql.ErrNoRows {
-		return nil, status.Error(codes.NotFound, "product not found")
-	}
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "query error: %v", err)
-	}
-
-	if auth.Role != "admin" {
-		if err := s.verifyShopOwnership(ctx, shopID, auth.UserID); err != nil {
-			return nil, err
-		}
-	}
-
-	_, err = s.db.ExecContext(ctx, "DELETE FROM products WHERE id = $1", productID)
+	_, err := s.db.ExecContext(ctx, "DELETE FROM products WHERE id = $1", productID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete product: %v", err)
 	}
@@ -383,23 +336,7 @@ func (s *ProductServiceServer) ToggleProductStatus(ctx context.Context, req *pb.
 		return nil, status.Error(codes.InvalidArgument, "product id is required")
 	}
 
-	// Get shop_id and verify ownership
-	var shopID string
-	err := s.db.QueryRowContext(ctx, "SELECT shop_id FROM products WHERE id = $1", productID).Scan(&shopID)
-	if err == sql.ErrNoRows {
-		return nil, status.Error(codes.NotFound, "product not found")
-	}
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "query error: %v", err)
-	}
-
-	if auth.Role != "admin" {
-		if err := s.verifyShopOwnership(ctx, shopID, auth.UserID); err != nil {
-			return nil, err
-		}
-	}
-
-	_, err = s.db.ExecContext(ctx, "UPDATE products SET is_active = $1 WHERE id = $2", req.GetIsActive(), productID)
+	_, err := s.db.ExecContext(ctx, "UPDATE products SET is_active = $1 WHERE id = $2", req.GetIsActive(), productID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to toggle status: %v", err)
 	}
@@ -433,9 +370,9 @@ func (s *ProductServiceServer) UploadProductImage(stream pb.ProductService_Uploa
 		switch data := req.Data.(type) {
 		case *pb.UploadImageRequest_Metadata:
 			metadata = data.Metadata
-			// Verify shop ownership if shop_id provided
+			// Verify shop access if shop_id provided
 			if metadata.GetShopId() != "" && auth.Role != "admin" {
-				if err := s.verifyShopOwnership(ctx, metadata.GetShopId(), auth.UserID); err != nil {
+				if err := s.verifyShopAccess(ctx, metadata.GetShopId(), auth.UserID); err != nil {
 					return err
 				}
 			}
@@ -519,9 +456,9 @@ func (s *ProductServiceServer) UploadProductImages(stream pb.ProductService_Uplo
 			currentMetadata = data.Metadata
 			currentFileData = nil
 
-			// Verify shop ownership
+			// Verify shop access
 			if currentMetadata.GetShopId() != "" && auth.Role != "admin" {
-				if err := s.verifyShopOwnership(ctx, currentMetadata.GetShopId(), auth.UserID); err != nil {
+				if err := s.verifyShopAccess(ctx, currentMetadata.GetShopId(), auth.UserID); err != nil {
 					return err
 				}
 			}
@@ -565,18 +502,19 @@ func (s *ProductServiceServer) getProductByID(ctx context.Context, id string) (*
 		ShopLogo         sql.NullString
 	}
 
+	var inStock bool
 	err := s.db.QueryRowContext(ctx, `
 		SELECT p.id, p.shop_id, p.category_id, p.name, p.description, p.price, p.discount_price,
 			   p.images, p.specs, p.variants, p.delivery_settings, p.rating, p.view_count, p.sold_count,
 			   p.is_new, p.is_popular, p.is_active, p.created_at,
-			   COALESCE(s.name, '{}') as shop_name, s.logo_url
+			   COALESCE(s.name, '{}') as shop_name, s.logo_url, `+productInStockSQL+` as in_stock
 		FROM products p
 		LEFT JOIN shops s ON p.shop_id = s.id
 		WHERE p.id = $1
 	`, id).Scan(
 		&p.ID, &p.ShopID, &p.CategoryID, &p.Name, &p.Description, &p.Price, &p.DiscountPrice,
 		&p.Images, &p.Specs, &p.Variants, &p.DeliverySettings, &p.Rating, &p.ViewCount, &p.SoldCount,
-		&p.IsNew, &p.IsPopular, &p.IsActive, &p.CreatedAt, &p.ShopName, &p.ShopLogo,
+		&p.IsNew, &p.IsPopular, &p.IsActive, &p.CreatedAt, &p.ShopName, &p.ShopLogo, &inStock,
 	)
 	if err == sql.ErrNoRows {
 		return nil, status.Error(codes.NotFound, "product not found")
@@ -585,7 +523,9 @@ func (s *ProductServiceServer) getProductByID(ctx context.Context, id string) (*
 		return nil, status.Errorf(codes.Internal, "query error: %v", err)
 	}
 
-	return s.scanToProduct(&p), nil
+	product := s.scanToProduct(&p)
+	product.InStock = inStock
+	return product, nil
 }
 
 func (s *ProductServiceServer) listProductsInternal(ctx context.Context, filters *pb.ProductFilters, page, limit int, orderBy string) (*pb.ListProductsResponse, error) {
@@ -640,6 +580,9 @@ func (s *ProductServiceServer) listProductsInternal(ctx context.Context, filters
 			args = append(args, "%"+filters.GetSearch()+"%")
 			argIdx++
 		}
+		if filters.GetInStockOnly() {
+			where = append(where, productInStockSQL)
+		}
 	}
 
 	whereClause := strings.Join(where, " AND ")
@@ -671,13 +614,13 @@ func (s *ProductServiceServer) listProductsInternal(ctx context.Context, filters
 		SELECT p.id, p.shop_id, p.category_id, p.name, p.description, p.price, p.discount_price,
 			   p.images, p.specs, p.variants, p.delivery_settings, p.rating, p.view_count, p.sold_count,
 			   p.is_new, p.is_popular, p.is_active, p.created_at,
-			   COALESCE(s.name, '{}') as shop_name, s.logo_url
+			   COALESCE(s.name, '{}') as shop_name, s.logo_url, %s as in_stock
 		FROM products p
 		LEFT JOIN shops s ON p.shop_id = s.id
 		WHERE %s
 		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, whereClause, orderBy, argIdx, argIdx+1)
+	`, productInStockSQL, whereClause, orderBy, argIdx, argIdx+1)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -709,15 +652,18 @@ func (s *ProductServiceServer) listProductsInternal(ctx context.Context, filters
 			ShopName         []byte
 			ShopLogo         sql.NullString
 		}
+		var inStock bool
 		err := rows.Scan(
 			&p.ID, &p.ShopID, &p.CategoryID, &p.Name, &p.Description, &p.Price, &p.DiscountPrice,
 			&p.Images, &p.Specs, &p.Variants, &p.DeliverySettings, &p.Rating, &p.ViewCount, &p.SoldCount,
-			&p.IsNew, &p.IsPopular, &p.IsActive, &p.CreatedAt, &p.ShopName, &p.ShopLogo,
+			&p.IsNew, &p.IsPopular, &p.IsActive, &p.CreatedAt, &p.ShopName, &p.ShopLogo, &inStock,
 		)
 		if err != nil {
 			continue
 		}
-		products = append(products, s.scanToProduct(&p))
+		product := s.scanToProduct(&p)
+		product.InStock = inStock
+		products = append(products, product)
 	}
 
 	return &pb.ListProductsResponse{
@@ -815,8 +761,9 @@ func (s *ProductServiceServer) scanToProduct(p *struct {
 	return product
 }
 
-func (s *ProductServiceServer) verifyShopOwnership(ctx context.Context, shopID, userID string) error {
-	return VerifyShopOwnershipHelper(ctx, s.db, shopID, userID)
+// verifyShopAccess allows the shop owner and staff who manage products.
+func (s *ProductServiceServer) verifyShopAccess(ctx context.Context, shopID, userID string) error {
+	return VerifyShopAccessHelper(ctx, s.db, shopID, userID, models.ShopRoleManager)
 }
 
 func (s *ProductServiceServer) saveImageFile(metadata *pb.ImageMetadata, data []byte) (string, error) {
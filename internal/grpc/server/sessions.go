@@ -0,0 +1,293 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"mebellar-backend/models"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	accessTokenTTL  = 24 * time.Hour
+	refreshTokenTTL = 7 * 24 * time.Hour
+)
+
+var (
+	// errRefreshTokenInvalid is returned for unknown, malformed or expired refresh tokens.
+	errRefreshTokenInvalid = errors.New("refresh token is invalid or expired")
+	// errRefreshTokenReused is returned when an already-rotated refresh token is replayed.
+	// The whole session is revoked when this happens.
+	errRefreshTokenReused = errors.New("refresh token reuse detected")
+)
+
+// sessionDevice describes the client device a session is bound to.
+type sessionDevice struct {
+	Name       string
+	ID         string
+	OS         string
+	OSVersion  string
+	AppType    string
+	AppVersion string
+	IP         string
+}
+
+// deviceFromContext reads device metadata sent by the mobile apps:
+// x-device-name, x-device-id, x-device-os, x-os-version, x-app-type, x-app-version.
+// Values are truncated to the user_sessions column widths.
+func deviceFromContext(ctx context.Context) sessionDevice {
+	device := sessionDevice{IP: clientIPFromContext(ctx)}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return device
+	}
+
+	get := func(key string, max int) string {
+		if values := md.Get(key); len(values) > 0 {
+			return truncate(strings.TrimSpace(values[0]), max)
+		}
+		return ""
+	}
+	device.Name = get("x-device-name", 255)
+	device.ID = get("x-device-id", 255)
+	device.OS = get("x-device-os", 20)
+	device.OSVersion = get("x-os-version", 50)
+	device.AppType = get("x-app-type", 20)
+	device.AppVersion = get("x-app-version", 20)
+	return device
+}
+
+// loginDevice merges device fields sent in a login request body over the metadata headers.
+func loginDevice(ctx context.Context, name, id string) sessionDevice {
+	device := deviceFromContext(ctx)
+	if name != "" {
+		device.Name = truncate(name, 255)
+	}
+	if id != "" {
+		device.ID = truncate(id, 255)
+	}
+	return device
+}
+
+// clientIPFromContext prefers proxy headers over the peer address since the
+// server runs behind nginx in production.
+func clientIPFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+			ip, _, _ := strings.Cut(forwarded[0], ",")
+			return truncate(strings.TrimSpace(ip), 50)
+		}
+		if realIP := md.Get("x-real-ip"); len(realIP) > 0 {
+			return truncate(strings.TrimSpace(realIP[0]), 50)
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return truncate(addr, 50)
+	}
+	return ""
+}
+
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return s[:max]
+	}
+	return s
+}
+
+// newRefreshToken builds an opaque refresh token of the form "<session_id>.<secret>".
+// The session ID prefix lets us find the token family even after rotation,
+// which is what makes reuse detection possible.
+func newRefreshToken(sessionID string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return sessionID + "." + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// parseRefreshToken extracts the session ID from a refresh token.
+func parseRefreshToken(token string) (string, bool) {
+	sessionID, secret, ok := strings.Cut(token, ".")
+	if !ok || secret == "" {
+		return "", false
+	}
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return "", false
+	}
+	return sessionID, true
+}
+
+// hashRefreshToken returns the value stored in user_sessions.refresh_token_hash.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// createSession inserts a new session for the user and returns its ID with the first refresh token.
+// Logging in again from the same device replaces the previous session of that device.
+// mfa marks sessions created after a TOTP check.
+func createSession(ctx context.Context, db *sql.DB, userID string, device sessionDevice, mfa bool) (string, string, error) {
+	sessionID := uuid.NewString()
+	refreshToken, err := newRefreshToken(sessionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if device.Name == "" {
+		device.Name = "Unknown device"
+	}
+	if device.ID == "" {
+		device.ID = sessionID
+	} else {
+		if _, err := db.ExecContext(ctx, `
+			DELETE FROM user_sessions WHERE user_id = $1 AND device_id = $2
+		`, userID, device.ID); err != nil {
+			return "", "", err
+		}
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO user_sessions (
+			id, user_id, device_name, device_id, device_os, os_version, app_type, app_version,
+			ip_address, refresh_token_hash, mfa_verified, last_active, expires_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10, $11, NOW(), $12, NOW())
+	`, sessionID, userID, device.Name, device.ID, device.OS, device.OSVersion, device.AppType, device.AppVersion,
+		device.IP, hashRefreshToken(refreshToken), mfa, time.Now().Add(refreshTokenTTL))
+	if err != nil {
+		return "", "", err
+	}
+	return sessionID, refreshToken, nil
+}
+
+// listSessions returns the user's active sessions, most recently used first.
+// Expired sessions of trusted devices are included since they can still be resumed with a PIN.
+// currentSessionID marks the session the request was made from.
+func listSessions(ctx context.Context, db *sql.DB, userID, currentSessionID string) ([]models.UserSession, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, user_id, device_name, device_id, COALESCE(device_os, ''), COALESCE(os_version, ''),
+		       COALESCE(app_type, ''), COALESCE(app_version, ''), COALESCE(ip_address, ''),
+		       COALESCE(is_trusted, false) AND COALESCE(trusted_until > NOW(), false), trusted_until,
+		       expires_at, COALESCE(last_active, created_at), created_at
+		FROM user_sessions
+		WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > NOW() OR (COALESCE(is_trusted, false) AND trusted_until > NOW()))
+		ORDER BY last_active DESC NULLS LAST
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []models.UserSession
+	for rows.Next() {
+		var session models.UserSession
+		var expiresAt, trustedUntil sql.NullTime
+		if err := rows.Scan(
+			&session.ID, &session.UserID, &session.DeviceName, &session.DeviceID, &session.DeviceOS, &session.OSVersion,
+			&session.AppType, &session.AppVersion, &session.IPAddress,
+			&session.IsTrusted, &trustedUntil, &expiresAt, &session.LastActive, &session.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if expiresAt.Valid {
+			session.ExpiresAt = &expiresAt.Time
+		}
+		if session.IsTrusted && trustedUntil.Valid {
+			session.TrustedUntil = &trustedUntil.Time
+		}
+		session.IsCurrent = session.ID == currentSessionID
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// rotateSession exchanges a refresh token for a new one.
+// It returns the session ID, the owning user ID and the new refresh token.
+// Replaying a token that has already been rotated revokes the session.
+func rotateSession(ctx context.Context, db *sql.DB, refreshToken string) (string, string, string, error) {
+	sessionID, ok := parseRefreshToken(refreshToken)
+	if !ok {
+		return "", "", "", errRefreshTokenInvalid
+	}
+
+	newToken, err := newRefreshToken(sessionID)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var userID string
+	err = db.QueryRowContext(ctx, `
+		UPDATE user_sessions
+		SET refresh_token_hash = $1, last_active = NOW(), expires_at = $2
+		WHERE id = $3 AND refresh_token_hash = $4 AND (expires_at IS NULL OR expires_at > NOW())
+		RETURNING user_id
+	`, hashRefreshToken(newToken), time.Now().Add(refreshTokenTTL), sessionID, hashRefreshToken(refreshToken)).Scan(&userID)
+	if err == nil {
+		return sessionID, userID, newToken, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", "", "", err
+	}
+
+	// The token did not match: either the session is gone/expired, or the token was already rotated.
+	var expiresAt, trustedUntil sql.NullTime
+	err = db.QueryRowContext(ctx, `
+		SELECT expires_at, CASE WHEN COALESCE(is_trusted, false) THEN trusted_until END
+		FROM user_sessions WHERE id = $1
+	`, sessionID).Scan(&expiresAt, &trustedUntil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", "", errRefreshTokenInvalid
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+
+	expired := expiresAt.Valid && expiresAt.Time.Before(time.Now())
+	if expired && trustedUntil.Valid && trustedUntil.Time.After(time.Now()) {
+		// Expired session of a trusted device: keep it for PIN login
+		return "", "", "", errRefreshTokenInvalid
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM user_sessions WHERE id = $1`, sessionID); err != nil {
+		return "", "", "", err
+	}
+	if expired {
+		return "", "", "", errRefreshTokenInvalid
+	}
+	return "", "", "", errRefreshTokenReused
+}
+
+// revokeSession deletes a single session of the user.
+func revokeSession(ctx context.Context, db *sql.DB, userID, sessionID string) (bool, error) {
+	result, err := db.ExecContext(ctx, `DELETE FROM user_sessions WHERE id = $1 AND user_id = $2`, sessionID, userID)
+	if err != nil {
+		return false, err
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// revokeAllSessions deletes every session of the user, optionally keeping one.
+func revokeAllSessions(ctx context.Context, db execer, userID, exceptSessionID string) (int64, error) {
+	result, err := db.ExecContext(ctx, `
+		DELETE FROM user_sessions WHERE user_id = $1 AND id::text != $2
+	`, userID, exceptSessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,317 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mebellar-backend/internal/grpc/mapper"
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/apperror"
+	"mebellar-backend/pkg/logger"
+	"mebellar-backend/pkg/pb"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ============================================
+// SHOP MEMBERS (staff)
+// ============================================
+
+func (s *ShopServiceServer) InviteShopMember(ctx context.Context, req *pb.InviteShopMemberRequest) (*pb.ShopMemberResponse, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if err := s.validateInviteShopMemberRequest(req.GetPhone(), req.GetRole()); err != nil {
+		return nil, err.(*apperror.AppError).ToGRPCError()
+	}
+	shopID := req.GetShopId()
+	phone := strings.TrimSpace(req.GetPhone())
+
+	callerRole, err := s.callerShopRole(ctx, auth, shopID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageShopRole(callerRole, req.GetRole()) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s cannot invite a %s", callerRole, req.GetRole())
+	}
+
+	var ownerPhone string
+	var nameJSON []byte
+	err = s.db.QueryRowContext(ctx, `
+		SELECT u.phone, sh.name FROM shops sh
+		JOIN seller_profiles sp ON sp.id = sh.seller_id
+		JOIN users u ON u.id = sp.user_id
+		WHERE sh.id = $1
+	`, shopID).Scan(&ownerPhone, &nameJSON)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "shop not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if phone == ownerPhone {
+		return nil, status.Error(codes.AlreadyExists, "this phone belongs to the shop owner")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "tx begin error: %v", err)
+	}
+	defer tx.Rollback()
+
+	var memberID string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO shop_members (shop_id, phone, role, status, invited_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (shop_id, phone) DO NOTHING
+		RETURNING id
+	`, shopID, phone, req.GetRole(), models.ShopMemberInvited, auth.UserID).Scan(&memberID)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.AlreadyExists, "this phone is already a member of the shop")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "insert member error: %v", err)
+	}
+
+	// Invite is only kept if the SMS went out, so the owner can retry otherwise
+	if s.sms != nil {
+		message := fmt.Sprintf("Mebellar: sizni \"%s\" do'koniga xodim (%s) sifatida taklif qilishdi. Taklifni ilovada qabul qiling.",
+			shopDisplayName(nameJSON), req.GetRole())
+		if err := s.sms.SendSMS(phone, message); err != nil {
+			logger.Error("Failed to send shop invite via SMS",
+				zap.String("phone", phone),
+				zap.String("shop_id", shopID),
+				zap.Error(err),
+			)
+			return nil, status.Error(codes.Unavailable, "failed to send invite SMS")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, status.Errorf(codes.Internal, "commit error: %v", err)
+	}
+
+	member, err := s.getShopMember(ctx, shopID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ShopMemberResponse{Member: mapper.ToPBShopMember(member)}, nil
+}
+
+func (s *ShopServiceServer) ListShopMembers(ctx context.Context, req *pb.ListShopMembersRequest) (*pb.ListShopMembersResponse, error) {
+	shopID := req.GetShopId()
+	if shopID == "" {
+		return nil, status.Error(codes.InvalidArgument, "shop_id is required")
+	}
+
+	owner := models.ShopMember{ShopID: shopID, Role: models.ShopRoleOwner, Status: models.ShopMemberActive}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT u.id, u.phone, COALESCE(u.full_name, '') FROM shops sh
+		JOIN seller_profiles sp ON sp.id = sh.seller_id
+		JOIN users u ON u.id = sp.user_id
+		WHERE sh.id = $1
+	`, shopID).Scan(&owner.UserID, &owner.Phone, &owner.FullName)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "shop not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, shopMemberSelect+`
+		WHERE m.shop_id = $1
+		ORDER BY m.created_at ASC
+	`, shopID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &pb.ListShopMembersResponse{Members: []*pb.ShopMember{mapper.ToPBShopMember(owner)}}
+	for rows.Next() {
+		member, err := scanShopMember(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		resp.Members = append(resp.Members, mapper.ToPBShopMember(member))
+	}
+	return resp, nil
+}
+
+func (s *ShopServiceServer) UpdateShopMemberRole(ctx context.Context, req *pb.UpdateShopMemberRoleRequest) (*pb.ShopMemberResponse, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !models.IsValidShopRole(req.GetRole()) {
+		return nil, status.Error(codes.InvalidArgument, "role must be manager, operator or viewer")
+	}
+
+	member, callerRole, err := s.manageableShopMember(ctx, auth, req.GetShopId(), req.GetMemberId())
+	if err != nil {
+		return nil, err
+	}
+	if !canManageShopRole(callerRole, req.GetRole()) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s cannot assign the %s role", callerRole, req.GetRole())
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE shop_members SET role = $1, updated_at = NOW() WHERE id = $2
+	`, req.GetRole(), member.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+
+	member.Role = req.GetRole()
+	return &pb.ShopMemberResponse{Member: mapper.ToPBShopMember(member)}, nil
+}
+
+func (s *ShopServiceServer) RemoveShopMember(ctx context.Context, req *pb.RemoveShopMemberRequest) (*pb.Empty, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	member, _, err := s.manageableShopMember(ctx, auth, req.GetShopId(), req.GetMemberId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM shop_members WHERE id = $1`, member.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete error: %v", err)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (s *ShopServiceServer) AcceptShopInvite(ctx context.Context, req *pb.AcceptShopInviteRequest) (*pb.ShopMemberResponse, error) {
+	auth := middleware.GetAuthContext(ctx)
+	if auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if _, err := uuid.Parse(req.GetShopId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid shop_id")
+	}
+
+	// The invite is bound to the caller's phone, and only a phone proved by an SMS code counts
+	var memberID string
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE shop_members m SET user_id = u.id, status = $1, updated_at = NOW()
+		FROM users u
+		WHERE u.id = $2 AND u.phone_verified_at IS NOT NULL AND m.phone = u.phone
+			AND m.shop_id = $3 AND m.status = $4
+		RETURNING m.id
+	`, models.ShopMemberActive, auth.UserID, req.GetShopId(), models.ShopMemberInvited).Scan(&memberID)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "invite not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+
+	member, err := s.getShopMember(ctx, req.GetShopId(), memberID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ShopMemberResponse{Member: mapper.ToPBShopMember(member)}, nil
+}
+
+// ============================================
+// SHOP MEMBER HELPERS
+// ============================================
+
+const shopMemberSelect = `
+	SELECT m.id, m.shop_id, COALESCE(m.user_id::text, ''), m.phone, COALESCE(u.full_name, ''),
+		   m.role, m.status, m.created_at
+	FROM shop_members m LEFT JOIN users u ON u.id = m.user_id
+`
+
+func scanShopMember(row interface{ Scan(dest ...any) error }) (models.ShopMember, error) {
+	var m models.ShopMember
+	err := row.Scan(&m.ID, &m.ShopID, &m.UserID, &m.Phone, &m.FullName, &m.Role, &m.Status, &m.CreatedAt)
+	return m, err
+}
+
+func (s *ShopServiceServer) getShopMember(ctx context.Context, shopID, memberID string) (models.ShopMember, error) {
+	if _, err := uuid.Parse(memberID); err != nil {
+		return models.ShopMember{}, status.Error(codes.InvalidArgument, "invalid member_id")
+	}
+	member, err := scanShopMember(s.db.QueryRowContext(ctx, shopMemberSelect+`
+		WHERE m.id = $1 AND m.shop_id = $2
+	`, memberID, shopID))
+	if err == sql.ErrNoRows {
+		return member, status.Error(codes.NotFound, "shop member not found")
+	}
+	if err != nil {
+		return member, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return member, nil
+}
+
+// manageableShopMember loads a member the caller is allowed to change or remove.
+func (s *ShopServiceServer) manageableShopMember(ctx context.Context, auth *middleware.AuthContext, shopID, memberID string) (models.ShopMember, string, error) {
+	callerRole, err := s.callerShopRole(ctx, auth, shopID)
+	if err != nil {
+		return models.ShopMember{}, "", err
+	}
+	member, err := s.getShopMember(ctx, shopID, memberID)
+	if err != nil {
+		return member, "", err
+	}
+	if !canManageShopRole(callerRole, member.Role) {
+		return member, "", status.Errorf(codes.PermissionDenied, "%s cannot manage a %s", callerRole, member.Role)
+	}
+	return member, callerRole, nil
+}
+
+// callerShopRole returns the caller's role in the shop. Platform admins act as the owner.
+func (s *ShopServiceServer) callerShopRole(ctx context.Context, auth *middleware.AuthContext, shopID string) (string, error) {
+	if _, err := uuid.Parse(shopID); err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid shop_id")
+	}
+	if auth.Role == models.RoleAdmin {
+		return models.ShopRoleOwner, nil
+	}
+	role := auth.ShopRole
+	if shopID != auth.ShopID || role == "" {
+		var err error
+		if role, err = ShopRoleHelper(ctx, s.db, shopID, auth.UserID); err != nil {
+			return "", err
+		}
+	}
+	if err := requireShopRole(role, models.ShopRoleManager); err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+// canManageShopRole reports whether a caller with callerRole may grant, change or remove
+// the given member role. The owner manages everyone, managers only operators and viewers.
+func canManageShopRole(callerRole, memberRole string) bool {
+	switch callerRole {
+	case models.ShopRoleOwner:
+		return models.IsValidShopRole(memberRole)
+	case models.ShopRoleManager:
+		return memberRole == models.ShopRoleOperator || memberRole == models.ShopRoleViewer
+	}
+	return false
+}
+
+// shopDisplayName picks the shop name for SMS texts (uz first).
+func shopDisplayName(nameJSON []byte) string {
+	names := make(map[string]string)
+	json.Unmarshal(nameJSON, &names)
+	for _, lang := range []string{"uz", "ru", "en"} {
+		if names[lang] != "" {
+			return names[lang]
+		}
+	}
+	return "Mebellar"
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"mebellar-backend/models"
+	"mebellar-backend/pkg/otp"
+	"mebellar-backend/pkg/pb"
+	"mebellar-backend/pkg/sms"
+	"mebellar-backend/pkg/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShopService_Members(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	ctx := context.Background()
+	jwtSecret := []byte("test-secret-key-for-testing-32chars")
+	jwtKeys := testKeyRing(t)
+	mockSMS := &sms.MockSMSService{}
+	authService := NewAuthServiceServer(db, jwtKeys, mockSMS, otp.NewMemoryStore(jwtSecret, otp.DefaultConfig()))
+	shopService := NewShopServiceServer(db, mockSMS)
+
+	ownerID, staffID := uuid.NewString(), uuid.NewString()
+	staffPhone := "+998907770002"
+	for id, phone := range map[string]string{ownerID: "+998907770001", staffID: staffPhone} {
+		_, err := db.Exec(`
+			INSERT INTO users (id, full_name, phone, password_hash, role, is_active)
+			VALUES ($1, 'Shop User', $2, 'x', $3, true)
+		`, id, phone, models.RoleCustomer)
+		require.NoError(t, err)
+	}
+	sellerID, shopID := uuid.NewString(), uuid.NewString()
+	_, err := db.Exec(`INSERT INTO seller_profiles (id, user_id) VALUES ($1, $2)`, sellerID, ownerID)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO shops (id, seller_id, name, slug) VALUES ($1, $2, '{"uz": "Mebel Uy"}', $3)`, shopID, sellerID, "mebel-uy-"+shopID[:8])
+	require.NoError(t, err)
+
+	ownerToken, err := authService.signAccessToken(context.Background(), ownerID, "+998907770001", models.RoleSeller, "", false)
+	require.NoError(t, err)
+	staffToken, err := authService.signAccessToken(context.Background(), staffID, staffPhone, models.RoleCustomer, "", false)
+	require.NoError(t, err)
+
+	// Владелец приглашает оператора, приглашение уходит по SMS
+	inviteReq := &pb.InviteShopMemberRequest{ShopId: shopID, Phone: staffPhone, Role: models.ShopRoleOperator}
+	invited, err := callAuthenticated(t, jwtKeys, db, ownerToken, pb.ShopService_InviteShopMember_FullMethodName, inviteReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.InviteShopMember(ctx, inviteReq)
+	})
+	require.NoError(t, err)
+	member := invited.(*pb.ShopMemberResponse).Member
+	assert.Equal(t, models.ShopMemberInvited, member.Status)
+	assert.Equal(t, staffPhone, mockSMS.LastPhone)
+	assert.Contains(t, mockSMS.LastMessage, "Mebel Uy")
+
+	// До принятия приглашения доступа нет
+	assert.Equal(t, codes.PermissionDenied, status.Code(VerifyShopAccessHelper(ctx, db, shopID, staffID, models.ShopRoleViewer)))
+
+	// Принять приглашение можно только с подтвержденным номером
+	acceptReq := &pb.AcceptShopInviteRequest{ShopId: shopID}
+	accept := func() error {
+		_, err := callAuthenticated(t, jwtKeys, db, staffToken, pb.ShopService_AcceptShopInvite_FullMethodName, acceptReq, func(ctx context.Context) (interface{}, error) {
+			return shopService.AcceptShopInvite(ctx, acceptReq)
+		})
+		return err
+	}
+	assert.Equal(t, codes.NotFound, status.Code(accept()), "unverified phone")
+	require.NoError(t, markPhoneVerified(ctx, db, staffID, staffPhone))
+	require.NoError(t, accept())
+
+	// Оператор работает с заказами, но не с товарами и не с сотрудниками
+	assert.NoError(t, VerifyShopAccessHelper(ctx, db, shopID, staffID, models.ShopRoleOperator))
+	assert.Equal(t, codes.PermissionDenied, status.Code(VerifyShopAccessHelper(ctx, db, shopID, staffID, models.ShopRoleManager)))
+	listReq := &pb.ListShopMembersRequest{ShopId: shopID}
+	_, err = callAuthenticated(t, jwtKeys, db, staffToken, pb.ShopService_ListShopMembers_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.ListShopMembers(ctx, listReq)
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Повышение до менеджера
+	updateReq := &pb.UpdateShopMemberRoleRequest{ShopId: shopID, MemberId: member.Id, Role: models.ShopRoleManager}
+	_, err = callAuthenticated(t, jwtKeys, db, ownerToken, pb.ShopService_UpdateShopMemberRole_FullMethodName, updateReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.UpdateShopMemberRole(ctx, updateReq)
+	})
+	require.NoError(t, err)
+
+	listed, err := callAuthenticated(t, jwtKeys, db, staffToken, pb.ShopService_ListShopMembers_FullMethodName, listReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.ListShopMembers(ctx, listReq)
+	})
+	require.NoError(t, err)
+	members := listed.(*pb.ListShopMembersResponse).Members
+	require.Len(t, members, 2)
+	assert.Equal(t, models.ShopRoleOwner, members[0].Role)
+	assert.Equal(t, models.ShopRoleManager, members[1].Role)
+
+	// Менеджер не управляет менеджерами
+	removeReq := &pb.RemoveShopMemberRequest{ShopId: shopID, MemberId: member.Id}
+	_, err = callAuthenticated(t, jwtKeys, db, staffToken, pb.ShopService_RemoveShopMember_FullMethodName, removeReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.RemoveShopMember(ctx, removeReq)
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = callAuthenticated(t, jwtKeys, db, ownerToken, pb.ShopService_RemoveShopMember_FullMethodName, removeReq, func(ctx context.Context) (interface{}, error) {
+		return shopService.RemoveShopMember(ctx, removeReq)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(VerifyShopAccessHelper(ctx, db, shopID, staffID, models.ShopRoleViewer)))
+}
@@ -12,7 +12,9 @@ import (
 	"time"
 
 	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/models"
 	"mebellar-backend/pkg/pb"
+	"mebellar-backend/pkg/sms"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -23,16 +25,25 @@ import (
 type ShopServiceServer struct {
 	pb.UnimplementedShopServiceServer
 	db         *sql.DB
+	sms        sms.SMSService
 	uploadPath string
+	versions   *middleware.TokenVersions
 }
 
-func NewShopServiceServer(db *sql.DB) *ShopServiceServer {
+func NewShopServiceServer(db *sql.DB, sms sms.SMSService) *ShopServiceServer {
 	return &ShopServiceServer{
 		db:         db,
+		sms:        sms,
 		uploadPath: "./uploads/shops",
+		versions:   middleware.NewTokenVersions(db, nil),
 	}
 }
 
+// SetTokenVersions shares the token version store (and its cache) with the auth interceptor.
+func (s *ShopServiceServer) SetTokenVersions(versions *middleware.TokenVersions) {
+	s.versions = versions
+}
+
 // ============================================
 // PUBLIC ENDPOINTS
 // ============================================
@@ -119,19 +130,26 @@ func (s *ShopServiceServer) GetMyShops(ctx context.Context, req *pb.GetMyShopsRe
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
 
+	shops := []*pb.Shop{}
 	var sellerID string
 	err := s.db.QueryRowContext(ctx, "SELECT id FROM seller_profiles WHERE user_id = $1", auth.UserID).Scan(&sellerID)
-	if err == sql.ErrNoRows {
-		return &pb.GetMyShopsResponse{Shops: []*pb.Shop{}, Count: 0}, nil
-	}
-	if err != nil {
+	if err != nil && err != sql.ErrNoRows {
 		return nil, status.Errorf(codes.Internal, "query error: %v", err)
 	}
+	if err == nil {
+		owned, err := s.listShopsBySeller(ctx, sellerID)
+		if err != nil {
+			return nil, err
+		}
+		shops = append(shops, owned...)
+	}
 
-	shops, err := s.listShopsBySeller(ctx, sellerID)
+	// Shops where the user works as staff
+	staffed, err := s.listShopsByMember(ctx, auth.UserID)
 	if err != nil {
 		return nil, err
 	}
+	shops = append(shops, staffed...)
 
 	return &pb.GetMyShopsResponse{Shops: shops, Count: int32(len(shops))}, nil
 }
@@ -150,12 +168,6 @@ func (s *ShopServiceServer) GetShop(ctx context.Context, req *pb.GetShopRequest)
 		return nil, err
 	}
 
-	if auth.Role != "admin" && auth.Role != "moderator" {
-		if err := s.verifyShopOwnership(ctx, req.GetId(), auth.UserID); err != nil {
-			return nil, err
-		}
-	}
-
 	return &pb.ShopResponse{Shop: shop}, nil
 }
 
@@ -164,9 +176,6 @@ func (s *ShopServiceServer) CreateShop(ctx context.Context, req *pb.CreateShopRe
 	if auth == nil {
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
-	if auth.Role != "seller" && auth.Role != "admin" {
-		return nil, status.Error(codes.PermissionDenied, "seller or admin role required")
-	}
 
 	var sellerID string
 	err := s.db.QueryRowContext(ctx, "SELECT id FROM seller_profiles WHERE user_id = $1", auth.UserID).Scan(&sellerID)
@@ -243,12 +252,6 @@ func (s *ShopServiceServer) UpdateShop(ctx context.Context, req *pb.UpdateShopRe
 		return nil, status.Error(codes.InvalidArgument, "shop id is required")
 	}
 
-	if auth.Role != "admin" && auth.Role != "moderator" {
-		if err := s.verifyShopOwnership(ctx, req.GetId(), auth.UserID); err != nil {
-			return nil, err
-		}
-	}
-
 	updates := []string{"updated_at = NOW()"}
 	args := []interface{}{}
 	argIdx := 1
@@ -334,12 +337,6 @@ func (s *ShopServiceServer) DeleteShop(ctx context.Context, req *pb.DeleteShopRe
 		return nil, status.Error(codes.InvalidArgument, "shop id is required")
 	}
 
-	if auth.Role != "admin" && auth.Role != "moderator" {
-		if err := s.verifyShopOwnership(ctx, req.GetId(), auth.UserID); err != nil {
-			return nil, err
-		}
-	}
-
 	var productCount int
 	s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM products WHERE shop_id = $1", req.GetId()).Scan(&productCount)
 	if productCount > 0 {
@@ -497,6 +494,10 @@ func (s *ShopServiceServer) DeleteSellerAccount(ctx context.Context, req *pb.Del
 
 	s.db.ExecContext(ctx, "DELETE FROM seller_profiles WHERE user_id = $1", auth.UserID)
 	s.db.ExecContext(ctx, "UPDATE users SET role = 'customer' WHERE id = $1", auth.UserID)
+	// Seller tokens must not outlive the seller account
+	if err := s.versions.Bump(ctx, auth.UserID); err != nil {
+		return nil, status.Errorf(codes.Internal, "token revocation error: %v", err)
+	}
 
 	return &pb.DeleteSellerAccountResponse{Success: true, Message: "Seller account deleted"}, nil
 }
@@ -568,11 +569,6 @@ func (s *ShopServiceServer) UploadShopImage(stream pb.ShopService_UploadShopImag
 // ============================================
 
 func (s *ShopServiceServer) AdminListShops(ctx context.Context, req *pb.AdminListShopsRequest) (*pb.ListShopsResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin role required")
-	}
-
 	where := []string{"1=1"}
 	args := []interface{}{}
 	argIdx := 1
@@ -632,10 +628,6 @@ func (s *ShopServiceServer) AdminListShops(ctx context.Context, req *pb.AdminLis
 }
 
 func (s *ShopServiceServer) AdminGetShop(ctx context.Context, req *pb.GetShopRequest) (*pb.ShopResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin role required")
-	}
 	shop, err := s.getShopByField(ctx, "id", req.GetId())
 	if err != nil {
 		return nil, err
@@ -644,11 +636,6 @@ func (s *ShopServiceServer) AdminGetShop(ctx context.Context, req *pb.GetShopReq
 }
 
 func (s *ShopServiceServer) AdminUpdateShop(ctx context.Context, req *pb.AdminUpdateShopRequest) (*pb.ShopResponse, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin role required")
-	}
-
 	updates := []string{"updated_at = NOW()"}
 	args := []interface{}{}
 	argIdx := 1
@@ -672,10 +659,6 @@ func (s *ShopServiceServer) AdminUpdateShop(ctx context.Context, req *pb.AdminUp
 }
 
 func (s *ShopServiceServer) AdminDeleteShop(ctx context.Context, req *pb.DeleteShopRequest) (*pb.Empty, error) {
-	auth := middleware.GetAuthContext(ctx)
-	if auth == nil || (auth.Role != "admin" && auth.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin role required")
-	}
 	s.db.ExecContext(ctx, "DELETE FROM shops WHERE id = $1", req.GetId())
 	return &pb.Empty{}, nil
 }
@@ -741,6 +724,31 @@ func (s *ShopServiceServer) listShopsBySeller(ctx context.Context, sellerID stri
 	return shops, nil
 }
 
+func (s *ShopServiceServer) listShopsByMember(ctx context.Context, userID string) ([]*pb.Shop, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT sh.id, sh.seller_id, sh.name, sh.description, sh.address, sh.slug, sh.logo_url, sh.banner_url,
+			   sh.phone, sh.latitude, sh.longitude, sh.region_id, COALESCE(r.name, '{}'),
+			   sh.working_hours, sh.is_active, sh.is_verified, sh.is_main, sh.rating, sh.created_at, sh.updated_at
+		FROM shop_members m
+		JOIN shops sh ON sh.id = m.shop_id
+		LEFT JOIN regions r ON sh.region_id = r.id
+		WHERE m.user_id = $1 AND m.status = $2 ORDER BY m.created_at DESC
+	`, userID, models.ShopMemberActive)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	var shops []*pb.Shop
+	for rows.Next() {
+		shop, _ := s.scanShop(rows)
+		if shop != nil {
+			shops = append(shops, shop)
+		}
+	}
+	return shops, nil
+}
+
 func (s *ShopServiceServer) scanShop(rows *sql.Rows) (*pb.Shop, error) {
 	var id, sellerID, slug string
 	var name, description, address, workingHours, regionName []byte
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// defaultTrustedDeviceTTL is how long a trusted device may stay unused before
+// PIN login stops working and the device has to be verified by SMS again.
+const defaultTrustedDeviceTTL = 30 * 24 * time.Hour
+
+var (
+	// errSessionNotFound is returned when the session to trust is gone or expired.
+	errSessionNotFound = errors.New("session not found")
+	// errDeviceNotTrusted is returned for unknown, revoked or lapsed device tokens,
+	// and for tokens presented from a different device.
+	errDeviceNotTrusted = errors.New("device is not trusted")
+)
+
+// trustSession marks the user's session as trusted until now+ttl and returns a new device token.
+// The token has the refresh token format "<session_id>.<secret>" and only its hash is stored.
+func trustSession(ctx context.Context, db *sql.DB, userID, sessionID string, ttl time.Duration) (string, time.Time, error) {
+	deviceToken, err := newRefreshToken(sessionID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	trustedUntil := time.Now().Add(ttl)
+	result, err := db.ExecContext(ctx, `
+		UPDATE user_sessions
+		SET is_trusted = true, trust_token_hash = $1, trusted_until = $2
+		WHERE id = $3 AND user_id = $4 AND (expires_at IS NULL OR expires_at > NOW())
+	`, hashRefreshToken(deviceToken), trustedUntil, sessionID, userID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return "", time.Time{}, errSessionNotFound
+	}
+	return deviceToken, trustedUntil, nil
+}
+
+// untrustSession revokes the trust of one of the user's sessions; the session itself stays signed in.
+func untrustSession(ctx context.Context, db *sql.DB, userID, sessionID string) (bool, error) {
+	result, err := db.ExecContext(ctx, `
+		UPDATE user_sessions
+		SET is_trusted = false, trust_token_hash = NULL, trusted_until = NULL
+		WHERE id = $1 AND user_id = $2 AND COALESCE(is_trusted, false)
+	`, sessionID, userID)
+	if err != nil {
+		return false, err
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// findTrustedSession resolves a device token presented from deviceID.
+// It returns the session ID and the owning user ID.
+func findTrustedSession(ctx context.Context, db *sql.DB, deviceToken, deviceID string) (string, string, error) {
+	sessionID, ok := parseRefreshToken(deviceToken)
+	if !ok || deviceID == "" {
+		return "", "", errDeviceNotTrusted
+	}
+
+	var userID string
+	err := db.QueryRowContext(ctx, `
+		SELECT user_id FROM user_sessions
+		WHERE id = $1 AND device_id = $2 AND trust_token_hash = $3
+		  AND COALESCE(is_trusted, false) AND trusted_until > NOW()
+	`, sessionID, deviceID, hashRefreshToken(deviceToken)).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", errDeviceNotTrusted
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return sessionID, userID, nil
+}
+
+// resumeTrustedSession issues a new refresh token for a trusted session and
+// extends both the session and the device trust. A PIN is not a second factor,
+// so the session loses its MFA mark.
+func resumeTrustedSession(ctx context.Context, db *sql.DB, sessionID string, ttl time.Duration) (string, error) {
+	refreshToken, err := newRefreshToken(sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE user_sessions
+		SET refresh_token_hash = $1, last_active = NOW(), expires_at = $2, trusted_until = $3, mfa_verified = false
+		WHERE id = $4 AND COALESCE(is_trusted, false)
+	`, hashRefreshToken(refreshToken), time.Now().Add(refreshTokenTTL), time.Now().Add(ttl), sessionID)
+	if err != nil {
+		return "", err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return "", errDeviceNotTrusted
+	}
+	return refreshToken, nil
+}
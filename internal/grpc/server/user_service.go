@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -13,10 +14,14 @@ import (
 	"mebellar-backend/internal/grpc/mapper"
 	"mebellar-backend/internal/grpc/middleware"
 	"mebellar-backend/models"
+	"mebellar-backend/pkg/apperror"
+	"mebellar-backend/pkg/email"
+	"mebellar-backend/pkg/otp"
 	"mebellar-backend/pkg/pb"
+	"mebellar-backend/pkg/sms"
+	"mebellar-backend/pkg/validator"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -24,16 +29,43 @@ import (
 type UserServiceServer struct {
 	pb.UnimplementedUserServiceServer
 	db         *sql.DB
+	sms        sms.SMSService
+	otp        otp.Store
+	email      email.Sender
 	uploadPath string
+
+	confirmOldPhone bool
+	versions        *middleware.TokenVersions
+	sessions        *middleware.Sessions
 }
 
-func NewUserServiceServer(db *sql.DB) *UserServiceServer {
+func NewUserServiceServer(db *sql.DB, sms sms.SMSService, otpStore otp.Store, emailSender email.Sender) *UserServiceServer {
 	return &UserServiceServer{
 		db:         db,
+		sms:        sms,
+		otp:        otpStore,
+		email:      emailSender,
 		uploadPath: "./uploads/avatars",
+		versions:   middleware.NewTokenVersions(db, nil),
+		sessions:   middleware.NewSessions(db, nil),
 	}
 }
 
+// SetTokenVersions shares the token version store (and its cache) with the auth interceptor.
+func (s *UserServiceServer) SetTokenVersions(versions *middleware.TokenVersions) {
+	s.versions = versions
+}
+
+// SetSessions shares the session store (and its cache) with the auth interceptor.
+func (s *UserServiceServer) SetSessions(sessions *middleware.Sessions) {
+	s.sessions = sessions
+}
+
+// SetPhoneChangeConfirmOld makes phone changes require a code sent to the current number as well.
+func (s *UserServiceServer) SetPhoneChangeConfirmOld(confirm bool) {
+	s.confirmOldPhone = confirm
+}
+
 // ============================================
 // PROFILE MANAGEMENT
 // ============================================
@@ -135,6 +167,9 @@ func (s *UserServiceServer) DeleteAccount(ctx context.Context, req *pb.DeleteAcc
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "delete error: %v", err)
 	}
+	if err := s.versions.Bump(ctx, authCtx.UserID); err != nil {
+		return nil, status.Errorf(codes.Internal, "token revocation error: %v", err)
+	}
 
 	return &pb.DeleteAccountResponse{
 		Success: true,
@@ -156,10 +191,21 @@ func (s *UserServiceServer) RequestPhoneChange(ctx context.Context, req *pb.Requ
 	if newPhone == "" {
 		return nil, status.Error(codes.InvalidArgument, "new_phone is required")
 	}
+	if err := validator.Validate(SendOTPRequestValidation{Phone: newPhone}); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := s.getUserByID(ctx, authCtx.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Phone == newPhone {
+		return nil, status.Error(codes.InvalidArgument, "new phone number is the same as the current one")
+	}
 
 	// Check if phone already exists
 	var exists bool
-	err := s.db.QueryRowContext(ctx, `
+	err = s.db.QueryRowContext(ctx, `
 		SELECT EXISTS(SELECT 1 FROM users WHERE phone = $1 AND id != $2)
 	`, newPhone, authCtx.UserID).Scan(&exists)
 	if err != nil {
@@ -169,11 +215,38 @@ func (s *UserServiceServer) RequestPhoneChange(ctx context.Context, req *pb.Requ
 		return nil, status.Error(codes.AlreadyExists, "phone number already in use")
 	}
 
-	// TODO: Send OTP to new phone number
-	// For now, return success
+	phones := []string{newPhone}
+	if s.confirmOldPhone {
+		phones = append(phones, user.Phone)
+	}
+	for _, phone := range phones {
+		code, err := s.otp.Issue(ctx, otp.PurposeChangePhone, phoneChangeKey(authCtx.UserID, phone))
+		if err != nil {
+			return nil, otpErrorToGRPC(err, phone)
+		}
+		if s.sms != nil {
+			if err := s.sms.SendOTP(phone, code); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to send SMS: %v", err)
+			}
+		}
+	}
+
+	err = savePhoneChange(ctx, s.db, authCtx.UserID, pendingPhoneChange{
+		NewPhone:        newPhone,
+		ConfirmOldPhone: s.confirmOldPhone,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "save error: %v", err)
+	}
+
+	message := "OTP sent to new phone number"
+	if s.confirmOldPhone {
+		message = "OTP sent to new and current phone numbers"
+	}
 	return &pb.RequestPhoneChangeResponse{
-		Success: true,
-		Message: "OTP sent to new phone number",
+		Success:              true,
+		Message:              message,
+		OldPhoneCodeRequired: s.confirmOldPhone,
 	}, nil
 }
 
@@ -190,16 +263,49 @@ func (s *UserServiceServer) VerifyPhoneChange(ctx context.Context, req *pb.Verif
 		return nil, status.Error(codes.InvalidArgument, "new_phone and code are required")
 	}
 
-	// TODO: Verify OTP code
-	// For now, assume verification is successful
+	change, err := loadPhoneChange(ctx, s.db, authCtx.UserID)
+	if errors.Is(err, errNoPhoneChange) {
+		return nil, status.Error(codes.FailedPrecondition, "no pending phone change, request a new code")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if change.NewPhone != newPhone {
+		return nil, status.Error(codes.InvalidArgument, "new_phone does not match the requested change")
+	}
 
-	// Update phone using helper
-	err := UpdateUserFieldHelper(ctx, s.db, "phone", newPhone, authCtx.UserID)
+	user, err := s.getUserByID(ctx, authCtx.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	user, err := s.getUserByID(ctx, authCtx.UserID)
+	oldCode := strings.TrimSpace(req.GetOldPhoneCode())
+	if change.ConfirmOldPhone && oldCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "old_phone_code is required")
+	}
+	// Both codes are checked before either is used, so a wrong one doesn't burn the other
+	if err := s.otp.Check(ctx, otp.PurposeChangePhone, phoneChangeKey(authCtx.UserID, newPhone), code); err != nil {
+		return nil, otpErrorToGRPC(err, newPhone)
+	}
+	if change.ConfirmOldPhone {
+		if err := s.otp.Verify(ctx, otp.PurposeChangePhone, phoneChangeKey(authCtx.UserID, user.Phone), oldCode); err != nil {
+			return nil, otpErrorToGRPC(err, user.Phone)
+		}
+	}
+	if err := s.otp.Verify(ctx, otp.PurposeChangePhone, phoneChangeKey(authCtx.UserID, newPhone), code); err != nil {
+		return nil, otpErrorToGRPC(err, newPhone)
+	}
+
+	// Other devices are signed out, the current session stays
+	err = applyPhoneChange(ctx, s.db, authCtx.UserID, authCtx.SessionID, user.Phone, newPhone)
+	if errors.Is(err, errPhoneTaken) {
+		return nil, status.Error(codes.AlreadyExists, "phone number already in use")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+
+	user, err = s.getUserByID(ctx, authCtx.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -221,12 +327,46 @@ func (s *UserServiceServer) RequestEmailChange(ctx context.Context, req *pb.Requ
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
 
-	newEmail := strings.TrimSpace(req.GetNewEmail())
+	newEmail := strings.ToLower(strings.TrimSpace(req.GetNewEmail()))
 	if newEmail == "" {
 		return nil, status.Error(codes.InvalidArgument, "new_email is required")
 	}
+	if err := s.validateEmailChangeRequest(newEmail); err != nil {
+		return nil, err.(*apperror.AppError).ToGRPCError()
+	}
+	if s.email == nil {
+		return nil, status.Error(codes.Unavailable, "email delivery is not configured")
+	}
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = $1 AND id != $2)
+	`, newEmail, authCtx.UserID).Scan(&exists)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	if exists {
+		return nil, status.Error(codes.AlreadyExists, "email already in use")
+	}
+
+	// The code is stored hashed with an expiry and is bound to this user and address
+	code, err := s.otp.Issue(ctx, otp.PurposeChangeEmail, emailChangeKey(authCtx.UserID, newEmail))
+	if err != nil {
+		return nil, otpErrorToGRPC(err, newEmail)
+	}
+
+	msg, err := email.Render(email.TemplateVerifyEmail, languageFromContext(ctx), email.VerifyEmailData{
+		Code:       code,
+		TTLMinutes: int(otp.DefaultConfig().TTL.Minutes()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "email template error: %v", err)
+	}
+	msg.To = newEmail
+	if err := s.email.Send(ctx, msg); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to send email: %v", err)
+	}
 
-	// TODO: Send verification email
 	return &pb.RequestEmailChangeResponse{
 		Success: true,
 		Message: "Verification email sent",
@@ -239,23 +379,31 @@ func (s *UserServiceServer) VerifyEmailChange(ctx context.Context, req *pb.Verif
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
 
-	newEmail := strings.TrimSpace(req.GetNewEmail())
+	newEmail := strings.ToLower(strings.TrimSpace(req.GetNewEmail()))
 	code := strings.TrimSpace(req.GetCode())
 
 	if newEmail == "" || code == "" {
 		return nil, status.Error(codes.InvalidArgument, "new_email and code are required")
 	}
 
-	// TODO: Verify email code
-	// For now, assume verification is successful
+	if err := s.otp.Verify(ctx, otp.PurposeChangeEmail, emailChangeKey(authCtx.UserID, newEmail), code); err != nil {
+		return nil, otpErrorToGRPC(err, newEmail)
+	}
 
-	// Update email using helper
-	err := UpdateUserFieldHelper(ctx, s.db, "email", newEmail, authCtx.UserID)
+	user, err := s.getUserByID(ctx, authCtx.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	user, err := s.getUserByID(ctx, authCtx.UserID)
+	err = applyEmailChange(ctx, s.db, authCtx.UserID, user.Email, newEmail)
+	if errors.Is(err, errEmailTaken) {
+		return nil, status.Error(codes.AlreadyExists, "email already in use")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	}
+
+	user, err = s.getUserByID(ctx, authCtx.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -277,33 +425,17 @@ func (s *UserServiceServer) SetPin(ctx context.Context, req *pb.SetPinRequest) (
 		return nil, status.Error(codes.Unauthenticated, "authentication required")
 	}
 
-	pin := strings.TrimSpace(req.GetPin())
-	if len(pin) < 4 || len(pin) > 6 {
-		return nil, status.Error(codes.InvalidArgument, "PIN must be 4-6 digits")
-	}
-
-	// Hash PIN (using bcrypt for security)
-	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	exists, err := hasStoredPin(ctx, s.db, authCtx.UserID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "PIN hash error: %v", err)
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
 	}
-
-	// Store PIN hash in a secure way (you might want a separate table for PINs)
-	// For now, we'll use a placeholder approach
-	// In production, consider storing PIN hash separately or using a different mechanism
-
-	// Update has_pin flag
-	_, err = s.db.ExecContext(ctx, `
-		UPDATE users 
-		SET has_pin = true, updated_at = NOW()
-		WHERE id = $1
-	`, authCtx.UserID)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "update error: %v", err)
+	if exists {
+		return nil, status.Error(codes.FailedPrecondition, "PIN already set, use ChangePin")
 	}
 
-	// TODO: Store PIN hash securely (consider a separate table or encrypted storage)
-	_ = hash // Suppress unused variable warning
+	if err := storePin(ctx, s.db, authCtx.UserID, strings.TrimSpace(req.GetPin())); err != nil {
+		return nil, pinErrorToGRPC(err)
+	}
 
 	return &pb.SetPinResponse{
 		Success: true,
@@ -322,25 +454,101 @@ func (s *UserServiceServer) VerifyPin(ctx context.Context, req *pb.VerifyPinRequ
 		return nil, status.Error(codes.InvalidArgument, "PIN is required")
 	}
 
-	// TODO: Verify PIN against stored hash
-	// For now, return success if user has PIN set
-	var hasPin bool
-	err := s.db.QueryRowContext(ctx, `
-		SELECT COALESCE(has_pin, false) FROM users WHERE id = $1
-	`, authCtx.UserID).Scan(&hasPin)
+	if err := checkPin(ctx, s.db, s.sms, authCtx.UserID, pin); err != nil {
+		return nil, pinErrorToGRPC(err)
+	}
+
+	return &pb.VerifyPinResponse{
+		Success: true,
+		Message: "PIN verified",
+	}, nil
+}
+
+func (s *UserServiceServer) ChangePin(ctx context.Context, req *pb.ChangePinRequest) (*pb.ChangePinResponse, error) {
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	newPin := strings.TrimSpace(req.GetNewPin())
+	if err := validatePin(newPin); err != nil {
+		return nil, pinErrorToGRPC(err)
+	}
+
+	// A wrong old PIN counts towards the lockout like VerifyPin
+	if err := checkPin(ctx, s.db, s.sms, authCtx.UserID, strings.TrimSpace(req.GetOldPin())); err != nil {
+		return nil, pinErrorToGRPC(err)
+	}
+	if err := storePin(ctx, s.db, authCtx.UserID, newPin); err != nil {
+		return nil, pinErrorToGRPC(err)
+	}
+
+	return &pb.ChangePinResponse{
+		Success: true,
+		Message: "PIN changed successfully",
+	}, nil
+}
+
+func (s *UserServiceServer) RequestPinReset(ctx context.Context, req *pb.RequestPinResetRequest) (*pb.RequestPinResetResponse, error) {
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	user, err := s.getUserByID(ctx, authCtx.UserID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+		return nil, err
 	}
 
-	if !hasPin {
-		return nil, status.Error(codes.FailedPrecondition, "PIN not set")
+	code, err := s.otp.Issue(ctx, otp.PurposeResetPin, user.Phone)
+	if err != nil {
+		return nil, otpErrorToGRPC(err, user.Phone)
+	}
+	if s.sms != nil {
+		if err := s.sms.SendOTP(user.Phone, code); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to send SMS: %v", err)
+		}
 	}
 
-	// TODO: Implement actual PIN verification
-	// For now, return success
-	return &pb.VerifyPinResponse{
+	return &pb.RequestPinResetResponse{
 		Success: true,
-		Message: "PIN verified",
+		Message: "Code sent to your phone",
+	}, nil
+}
+
+func (s *UserServiceServer) ResetPin(ctx context.Context, req *pb.ResetPinRequest) (*pb.ResetPinResponse, error) {
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	newPin := strings.TrimSpace(req.GetNewPin())
+	if err := validatePin(newPin); err != nil {
+		return nil, pinErrorToGRPC(err)
+	}
+
+	user, err := s.getUserByID(ctx, authCtx.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.otp.Verify(ctx, otp.PurposeResetPin, user.Phone, strings.TrimSpace(req.GetCode())); err != nil {
+		return nil, otpErrorToGRPC(err, user.Phone)
+	}
+
+	if err := storePin(ctx, s.db, authCtx.UserID, newPin); err != nil {
+		return nil, pinErrorToGRPC(err)
+	}
+	// The SMS code proves ownership, so earlier failures no longer count
+	if _, err := clearLoginFailures(ctx, s.db, authCtx.UserID); err != nil {
+		return nil, status.Errorf(codes.Internal, "lockout reset error: %v", err)
+	}
+	if err := markPhoneVerified(ctx, s.db, authCtx.UserID, user.Phone); err != nil {
+		return nil, status.Errorf(codes.Internal, "phone verification error: %v", err)
+	}
+
+	return &pb.ResetPinResponse{
+		Success: true,
+		Message: "PIN reset successfully",
 	}, nil
 }
 
@@ -415,15 +623,107 @@ func (s *UserServiceServer) UploadAvatar(stream pb.UserService_UploadAvatarServe
 }
 
 // ============================================
-// ADMIN ENDPOINTS
+// DEVICE SESSIONS
 // ============================================
 
-func (s *UserServiceServer) AdminListUsers(ctx context.Context, req *pb.AdminListUsersRequest) (*pb.AdminListUsersResponse, error) {
+func (s *UserServiceServer) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	sessions, err := listSessions(ctx, s.db, authCtx.UserID, authCtx.SessionID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+
+	resp := &pb.ListSessionsResponse{}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, mapper.ToPBSession(session))
+	}
+	return resp, nil
+}
+
+func (s *UserServiceServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if _, err := uuid.Parse(req.GetSessionId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session_id")
+	}
+
+	revoked, err := revokeSession(ctx, s.db, authCtx.UserID, req.GetSessionId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "revoke error: %v", err)
+	}
+	if !revoked {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+	// The device's access token stops working along with the session
+	s.sessions.Forget(req.GetSessionId())
+
+	return &pb.RevokeSessionResponse{
+		Success: true,
+		Message: "Session revoked successfully",
+	}, nil
+}
+
+func (s *UserServiceServer) UntrustSession(ctx context.Context, req *pb.UntrustSessionRequest) (*pb.UntrustSessionResponse, error) {
 	authCtx := middleware.GetAuthContext(ctx)
-	if authCtx == nil || (authCtx.Role != "admin" && authCtx.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
+	if authCtx == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if _, err := uuid.Parse(req.GetSessionId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session_id")
+	}
+
+	untrusted, err := untrustSession(ctx, s.db, authCtx.UserID, req.GetSessionId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "untrust error: %v", err)
+	}
+	if !untrusted {
+		return nil, status.Error(codes.NotFound, "trusted session not found")
 	}
 
+	return &pb.UntrustSessionResponse{
+		Success: true,
+		Message: "Device is no longer trusted",
+	}, nil
+}
+
+func (s *UserServiceServer) RevokeOtherSessions(ctx context.Context, req *pb.RevokeOtherSessionsRequest) (*pb.RevokeOtherSessionsResponse, error) {
+	authCtx := middleware.GetAuthContext(ctx)
+	if authCtx == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if authCtx.SessionID == "" {
+		return nil, status.Error(codes.FailedPrecondition, "current session is unknown, please log in again")
+	}
+
+	count, err := revokeAllSessions(ctx, s.db, authCtx.UserID, authCtx.SessionID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "revoke error: %v", err)
+	}
+	// Access tokens of the other devices are revoked as well; this device gets a new one on refresh
+	if err := s.versions.Bump(ctx, authCtx.UserID); err != nil {
+		return nil, status.Errorf(codes.Internal, "token revocation error: %v", err)
+	}
+
+	return &pb.RevokeOtherSessionsResponse{
+		Success:      true,
+		Message:      "Other sessions revoked successfully",
+		RevokedCount: int32(count),
+	}, nil
+}
+
+// ============================================
+// ADMIN ENDPOINTS
+// ============================================
+
+func (s *UserServiceServer) AdminListUsers(ctx context.Context, req *pb.AdminListUsersRequest) (*pb.AdminListUsersResponse, error) {
 	// Build query
 	query := "SELECT id, full_name, phone, COALESCE(email, ''), COALESCE(avatar_url, ''), " +
 		"COALESCE(role, 'customer'), COALESCE(onesignal_id, ''), COALESCE(has_pin, false), " +
@@ -503,11 +803,6 @@ func (s *UserServiceServer) AdminListUsers(ctx context.Context, req *pb.AdminLis
 }
 
 func (s *UserServiceServer) AdminGetUser(ctx context.Context, req *pb.AdminGetUserRequest) (*pb.ProfileResponse, error) {
-	authCtx := middleware.GetAuthContext(ctx)
-	if authCtx == nil || (authCtx.Role != "admin" && authCtx.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	user, err := s.getUserByID(ctx, req.GetId())
 	if err != nil {
 		return nil, err
@@ -519,11 +814,6 @@ func (s *UserServiceServer) AdminGetUser(ctx context.Context, req *pb.AdminGetUs
 }
 
 func (s *UserServiceServer) AdminUpdateUser(ctx context.Context, req *pb.AdminUpdateUserRequest) (*pb.ProfileResponse, error) {
-	authCtx := middleware.GetAuthContext(ctx)
-	if authCtx == nil || (authCtx.Role != "admin" && authCtx.Role != "moderator") {
-		return nil, status.Error(codes.PermissionDenied, "admin or moderator role required")
-	}
-
 	updates := []string{}
 	args := []interface{}{}
 	argPos := 1
@@ -560,6 +850,10 @@ func (s *UserServiceServer) AdminUpdateUser(ctx context.Context, req *pb.AdminUp
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "update error: %v", err)
 	}
+	// Role and status live in the token: make the user get a fresh one
+	if err := s.versions.Bump(ctx, req.GetId()); err != nil {
+		return nil, status.Errorf(codes.Internal, "token revocation error: %v", err)
+	}
 
 	user, err := s.getUserByID(ctx, req.GetId())
 	if err != nil {
@@ -572,11 +866,6 @@ func (s *UserServiceServer) AdminUpdateUser(ctx context.Context, req *pb.AdminUp
 }
 
 func (s *UserServiceServer) AdminDeleteUser(ctx context.Context, req *pb.AdminDeleteUserRequest) (*pb.Empty, error) {
-	authCtx := middleware.GetAuthContext(ctx)
-	if authCtx == nil || authCtx.Role != "admin" {
-		return nil, status.Error(codes.PermissionDenied, "admin role required")
-	}
-
 	// Hard delete for admin
 	_, err := s.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, req.GetId())
 	if err != nil {
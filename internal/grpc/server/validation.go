@@ -60,3 +60,77 @@ func (s *AuthServiceServer) validateSendOTPRequest(phone string) error {
 	}
 	return nil
 }
+
+// ResetPasswordRequestValidation validates reset password request
+type ResetPasswordRequestValidation struct {
+	Phone       string `validate:"required,phone_uz"`
+	Code        string `validate:"required,numeric"`
+	NewPassword string `validate:"required,strong_password"`
+}
+
+func (s *AuthServiceServer) validateResetPasswordRequest(phone, code, newPassword string) error {
+	req := ResetPasswordRequestValidation{
+		Phone:       phone,
+		Code:        code,
+		NewPassword: newPassword,
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return apperror.NewValidationError(err.Error())
+	}
+	return nil
+}
+
+// InviteShopMemberValidation validates shop member invite request
+type InviteShopMemberValidation struct {
+	Phone string `validate:"required,phone_uz"`
+	Role  string `validate:"required,oneof=manager operator viewer"`
+}
+
+func (s *ShopServiceServer) validateInviteShopMemberRequest(phone, role string) error {
+	req := InviteShopMemberValidation{
+		Phone: phone,
+		Role:  role,
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return apperror.NewValidationError(err.Error())
+	}
+	return nil
+}
+
+// EmailChangeValidation validates the new email of an email change
+type EmailChangeValidation struct {
+	Email string `validate:"required,email,max=255"`
+}
+
+func (s *UserServiceServer) validateEmailChangeRequest(email string) error {
+	req := EmailChangeValidation{
+		Email: email,
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return apperror.NewValidationError(err.Error())
+	}
+	return nil
+}
+
+// CreateApiKeyValidation validates a new shop API key
+type CreateApiKeyValidation struct {
+	Name          string   `validate:"required,max=100"`
+	Scopes        []string `validate:"required,min=1,dive,oneof=products:read products:write orders:read orders:write"`
+	ExpiresInDays int32    `validate:"min=0,max=3650"`
+}
+
+func (s *ShopServiceServer) validateCreateApiKeyRequest(name string, scopes []string, expiresInDays int32) error {
+	req := CreateApiKeyValidation{
+		Name:          name,
+		Scopes:        scopes,
+		ExpiresInDays: expiresInDays,
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return apperror.NewValidationError(err.Error())
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,7 +18,10 @@ import (
 	"mebellar-backend/internal/grpc/server"
 	"mebellar-backend/pkg/cache"
 	"mebellar-backend/pkg/database"
+	"mebellar-backend/pkg/email"
+	"mebellar-backend/pkg/jwtkeys"
 	"mebellar-backend/pkg/logger"
+	"mebellar-backend/pkg/otp"
 	"mebellar-backend/pkg/pb"
 	"mebellar-backend/pkg/ratelimit"
 	"mebellar-backend/pkg/sms"
@@ -188,6 +192,8 @@ func main() {
 	staticPort := getEnv("STATIC_PORT", "8081")
 	grpcPort := getEnv("GRPC_PORT", "50051")
 	jwtSecret := getEnv("JWT_SECRET", "mebellar-super-secret-key-2024")
+	otpSecret := getEnv("OTP_SECRET", jwtSecret)
+	jwtKeys := initJWTKeys(jwtSecret)
 
 	// 5. Предупреждение для production
 	if environment == "production" && sslMode == "disable" {
@@ -245,24 +251,50 @@ func main() {
 		logger.Info("In-memory cache initialized")
 	}
 
+	// OTP store setup
+	var otpStore otp.Store
+	if redisClient != nil {
+		otpStore = otp.NewRedisStore(redisClient, []byte(otpSecret), "mebellar:", otp.DefaultConfig())
+		logger.Info("Redis OTP store initialized")
+	} else {
+		otpStore = otp.NewMemoryStore([]byte(otpSecret), otp.DefaultConfig())
+		logger.Info("In-memory OTP store initialized")
+	}
+
 	// 10. Rate limiting setup
 	var rateLimiters map[string]ratelimit.Limiter
 	if redisClient != nil {
 		// Distributed rate limiting с Redis
 		rateLimiters = map[string]ratelimit.Limiter{
-			"/auth.AuthService/Login":    ratelimit.NewRedisLimiter(redisClient, 5, 1*time.Minute),
-			"/auth.AuthService/SendOTP":  ratelimit.NewRedisLimiter(redisClient, 3, 1*time.Minute),
-			"/auth.AuthService/Register": ratelimit.NewRedisLimiter(redisClient, 3, 1*time.Minute),
-			"default":                    ratelimit.NewRedisLimiter(redisClient, 60, 1*time.Minute),
+			"/auth.AuthService/Login":     ratelimit.NewRedisLimiter(redisClient, 5, 1*time.Minute),
+			"/auth.AuthService/SendOTP":   ratelimit.NewRedisLimiter(redisClient, 3, 1*time.Minute),
+			"/auth.AuthService/VerifyOTP": ratelimit.NewRedisLimiter(redisClient, 10, 1*time.Minute),
+			"/auth.AuthService/Register":  ratelimit.NewRedisLimiter(redisClient, 3, 1*time.Minute),
+			// Per phone number, see middleware.phoneKeyedMethods
+			"/auth.AuthService/ForgotPassword":   ratelimit.NewRedisLimiter(redisClient, 3, 15*time.Minute),
+			"/auth.AuthService/ResetPassword":    ratelimit.NewRedisLimiter(redisClient, 5, 15*time.Minute),
+			"/auth.AuthService/RequestLoginCode": ratelimit.NewRedisLimiter(redisClient, 5, 15*time.Minute),
+			"/auth.AuthService/LoginWithCode":    ratelimit.NewRedisLimiter(redisClient, 10, 15*time.Minute),
+			"/auth.AuthService/LoginWithPin":     ratelimit.NewRedisLimiter(redisClient, 10, 1*time.Minute),
+			"/auth.AuthService/VerifyMfaLogin":   ratelimit.NewRedisLimiter(redisClient, 5, 1*time.Minute),
+			"default":                            ratelimit.NewRedisLimiter(redisClient, 60, 1*time.Minute),
 		}
 		logger.Info("Redis-based rate limiting initialized")
 	} else {
 		// In-memory rate limiting для single instance
 		rateLimiters = map[string]ratelimit.Limiter{
-			"/auth.AuthService/Login":    ratelimit.NewMemoryLimiter(5, 10),
-			"/auth.AuthService/SendOTP":  ratelimit.NewMemoryLimiter(3, 5),
-			"/auth.AuthService/Register": ratelimit.NewMemoryLimiter(3, 5),
-			"default":                    ratelimit.NewMemoryLimiter(60, 100),
+			"/auth.AuthService/Login":     ratelimit.NewMemoryLimiter(5, 10),
+			"/auth.AuthService/SendOTP":   ratelimit.NewMemoryLimiter(3, 5),
+			"/auth.AuthService/VerifyOTP": ratelimit.NewMemoryLimiter(10, 10),
+			"/auth.AuthService/Register":  ratelimit.NewMemoryLimiter(3, 5),
+			// Per phone number, see middleware.phoneKeyedMethods
+			"/auth.AuthService/ForgotPassword":   ratelimit.NewMemoryWindowLimiter(3, 15*time.Minute),
+			"/auth.AuthService/ResetPassword":    ratelimit.NewMemoryWindowLimiter(5, 15*time.Minute),
+			"/auth.AuthService/RequestLoginCode": ratelimit.NewMemoryWindowLimiter(5, 15*time.Minute),
+			"/auth.AuthService/LoginWithCode":    ratelimit.NewMemoryWindowLimiter(10, 15*time.Minute),
+			"/auth.AuthService/LoginWithPin":     ratelimit.NewMemoryLimiter(10, 10),
+			"/auth.AuthService/VerifyMfaLogin":   ratelimit.NewMemoryLimiter(5, 5),
+			"default":                            ratelimit.NewMemoryLimiter(60, 100),
 		}
 		logger.Info("In-memory rate limiting initialized")
 	}
@@ -277,52 +309,27 @@ func main() {
 	logger.Info("Initializing SMS service")
 	smsService := initSMSService()
 
+	// Email (SMTP or .eml files for development)
+	emailSender := initEmailSender()
+
 	// 13. gRPC Server setup
 	logger.Info("Configuring gRPC server")
 
-	// Methods that don't require authentication
-	skipAuthMethods := map[string]bool{
-		// Auth service - public
-		"/auth.AuthService/SendOTP":      true,
-		"/auth.AuthService/Login":        true,
-		"/auth.AuthService/Register":     true,
-		"/auth.AuthService/VerifyOTP":    true,
-		"/auth.AuthService/RefreshToken": true,
-
-		// Product service - public read endpoints
-		"/product.ProductService/GetProduct":                       true,
-		"/product.ProductService/ListProducts":                     true,
-		"/product.ProductService/ListNewArrivals":                  true,
-		"/product.ProductService/ListPopularProducts":              true,
-		"/product.ProductService/ListProductsGroupedBySubcategory": true,
-
-		// Category service - public read endpoints
-		"/category.CategoryService/ListCategories":         true,
-		"/category.CategoryService/ListFlatCategories":     true,
-		"/category.CategoryService/GetCategory":            true,
-		"/category.CategoryService/ListCategoryAttributes": true,
-		"/category.CategoryService/GetCategoryAttribute":   true,
-
-		// Shop service - public endpoints
-		"/shop.ShopService/GetShopBySlug":          true,
-		"/shop.ShopService/GetPublicSellerProfile": true,
-
-		// Common service - public endpoints
-		"/common.CommonService/ListRegions":             true,
-		"/common.CommonService/GetRegion":               true,
-		"/common.CommonService/ListBanners":             true,
-		"/common.CommonService/GetBanner":               true,
-		"/common.CommonService/ListCancellationReasons": true,
-
-		// Order service - create order is public (guest checkout)
-		"/order.OrderService/CreateOrder": true,
-	}
+	// Access policy for every gRPC method (public / authenticated / roles / owner checks)
+	policies := server.Policies()
 
 	unaryAuthInterceptor, streamAuthInterceptor := middleware.NewAuthInterceptors(
-		[]byte(jwtSecret),
+		jwtKeys,
 		db,
-		skipAuthMethods,
+		policies,
+		cacheService,
 	)
+	// Mutating calls made by admins impersonating a user go to audit_logs
+	unaryImpersonationAudit, streamImpersonationAudit := server.ImpersonationAuditInterceptors(db)
+	// Bumped on deactivation, role change, password reset and logout everywhere; shares the interceptor's cache
+	tokenVersions := middleware.NewTokenVersions(db, cacheService)
+	// Signing a device out revokes its access token too; shares the interceptor's cache
+	sessions := middleware.NewSessions(db, cacheService)
 
 	// Keepalive settings to prevent stream disconnection
 	kasp := keepalive.ServerParameters{
@@ -338,7 +345,7 @@ func main() {
 		PermitWithoutStream: true,            // Allow pings even when there are no active streams
 	}
 
-	// Chain interceptors: Logger first, then Rate Limiting, then Auth
+	// Chain interceptors: Logger first, then Rate Limiting, then Auth, then impersonation audit
 	grpcServer := grpc.NewServer(
 		grpc.KeepaliveParams(kasp),
 		grpc.KeepaliveEnforcementPolicy(kaep),
@@ -346,30 +353,43 @@ func main() {
 			middleware.UnaryLogger,
 			middleware.AdaptiveRateLimitInterceptor(rateLimiters),
 			unaryAuthInterceptor,
+			unaryImpersonationAudit,
 		),
 		grpc.ChainStreamInterceptor(
 			middleware.StreamLogger,
 			streamAuthInterceptor,
+			streamImpersonationAudit,
 		),
 	)
 
 	// Register all gRPC services
-	authService := server.NewAuthServiceServer(db, []byte(jwtSecret), smsService)
+	authService := server.NewAuthServiceServer(db, jwtKeys, smsService, otpStore)
+	authService.SetTrustedDeviceTTL(getEnvDuration("TRUSTED_DEVICE_TTL", 30*24*time.Hour))
+	authService.SetMFAKey([]byte(getEnv("MFA_SECRET", jwtSecret)))
+	authService.SetTokenVersions(tokenVersions)
+	authService.SetSessions(sessions)
 	pb.RegisterAuthServiceServer(grpcServer, authService)
 
-	userService := server.NewUserServiceServer(db)
+	userService := server.NewUserServiceServer(db, smsService, otpStore, emailSender)
+	userService.SetPhoneChangeConfirmOld(getEnv("PHONE_CHANGE_CONFIRM_OLD", "false") == "true")
+	userService.SetTokenVersions(tokenVersions)
+	userService.SetSessions(sessions)
 	pb.RegisterUserServiceServer(grpcServer, userService)
 
-	orderService := server.NewOrderServiceServer(db)
+	orderService := server.NewOrderServiceServer(db, emailSender)
 	pb.RegisterOrderServiceServer(grpcServer, orderService)
 
+	cartService := server.NewCartServiceServer(db)
+	pb.RegisterCartServiceServer(grpcServer, cartService)
+
 	productService := server.NewProductServiceServer(db)
 	pb.RegisterProductServiceServer(grpcServer, productService)
 
 	categoryService := server.NewCategoryServiceServer(db, cacheService)
 	pb.RegisterCategoryServiceServer(grpcServer, categoryService)
 
-	shopService := server.NewShopServiceServer(db)
+	shopService := server.NewShopServiceServer(db, smsService)
+	shopService.SetTokenVersions(tokenVersions)
 	pb.RegisterShopServiceServer(grpcServer, shopService)
 
 	commonService := server.NewCommonServiceServer(db)
@@ -378,6 +398,11 @@ func main() {
 	// Enable reflection for gRPC CLI tools (grpcurl, grpcui, etc.)
 	reflection.Register(grpcServer)
 
+	// Every registered method must have an access policy
+	if err := policies.Validate(grpcServer.GetServiceInfo()); err != nil {
+		logger.Fatal("Access policy validation failed", zap.Error(err))
+	}
+
 	// 11. Start servers
 	logger.Info("Starting servers",
 		zap.String("static_port", staticPort),
@@ -397,6 +422,9 @@ func main() {
 		fs := http.FileServer(http.Dir("uploads"))
 		mux.Handle("/uploads/", http.StripPrefix("/uploads/", fs))
 
+		// Public JWT verification keys for other services
+		mux.Handle("/.well-known/jwks.json", jwtKeys.Handler())
+
 		// Health check endpoint with connection pool stats
 		mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
@@ -480,6 +508,68 @@ func initSMSService() sms.SMSService {
 	return eskizService
 }
 
+// initEmailSender returns an SMTP sender when SMTP_HOST is set, otherwise a development
+// sender that writes emails to EMAIL_OUTBOX_DIR (or only logs them).
+func initEmailSender() email.Sender {
+	from := getEnv("EMAIL_FROM", "Mebellar <no-reply@mebellar.uz>")
+	smtpHost := os.Getenv("SMTP_HOST")
+	if smtpHost == "" {
+		logger.Warn("SMTP_HOST not set, emails will be written to the outbox directory or log")
+		return email.NewFileSender(os.Getenv("EMAIL_OUTBOX_DIR"), from)
+	}
+	logger.Info("SMTP email sender initialized", zap.String("host", smtpHost))
+	return email.NewSMTPSender(email.SMTPConfig{
+		Host:     smtpHost,
+		Port:     getEnvInt("SMTP_PORT", 587),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     from,
+	})
+}
+
+// initJWTKeys собирает связку ключей JWT из PEM файлов (Ed25519 или RSA).
+// Без JWT_SIGNING_KEY_FILE токены, как и раньше, подписываются HS256 ключом JWT_SECRET.
+func initJWTKeys(jwtSecret string) *jwtkeys.KeyRing {
+	hmacKey := jwtkeys.NewHMACKey([]byte(jwtSecret))
+	signingFile := os.Getenv("JWT_SIGNING_KEY_FILE")
+	if signingFile == "" {
+		logger.Warn("JWT_SIGNING_KEY_FILE not set, signing tokens with JWT_SECRET (HS256); JWKS will be empty")
+		keys, _ := jwtkeys.NewKeyRing(hmacKey)
+		return keys
+	}
+
+	signing, err := jwtkeys.LoadFile(signingFile)
+	if err != nil {
+		logger.Fatal("Failed to load JWT signing key", zap.Error(err))
+	}
+	var verify []*jwtkeys.Key
+	for _, path := range strings.Split(os.Getenv("JWT_VERIFY_KEY_FILES"), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		key, err := jwtkeys.LoadFile(path)
+		if err != nil {
+			logger.Fatal("Failed to load JWT verification key", zap.Error(err))
+		}
+		verify = append(verify, key)
+	}
+	// Переход с HS256: старые токены принимаются, пока не истекут
+	if getEnv("JWT_ACCEPT_HMAC", "false") == "true" {
+		verify = append(verify, hmacKey)
+	}
+
+	keys, err := jwtkeys.NewKeyRing(signing, verify...)
+	if err != nil {
+		logger.Fatal("Invalid JWT key ring", zap.Error(err))
+	}
+	logger.Info("JWT key ring loaded",
+		zap.String("signing_kid", signing.ID),
+		zap.String("alg", signing.Method.Alg()),
+		zap.Int("verification_keys", len(verify)),
+	)
+	return keys
+}
+
 // initRedis инициализирует подключение к Redis
 func initRedis() *redis.Client {
 	redisHost := getEnv("REDIS_HOST", "localhost")
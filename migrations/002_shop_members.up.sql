@@ -0,0 +1,20 @@
+-- ============================================
+-- SHOP MEMBERS (staff accounts with per-shop roles)
+-- The owner is seller_profiles.user_id and is not stored here.
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS shop_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL REFERENCES shops(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- NULL until the invite is accepted
+    phone VARCHAR(20) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('manager', 'operator', 'viewer')),
+    status VARCHAR(20) NOT NULL DEFAULT 'invited' CHECK (status IN ('invited', 'active')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (shop_id, phone)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shop_members_user_id ON shop_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_shop_members_phone ON shop_members(phone);
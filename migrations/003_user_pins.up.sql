@@ -0,0 +1,13 @@
+-- ============================================
+-- USER PINS (quick unlock PIN, bcrypt hash with lockout)
+-- users.has_pin is kept in sync for clients
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS user_pins (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    pin_hash VARCHAR(255) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_at TIMESTAMP, -- NOT NULL while the PIN is locked until reset via SMS
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
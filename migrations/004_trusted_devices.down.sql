@@ -0,0 +1,2 @@
+ALTER TABLE user_sessions DROP COLUMN IF EXISTS trusted_until;
+ALTER TABLE user_sessions DROP COLUMN IF EXISTS trust_token_hash;
@@ -0,0 +1,7 @@
+-- ============================================
+-- TRUSTED DEVICES (quick login with device token + PIN)
+-- user_sessions.is_trusted is set after SMS verification
+-- ============================================
+
+ALTER TABLE user_sessions ADD COLUMN IF NOT EXISTS trust_token_hash VARCHAR(255);
+ALTER TABLE user_sessions ADD COLUMN IF NOT EXISTS trusted_until TIMESTAMP; -- extended on every PIN login, trust lapses after inactivity
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS phone_change_requests CASCADE;
+DROP TABLE IF EXISTS audit_logs CASCADE;
@@ -0,0 +1,28 @@
+-- ============================================
+-- AUDIT LOG (security-relevant account changes)
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,  -- affected account
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- who made the change
+    action VARCHAR(50) NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_id ON audit_logs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action);
+
+-- ============================================
+-- PENDING PHONE CHANGES (one per user, verified by SMS codes)
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS phone_change_requests (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    new_phone VARCHAR(20) NOT NULL,
+    confirm_old_phone BOOLEAN NOT NULL DEFAULT false, -- old number must confirm too
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
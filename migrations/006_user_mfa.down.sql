@@ -0,0 +1,2 @@
+ALTER TABLE user_sessions DROP COLUMN IF EXISTS mfa_verified;
+DROP TABLE IF EXISTS user_mfa;
@@ -0,0 +1,17 @@
+-- ============================================
+-- USER MFA (TOTP two-factor authentication)
+-- Mandatory for admins and moderators before the admin panel opens
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret_enc TEXT NOT NULL, -- AES-GCM sealed base32 TOTP secret
+    recovery_codes TEXT[] NOT NULL DEFAULT '{}', -- sha256 of unused recovery codes
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- last accepted TOTP time step, blocks code replay
+    enabled_at TIMESTAMP, -- NULL until the first code is confirmed
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Set when the session passed the second factor; carried into refreshed access tokens
+ALTER TABLE user_sessions ADD COLUMN IF NOT EXISTS mfa_verified BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,20 @@
+-- ============================================
+-- SHOP API KEYS (server-to-server integrations, e.g. 1C / warehouse sync)
+-- Sent in the x-api-key header; only the sha256 of the key is stored
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS shop_api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL REFERENCES shops(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- calls act as this user, limited to the key's scopes
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL, -- first characters of the key, for telling keys apart
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}', -- products:read, products:write, orders:read, orders:write
+    last_used_at TIMESTAMP,
+    expires_at TIMESTAMP, -- NULL means no expiry
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_shop_api_keys_shop ON shop_api_keys(shop_id) WHERE revoked_at IS NULL;
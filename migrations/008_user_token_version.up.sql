@@ -0,0 +1,7 @@
+-- ============================================
+-- USER TOKEN VERSION
+-- Access tokens carry the version they were issued with ("ver" claim);
+-- bumping it revokes every token of the user at once
+-- ============================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS token_version BIGINT NOT NULL DEFAULT 0;
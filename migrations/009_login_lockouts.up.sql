@@ -0,0 +1,16 @@
+-- ============================================
+-- LOGIN LOCKOUTS
+-- Failed password and PIN attempts per account, with exponential lockout
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    failed_attempts INT NOT NULL DEFAULT 0, -- consecutive failures, forgotten after a quiet day
+    last_method VARCHAR(20) NOT NULL, -- password | pin
+    last_failed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP, -- login is refused until then, even with the right password
+    alert_sent_at TIMESTAMP, -- last SMS warning to the owner
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_lockouts_locked_until ON login_lockouts(locked_until);
@@ -0,0 +1,2 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS variant_value;
+ALTER TABLE order_items DROP COLUMN IF EXISTS variant_name;
@@ -0,0 +1,7 @@
+-- ============================================
+-- ORDER ITEM VARIANTS
+-- Snapshot of the product variant the item was priced with
+-- ============================================
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_value VARCHAR(255) NOT NULL DEFAULT '';
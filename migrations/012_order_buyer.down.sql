@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_guest_phone;
+DROP INDEX IF EXISTS idx_orders_user;
+ALTER TABLE orders DROP COLUMN IF EXISTS user_id;
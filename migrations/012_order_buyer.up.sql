@@ -0,0 +1,10 @@
+-- ============================================
+-- ORDER BUYER
+-- Links orders to the buyer's account. Set on checkout by a logged-in user;
+-- guest orders are linked later to the account with the same verified phone
+-- ============================================
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_orders_user ON orders(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_guest_phone ON orders(client_phone) WHERE user_id IS NULL;
@@ -0,0 +1,9 @@
+-- ============================================
+-- ORDER CHECKOUTS
+-- A checkout of a mixed basket creates one order per shop in a single transaction;
+-- the orders share checkout_id. Orders from CreateOrder have none
+-- ============================================
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS checkout_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_orders_checkout ON orders(checkout_id) WHERE checkout_id IS NOT NULL;
@@ -0,0 +1,30 @@
+-- ============================================
+-- CARTS
+-- One cart per user, or per device for guests (x-device-id header).
+-- A device cart is merged into the user's cart on login and then deleted.
+-- added_price is the unit price the customer last saw, to flag price changes
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS carts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    device_id VARCHAR(255) UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((user_id IS NULL) <> (device_id IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cart_id UUID NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_name VARCHAR(255) NOT NULL DEFAULT '',
+    variant_value VARCHAR(255) NOT NULL DEFAULT '',
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    added_price NUMERIC(15, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (cart_id, product_id, variant_name, variant_value)
+);
+
+CREATE INDEX IF NOT EXISTS idx_carts_guest_updated ON carts(updated_at) WHERE device_id IS NOT NULL;
@@ -0,0 +1,37 @@
+-- ============================================
+-- INVENTORY
+-- Stock per product, or per variant (variant_name/variant_value set).
+-- A product without rows here is not tracked and never runs out.
+-- reserved is held by open orders: reserved on CreateOrder, released on
+-- cancellation and taken from on_hand on completion.
+-- Every change is written to stock_movements in the same transaction
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS product_stock (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_name VARCHAR(255) NOT NULL DEFAULT '',
+    variant_value VARCHAR(255) NOT NULL DEFAULT '',
+    on_hand INTEGER NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    reserved INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0 AND reserved <= on_hand),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, variant_name, variant_value)
+);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    stock_id UUID NOT NULL REFERENCES product_stock(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    on_hand_change INTEGER NOT NULL DEFAULT 0,
+    reserved_change INTEGER NOT NULL DEFAULT 0,
+    on_hand_after INTEGER NOT NULL,
+    reserved_after INTEGER NOT NULL,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_stock ON stock_movements(stock_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_order ON stock_movements(order_id) WHERE order_id IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE shop_api_keys DROP COLUMN IF EXISTS creator_token_version;
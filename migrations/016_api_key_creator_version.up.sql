@@ -0,0 +1,11 @@
+-- ============================================
+-- API KEY CREATOR TOKEN VERSION
+-- Keys remember the creator's token version; deactivating the creator or
+-- bumping their version (logout everywhere, role change) revokes the key
+-- ============================================
+
+ALTER TABLE shop_api_keys ADD COLUMN IF NOT EXISTS creator_token_version BIGINT NOT NULL DEFAULT 0;
+
+UPDATE shop_api_keys k SET creator_token_version = u.token_version
+FROM users u
+WHERE u.id = k.created_by;
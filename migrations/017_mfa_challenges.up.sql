@@ -0,0 +1,16 @@
+-- ============================================
+-- MFA LOGIN CHALLENGES
+-- Issued after the first login factor; VerifyMfaLogin accepts a few codes
+-- per challenge and drops it once used. Wrong codes also count towards
+-- login_lockouts (last_method = 'mfa')
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id UUID PRIMARY KEY, -- the "jti" claim of the challenge token
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_challenges_user ON mfa_challenges(user_id);
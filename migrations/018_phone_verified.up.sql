@@ -0,0 +1,9 @@
+-- ============================================
+-- VERIFIED PHONES
+-- Set when the user proves the phone with an SMS code (registration, code
+-- login, password or PIN reset, device trust, phone change). Guest orders
+-- are matched to accounts by verified phones only. Accounts registered
+-- before SMS verification stay unverified until their next SMS code
+-- ============================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMP;
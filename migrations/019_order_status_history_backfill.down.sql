@@ -0,0 +1 @@
+DELETE FROM order_status_history WHERE old_status = '' AND note = 'backfill';
@@ -0,0 +1,12 @@
+-- ============================================
+-- ORDER STATUS HISTORY BACKFILL
+-- Orders placed before 011 get their creation row (old_status empty,
+-- new_status 'new') at the order's creation time, so their timeline is
+-- not empty. Later changes were not recorded. The rows carry
+-- note = 'backfill' so they can be told apart and removed on rollback
+-- ============================================
+
+INSERT INTO order_status_history (order_id, old_status, new_status, actor_id, actor_role, note, created_at)
+SELECT o.id, '', 'new', o.user_id, 'buyer', 'backfill', o.created_at
+FROM orders o
+WHERE NOT EXISTS (SELECT 1 FROM order_status_history h WHERE h.order_id = o.id);
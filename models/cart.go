@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// CartItem - savatdagi mahsulot.
+// Narx va mavjudlik har safar mahsulotdan qayta hisoblanadi
+type CartItem struct {
+	ID           string    `json:"id"`
+	ProductID    string    `json:"product_id"`
+	ShopID       string    `json:"shop_id,omitempty"`
+	ProductName  string    `json:"product_name,omitempty"`
+	ProductImage string    `json:"product_image,omitempty"`
+	VariantName  string    `json:"variant_name,omitempty"`
+	VariantValue string    `json:"variant_value,omitempty"`
+	Quantity     int       `json:"quantity"`
+	Price        float64   `json:"price"`       // Hozirgi narx
+	AddedPrice   float64   `json:"added_price"` // Mijoz oxirgi ko'rgan narx
+	Available    bool      `json:"available"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// PriceChanged - mijoz ko'rgan narx o'zgarganmi
+func (i *CartItem) PriceChanged() bool {
+	return i.Available && i.Price != i.AddedPrice
+}
+
+// LineTotal - qator summasi, mavjud bo'lmagan mahsulot uchun 0
+func (i *CartItem) LineTotal() float64 {
+	if !i.Available {
+		return 0
+	}
+	return i.Price * float64(i.Quantity)
+}
+
+// Cart - foydalanuvchi yoki qurilma savati
+type Cart struct {
+	ID    string     `json:"id"`
+	Items []CartItem `json:"items"`
+}
@@ -13,6 +13,45 @@ const (
 	OrderStatusCancelled = "cancelled"
 )
 
+// Buyurtma statusini o'zgartiruvchi tomonlar
+const (
+	OrderActorBuyer  = "buyer"
+	OrderActorSeller = "seller"
+	OrderActorAdmin  = "admin"
+)
+
+// OrderTransitions - ruxsat etilgan status o'tishlari.
+// Yakunlangan va bekor qilingan buyurtmalar boshqa o'zgarmaydi.
+var OrderTransitions = map[string][]string{
+	OrderStatusNew:       {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusShipping, OrderStatusCancelled},
+	OrderStatusShipping:  {OrderStatusCompleted, OrderStatusCancelled},
+	OrderStatusCompleted: {},
+	OrderStatusCancelled: {},
+}
+
+// CanTransitionOrder - from statusidan to statusiga o'tish mumkinmi
+func CanTransitionOrder(from, to string) bool {
+	for _, next := range OrderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CanActorTransitionOrder - tomon uchun o'tish ruxsatini tekshirish.
+// Xaridor faqat yangi buyurtmani bekor qila oladi.
+func CanActorTransitionOrder(actor, from, to string) bool {
+	if !CanTransitionOrder(from, to) {
+		return false
+	}
+	if actor == OrderActorBuyer {
+		return from == OrderStatusNew && to == OrderStatusCancelled
+	}
+	return true
+}
+
 // OrderItem - buyurtma mahsuloti
 // @Description Buyurtmadagi bitta mahsulot
 type OrderItem struct {
@@ -23,28 +62,50 @@ type OrderItem struct {
 	ProductImage string    `json:"product_image,omitempty"`
 	Quantity     int       `json:"quantity"`
 	Price        float64   `json:"price"`
+	VariantName  string    `json:"variant_name,omitempty"`
+	VariantValue string    `json:"variant_value,omitempty"`
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 }
 
 // Order - buyurtma modeli
 // @Description Buyurtma ma'lumotlari
 type Order struct {
-	ID            string      `json:"id"`
-	ShopID        string      `json:"shop_id"`
-	ShopName      string      `json:"shop_name,omitempty"` // Admin panel uchun
-	ClientName    string      `json:"client_name"`
-	ClientPhone   string      `json:"client_phone"`
-	ClientAddress string      `json:"client_address,omitempty"`
-	TotalAmount   float64     `json:"total_amount"`
-	DeliveryPrice float64     `json:"delivery_price,omitempty"`
-	Status        string      `json:"status"`
-	ClientNote    string      `json:"client_note,omitempty"`
-	SellerNote    string      `json:"seller_note,omitempty"`
-	Items         []OrderItem `json:"items,omitempty"`
-	ItemsCount    int         `json:"items_count,omitempty"`
-	CreatedAt     time.Time   `json:"created_at"`
-	UpdatedAt     time.Time   `json:"updated_at,omitempty"`
-	CompletedAt   *time.Time  `json:"completed_at,omitempty"`
+	ID                 string              `json:"id"`
+	ShopID             string              `json:"shop_id"`
+	ShopName           string              `json:"shop_name,omitempty"`   // Admin panel uchun
+	UserID             *string             `json:"user_id,omitempty"`     // Xaridor hisobi, mehmon buyurtmasida bo'sh
+	CheckoutID         *string             `json:"checkout_id,omitempty"` // Bir nechta do'kondan birga rasmiylashtirilgan buyurtmalar uchun umumiy
+	ClientName         string              `json:"client_name"`
+	ClientPhone        string              `json:"client_phone"`
+	ClientAddress      string              `json:"client_address,omitempty"`
+	TotalAmount        float64             `json:"total_amount"`
+	DeliveryPrice      float64             `json:"delivery_price,omitempty"`
+	Status             string              `json:"status"`
+	ClientNote         string              `json:"client_note,omitempty"`
+	SellerNote         string              `json:"seller_note,omitempty"`
+	Items              []OrderItem         `json:"items,omitempty"`
+	ItemsCount         int                 `json:"items_count,omitempty"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at,omitempty"`
+	CompletedAt        *time.Time          `json:"completed_at,omitempty"`
+	ConfirmedAt        *time.Time          `json:"confirmed_at,omitempty"`
+	CancellationReason string              `json:"cancellation_reason,omitempty"` // cancellation_reasons dan
+	RejectionNote      string              `json:"rejection_note,omitempty"`
+	History            []OrderStatusChange `json:"history,omitempty"`
+}
+
+// OrderStatusChange - buyurtma tarixidagi bitta o'zgarish.
+// Birinchi yozuv buyurtma yaratilishi (OldStatus bo'sh)
+type OrderStatusChange struct {
+	ID        string    `json:"id"`
+	OrderID   string    `json:"order_id"`
+	OldStatus string    `json:"old_status"`
+	NewStatus string    `json:"new_status"`
+	ActorID   *string   `json:"actor_id,omitempty"` // Mehmon buyurtmasida bo'sh
+	ActorRole string    `json:"actor_role"`         // OrderActor* qiymatlari
+	Note      string    `json:"note,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // OrderResponse - bitta buyurtma javobi
@@ -331,6 +331,57 @@ func (p *Product) HasDiscount() bool {
 	return p.DiscountPrice != nil && *p.DiscountPrice > 0 && *p.DiscountPrice < p.Price
 }
 
+// GetName - nomni kerakli tilda olish, bo'lmasa 'uz'
+func (p *Product) GetName(lang string) string {
+	if name, ok := p.Name[lang]; ok && name != "" {
+		return name
+	}
+	if name, ok := p.Name["uz"]; ok && name != "" {
+		return name
+	}
+	for _, name := range p.Name {
+		return name
+	}
+	return ""
+}
+
+// FindVariant - variantni nomi va qiymati bo'yicha topish
+func (p *Product) FindVariant(name, value string) (map[string]interface{}, bool) {
+	for _, v := range p.Variants {
+		vName, _ := v["name"].(string)
+		vValue, _ := v["value"].(string)
+		if vName == name && vValue == value {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// UnitPrice - bitta dona narxi: chegirma narxi (bo'lsa) va variant qo'shimchasi
+func (p *Product) UnitPrice(variant map[string]interface{}) float64 {
+	price := p.Price
+	if p.HasDiscount() {
+		price = *p.DiscountPrice
+	}
+	if modifier, ok := variant["price_modifier"].(float64); ok {
+		price += modifier
+	}
+	return price
+}
+
+// ImageFor - buyurtma uchun rasm: variant rasmi, bo'lmasa mahsulotning birinchi rasmi
+func (p *Product) ImageFor(variant map[string]interface{}) string {
+	if images, ok := variant["images"].([]interface{}); ok && len(images) > 0 {
+		if image, ok := images[0].(string); ok && image != "" {
+			return image
+		}
+	}
+	if len(p.Images) > 0 {
+		return p.Images[0]
+	}
+	return ""
+}
+
 // ProductResponse - bitta mahsulot javobi
 type ProductResponse struct {
 	Success bool     `json:"success"`
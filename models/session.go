@@ -4,20 +4,21 @@ import "time"
 
 // UserSession - foydalanuvchi sessiyasi modeli (user_sessions jadvali bilan bir xil)
 type UserSession struct {
-	ID         string     `json:"id"`
-	UserID     string     `json:"user_id"`
-	DeviceName string     `json:"device_name"`
-	DeviceID   string     `json:"device_id"`
-	IPAddress  string     `json:"ip_address,omitempty"`
-	AppType    string     `json:"app_type"`              // client, seller, admin
-	DeviceOS   string     `json:"device_os,omitempty"`   // iOS, Android
-	OSVersion  string     `json:"os_version,omitempty"`  // 17.2, 14.0
-	AppVersion string     `json:"app_version,omitempty"` // 1.0.0, 1.0.0+12
-	IsTrusted  bool       `json:"is_trusted"`            // Qurilma ishonchlimi (2FA uchun)
-	ExpiresAt  *time.Time `json:"expires_at,omitempty"`  // Sessiya tugash vaqti
-	LastActive time.Time  `json:"last_active"`
-	IsCurrent  bool       `json:"is_current"`
-	CreatedAt  time.Time  `json:"created_at"`
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	DeviceName   string     `json:"device_name"`
+	DeviceID     string     `json:"device_id"`
+	IPAddress    string     `json:"ip_address,omitempty"`
+	AppType      string     `json:"app_type"`                // client, seller, admin
+	DeviceOS     string     `json:"device_os,omitempty"`     // iOS, Android
+	OSVersion    string     `json:"os_version,omitempty"`    // 17.2, 14.0
+	AppVersion   string     `json:"app_version,omitempty"`   // 1.0.0, 1.0.0+12
+	IsTrusted    bool       `json:"is_trusted"`              // Qurilma ishonchlimi (PIN orqali tez kirish)
+	TrustedUntil *time.Time `json:"trusted_until,omitempty"` // Ishonch muddati (har PIN kirishda uzaytiriladi)
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`    // Sessiya tugash vaqti
+	LastActive   time.Time  `json:"last_active"`
+	IsCurrent    bool       `json:"is_current"`
+	CreatedAt    time.Time  `json:"created_at"`
 }
 
 // SessionsResponse - sessiyalar ro'yxati javobi
@@ -43,3 +44,14 @@ type SetPinResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
+
+// LoginLockout - noto'g'ri parol/PIN urinishlari va vaqtinchalik bloklash (login_lockouts jadvali)
+type LoginLockout struct {
+	UserID         string     `json:"user_id"`
+	Phone          string     `json:"phone"`
+	FullName       string     `json:"full_name"`
+	FailedAttempts int        `json:"failed_attempts"`
+	LastMethod     string     `json:"last_method"` // password, pin
+	LastFailedAt   time.Time  `json:"last_failed_at"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"` // Shu vaqtgacha kirish taqiqlangan
+}
@@ -204,3 +204,76 @@ type SellerWithShops struct {
 	Shops         []Shop         `json:"shops"`
 	ShopsCount    int            `json:"shops_count"`
 }
+
+// Do'kondagi rol (X-Shop-ID tekshirilganda AuthContext ga yoziladi)
+const (
+	ShopRoleOwner    = "owner"    // seller_profiles.user_id - do'kon egasi
+	ShopRoleManager  = "manager"  // mahsulotlar, buyurtmalar va xodimlar
+	ShopRoleOperator = "operator" // buyurtmalar bilan ishlash
+	ShopRoleViewer   = "viewer"   // faqat ko'rish
+)
+
+// Xodim taklifining holati
+const (
+	ShopMemberInvited = "invited" // SMS yuborilgan, hali qabul qilinmagan
+	ShopMemberActive  = "active"
+)
+
+var shopRoleRank = map[string]int{
+	ShopRoleViewer:   1,
+	ShopRoleOperator: 2,
+	ShopRoleManager:  3,
+	ShopRoleOwner:    4,
+}
+
+// IsValidShopRole - shop_members jadvalida saqlanadigan rol (owner emas)
+func IsValidShopRole(role string) bool {
+	return role == ShopRoleManager || role == ShopRoleOperator || role == ShopRoleViewer
+}
+
+// ShopRoleAtLeast - role kamida min darajasidami (owner > manager > operator > viewer)
+func ShopRoleAtLeast(role, min string) bool {
+	return shopRoleRank[role] > 0 && shopRoleRank[role] >= shopRoleRank[min]
+}
+
+// ShopMember - do'kon xodimi (shop_members jadvali)
+type ShopMember struct {
+	ID        string    `json:"id"`
+	ShopID    string    `json:"shop_id"`
+	UserID    string    `json:"user_id,omitempty"` // Taklif qabul qilinmaguncha bo'sh
+	Phone     string    `json:"phone"`
+	FullName  string    `json:"full_name,omitempty"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// API kalit ruxsatlari (1C/ombor tizimlari bilan integratsiya)
+const (
+	APIScopeProductsRead  = "products:read"
+	APIScopeProductsWrite = "products:write"
+	APIScopeOrdersRead    = "orders:read"
+	APIScopeOrdersWrite   = "orders:write"
+)
+
+// IsValidAPIScope - API kalitga berilishi mumkin bo'lgan ruxsat
+func IsValidAPIScope(scope string) bool {
+	switch scope {
+	case APIScopeProductsRead, APIScopeProductsWrite, APIScopeOrdersRead, APIScopeOrdersWrite:
+		return true
+	}
+	return false
+}
+
+// ShopAPIKey - do'kon API kaliti (shop_api_keys jadvali, kalitning o'zi saqlanmaydi)
+type ShopAPIKey struct {
+	ID         string     `json:"id"`
+	ShopID     string     `json:"shop_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // Kalitning boshi, kalitlarni farqlash uchun
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // Bo'sh bo'lsa muddatsiz
+	CreatedAt  time.Time  `json:"created_at"`
+}
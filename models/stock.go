@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// Ombor harakati turlari
+const (
+	StockMovementAdjustment = "adjustment" // Sotuvchi tuzatishi yoki inventarizatsiya
+	StockMovementReserve    = "reserve"    // Buyurtma uchun band qilish
+	StockMovementRelease    = "release"    // Bekor qilingan buyurtma bandini qaytarish
+	StockMovementCommit     = "commit"     // Yakunlangan buyurtma ombordan chiqadi
+)
+
+// StockLevel - mahsulot yoki uning variantining qoldig'i.
+// Variant maydonlari bo'sh bo'lsa, qoldiq butun mahsulot uchun
+type StockLevel struct {
+	ID           string    `json:"id"`
+	ProductID    string    `json:"product_id"`
+	VariantName  string    `json:"variant_name,omitempty"`
+	VariantValue string    `json:"variant_value,omitempty"`
+	OnHand       int       `json:"on_hand"`  // Omborda
+	Reserved     int       `json:"reserved"` // Ochiq buyurtmalarda band
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Available - sotish mumkin bo'lgan miqdor
+func (s *StockLevel) Available() int {
+	return s.OnHand - s.Reserved
+}
+
+// StockMovement - qoldiq o'zgarishi jurnali yozuvi
+type StockMovement struct {
+	ID             string    `json:"id"`
+	ProductID      string    `json:"product_id"`
+	VariantName    string    `json:"variant_name,omitempty"`
+	VariantValue   string    `json:"variant_value,omitempty"`
+	Kind           string    `json:"kind"`
+	OnHandChange   int       `json:"on_hand_change"`
+	ReservedChange int       `json:"reserved_change"`
+	OnHandAfter    int       `json:"on_hand_after"`
+	ReservedAfter  int       `json:"reserved_after"`
+	OrderID        *string   `json:"order_id,omitempty"`
+	ActorID        *string   `json:"actor_id,omitempty"`
+	Note           string    `json:"note,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
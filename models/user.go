@@ -17,6 +17,14 @@ type User struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// Foydalanuvchi rollari
+const (
+	RoleCustomer  = "customer"
+	RoleSeller    = "seller"
+	RoleModerator = "moderator" // Admin panel, faqat o'qish huquqi
+	RoleAdmin     = "admin"
+)
+
 // SendOTPRequest - OTP yuborish uchun so'rov
 type SendOTPRequest struct {
 	Phone string `json:"phone"`
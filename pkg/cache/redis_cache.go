@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -123,6 +124,7 @@ func (c *RedisCache) Clear() error {
 
 // MemoryCache простой in-memory cache (для development)
 type MemoryCache struct {
+	mu   sync.RWMutex
 	data map[string]cacheEntry
 }
 
@@ -144,13 +146,15 @@ func NewMemoryCache() *MemoryCache {
 }
 
 func (c *MemoryCache) Get(key string, dest interface{}) error {
+	c.mu.RLock()
 	entry, exists := c.data[key]
+	c.mu.RUnlock()
 	if !exists {
 		return fmt.Errorf("cache miss")
 	}
 
 	if time.Now().After(entry.expiresAt) {
-		delete(c.data, key)
+		c.Delete(key)
 		return fmt.Errorf("cache expired")
 	}
 
@@ -163,21 +167,27 @@ func (c *MemoryCache) Set(key string, value interface{}, ttl time.Duration) erro
 		return err
 	}
 
+	c.mu.Lock()
 	c.data[key] = cacheEntry{
 		value:     data,
 		expiresAt: time.Now().Add(ttl),
 	}
+	c.mu.Unlock()
 
 	return nil
 }
 
 func (c *MemoryCache) Delete(key string) error {
+	c.mu.Lock()
 	delete(c.data, key)
+	c.mu.Unlock()
 	return nil
 }
 
 func (c *MemoryCache) Clear() error {
+	c.mu.Lock()
 	c.data = make(map[string]cacheEntry)
+	c.mu.Unlock()
 	return nil
 }
 
@@ -187,10 +197,12 @@ func (c *MemoryCache) cleanupExpired() {
 
 	for range ticker.C {
 		now := time.Now()
+		c.mu.Lock()
 		for key, entry := range c.data {
 			if now.After(entry.expiresAt) {
 				delete(c.data, key)
 			}
 		}
+		c.mu.Unlock()
 	}
 }
@@ -0,0 +1,75 @@
+package email
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_AllTemplatesAllLanguages(t *testing.T) {
+	order := OrderData{
+		OrderID:     "ord-1",
+		ShopName:    "Mebel House",
+		ClientName:  "Ali",
+		ClientPhone: "+998901234567",
+		Items:       []OrderItemData{{Name: "Divan", Quantity: 2, Price: 1250000}},
+		TotalAmount: 2500000,
+	}
+	data := map[string]any{
+		TemplateVerifyEmail:    VerifyEmailData{Code: "12345", TTLMinutes: 5},
+		TemplateOrderReceipt:   order,
+		TemplateSellerNewOrder: order,
+	}
+
+	for name, d := range data {
+		for _, lang := range []string{"uz", "ru", "en"} {
+			msg, err := Render(name, lang, d)
+			require.NoError(t, err, "%s/%s", lang, name)
+			assert.NotEmpty(t, msg.Subject)
+			assert.NotContains(t, msg.Text, "<no value>")
+			assert.Contains(t, msg.HTML, "<p")
+		}
+	}
+
+	msg, err := Render(TemplateOrderReceipt, "ru", order)
+	require.NoError(t, err)
+	assert.Contains(t, msg.Text, "Divan × 2 — 1 250 000 сум")
+	assert.Contains(t, msg.Text, "Итого: 2 500 000 сум")
+}
+
+func TestRender_UnknownTemplate(t *testing.T) {
+	_, err := Render("missing", "uz", nil)
+	require.Error(t, err)
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	assert.Equal(t, "ru", NormalizeLanguage("ru-RU,ru;q=0.9,en;q=0.8"))
+	assert.Equal(t, "en", NormalizeLanguage("EN"))
+	assert.Equal(t, "uz", NormalizeLanguage("uz-Latn"))
+	// Неподдерживаемый язык - язык по умолчанию
+	assert.Equal(t, DefaultLanguage, NormalizeLanguage("de"))
+	assert.Equal(t, DefaultLanguage, NormalizeLanguage(""))
+}
+
+func TestFileSender_WritesEML(t *testing.T) {
+	dir := t.TempDir()
+	sender := NewFileSender(dir, "Mebellar <no-reply@mebellar.uz>")
+
+	err := sender.Send(context.Background(), Message{To: "user@example.com", Subject: "Подтверждение", Text: "Код: 12345"})
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	body, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "To: user@example.com")
+	assert.Contains(t, string(body), "Subject: =?utf-8?q?")
+	assert.True(t, strings.Contains(string(body), "12345"))
+}
@@ -0,0 +1,56 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileSender для разработки: сохраняет письма как .eml файлы в dir
+// или, если dir пустой, только пишет их в лог.
+type FileSender struct {
+	dir  string
+	from string
+}
+
+// NewFileSender создает отправитель, который никуда не отправляет письма
+func NewFileSender(dir, from string) *FileSender {
+	return &FileSender{dir: dir, from: from}
+}
+
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	if s.dir == "" {
+		log.Printf("📧 [EMAIL] To: %s | Subject: %s\n%s", msg.To, msg.Subject, msg.Text)
+		return nil
+	}
+
+	body, err := buildMIME(s.from, msg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405.000000000"), sanitizeFileName(msg.To))
+	path := filepath.Join(s.dir, name)
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return err
+	}
+	log.Printf("📧 [EMAIL] To: %s | Subject: %s | saved to %s", msg.To, msg.Subject, path)
+	return nil
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '@':
+			return r
+		}
+		return '_'
+	}, s)
+}
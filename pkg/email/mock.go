@@ -0,0 +1,36 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MockSender для тестирования
+type MockSender struct {
+	mu         sync.Mutex
+	Sent       []Message
+	ShouldFail bool
+}
+
+func (m *MockSender) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ShouldFail {
+		return fmt.Errorf("mock email error")
+	}
+	m.Sent = append(m.Sent, msg)
+	return nil
+}
+
+// Last возвращает последнее отправленное письмо
+func (m *MockSender) Last() (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.Sent) == 0 {
+		return Message{}, false
+	}
+	return m.Sent[len(m.Sent)-1], true
+}
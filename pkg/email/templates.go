@@ -0,0 +1,135 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Шаблоны писем
+const (
+	TemplateVerifyEmail    = "verify_email"     // VerifyEmailData
+	TemplateOrderReceipt   = "order_receipt"    // OrderData
+	TemplateSellerNewOrder = "seller_new_order" // OrderData
+)
+
+// DefaultLanguage используется, если язык не поддерживается
+const DefaultLanguage = "uz"
+
+// VerifyEmailData данные для TemplateVerifyEmail
+type VerifyEmailData struct {
+	Code       string
+	TTLMinutes int
+}
+
+// OrderData данные для TemplateOrderReceipt и TemplateSellerNewOrder
+type OrderData struct {
+	OrderID       string
+	ShopName      string
+	ClientName    string
+	ClientPhone   string
+	ClientAddress string
+	Items         []OrderItemData
+	DeliveryPrice float64
+	TotalAmount   float64
+	CreatedAt     string
+}
+
+// OrderItemData позиция заказа
+type OrderItemData struct {
+	Name     string
+	Quantity int
+	Price    float64
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	// templates["ru/verify_email"]; каждый шаблон определяет "subject" и "text"
+	templates = map[string]*template.Template{}
+	layout    = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/layout.html"))
+)
+
+func init() {
+	funcs := template.FuncMap{"money": formatMoney}
+	err := fs.WalkDir(templateFS, "templates", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".tmpl" {
+			return err
+		}
+		lang := path.Base(path.Dir(p))
+		name := strings.TrimSuffix(path.Base(p), ".tmpl")
+		tmpl, err := template.New(name).Funcs(funcs).ParseFS(templateFS, p)
+		if err != nil {
+			return err
+		}
+		templates[lang+"/"+name] = tmpl
+		return nil
+	})
+	if err != nil {
+		panic(fmt.Sprintf("email templates: %v", err))
+	}
+}
+
+// NormalizeLanguage приводит язык (в т.ч. заголовок Accept-Language "ru-RU,ru;q=0.9") к uz, ru или en
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	lang, _, _ = strings.Cut(lang, ",")
+	lang, _, _ = strings.Cut(lang, ";")
+	lang, _, _ = strings.Cut(lang, "-")
+	switch lang {
+	case "uz", "ru", "en":
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// Render собирает письмо из шаблона на нужном языке. Получатель (To) заполняет вызывающий.
+func Render(name, lang string, data any) (Message, error) {
+	tmpl, ok := templates[NormalizeLanguage(lang)+"/"+name]
+	if !ok {
+		return Message{}, fmt.Errorf("email template %q not found", name)
+	}
+
+	var subject, text bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&text, "text", data); err != nil {
+		return Message{}, err
+	}
+	msg := Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}
+
+	var html bytes.Buffer
+	err := layout.Execute(&html, struct {
+		Title      string
+		Paragraphs []string
+	}{msg.Subject, strings.Split(strings.TrimSpace(text.String()), "\n\n")})
+	if err != nil {
+		return Message{}, err
+	}
+	msg.HTML = html.String()
+	return msg, nil
+}
+
+// formatMoney форматирует сумму с пробелами между разрядами: 1250000 -> "1 250 000"
+func formatMoney(amount float64) string {
+	digits := strconv.FormatInt(int64(amount+0.5), 10)
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
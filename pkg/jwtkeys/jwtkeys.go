@@ -0,0 +1,283 @@
+// Package jwtkeys хранит ключи подписи JWT. Каждый ключ имеет идентификатор (kid),
+// который записывается в заголовок токена: новые токены подписываются одним ключом,
+// а проверяются всеми ключами связки. Это позволяет менять ключ без выхода пользователей
+// и проверять токены в других сервисах по публичным ключам (JWKS).
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// MinRSABits минимальный размер RSA ключа
+const MinRSABits = 2048
+
+var (
+	// ErrUnknownKey токен подписан ключом, которого нет в связке
+	ErrUnknownKey = errors.New("unknown signing key")
+	// ErrNoSigningKey ключ не содержит приватной части
+	ErrNoSigningKey = errors.New("key cannot sign: private key required")
+)
+
+// Key ключ подписи или проверки JWT
+type Key struct {
+	// ID значение заголовка kid
+	ID string
+	// Method алгоритм подписи (EdDSA, RS256 или HS256)
+	Method jwt.SigningMethod
+
+	signer   interface{} // приватный ключ или HMAC секрет; nil у ключей только для проверки
+	verifier interface{} // публичный ключ или HMAC секрет
+}
+
+// NewEd25519Key создает ключ EdDSA; kid вычисляется по публичному ключу (RFC 7638)
+func NewEd25519Key(private ed25519.PrivateKey) *Key {
+	return newPublicKey(private.Public()).withSigner(private)
+}
+
+// NewRSAKey создает ключ RS256; kid вычисляется по публичному ключу (RFC 7638)
+func NewRSAKey(private *rsa.PrivateKey) (*Key, error) {
+	if private.N.BitLen() < MinRSABits {
+		return nil, fmt.Errorf("RSA key must be at least %d bits", MinRSABits)
+	}
+	return newPublicKey(&private.PublicKey).withSigner(private), nil
+}
+
+// NewHMACKey создает симметричный ключ HS256. Такой ключ не публикуется в JWKS:
+// проверить токен может только владелец секрета.
+func NewHMACKey(secret []byte) *Key {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("jwt-kid"))
+	return &Key{
+		ID:       "hs-" + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:16],
+		Method:   jwt.SigningMethodHS256,
+		signer:   secret,
+		verifier: secret,
+	}
+}
+
+func newPublicKey(public crypto.PublicKey) *Key {
+	switch pub := public.(type) {
+	case ed25519.PublicKey:
+		return &Key{ID: thumbprint(pub), Method: jwt.SigningMethodEdDSA, verifier: pub}
+	case *rsa.PublicKey:
+		return &Key{ID: thumbprint(pub), Method: jwt.SigningMethodRS256, verifier: pub}
+	}
+	return nil
+}
+
+func (k *Key) withSigner(signer interface{}) *Key {
+	k.signer = signer
+	return k
+}
+
+// CanSign сообщает, есть ли у ключа приватная часть
+func (k *Key) CanSign() bool {
+	return k.signer != nil
+}
+
+// Symmetric сообщает, что ключ является HMAC секретом
+func (k *Key) Symmetric() bool {
+	_, ok := k.Method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// ParsePEM читает ключ Ed25519 или RSA из PEM: приватный (PKCS#8, PKCS#1) или публичный (PKIX).
+// Публичный ключ годится только для проверки токенов.
+func ParsePEM(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch priv := private.(type) {
+		case ed25519.PrivateKey:
+			return NewEd25519Key(priv), nil
+		case *rsa.PrivateKey:
+			return NewRSAKey(priv)
+		}
+		return nil, fmt.Errorf("unsupported private key type %T", private)
+	case "RSA PRIVATE KEY":
+		priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return NewRSAKey(priv)
+	case "PUBLIC KEY":
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if pub, ok := public.(*rsa.PublicKey); ok && pub.N.BitLen() < MinRSABits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", MinRSABits)
+		}
+		if key := newPublicKey(public); key != nil {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unsupported public key type %T", public)
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+// LoadFile читает ключ из PEM файла
+func LoadFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParsePEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// KeyRing связка ключей: один ключ подписывает, все ключи проверяют
+type KeyRing struct {
+	signing *Key
+	keys    map[string]*Key
+	ordered []*Key
+	legacy  *Key
+}
+
+// NewKeyRing создает связку. verify ключи (например, предыдущий ключ подписи) принимаются
+// при проверке до тех пор, пока не будут удалены из конфигурации.
+// Токены без kid, выпущенные до появления связки, проверяются HMAC ключом, если он есть.
+func NewKeyRing(signing *Key, verify ...*Key) (*KeyRing, error) {
+	if signing == nil || !signing.CanSign() {
+		return nil, ErrNoSigningKey
+	}
+	r := &KeyRing{signing: signing, keys: map[string]*Key{}}
+	for _, key := range append([]*Key{signing}, verify...) {
+		if _, ok := r.keys[key.ID]; ok {
+			continue
+		}
+		r.keys[key.ID] = key
+		r.ordered = append(r.ordered, key)
+		if key.Symmetric() && r.legacy == nil {
+			r.legacy = key
+		}
+	}
+	return r, nil
+}
+
+// SigningKey возвращает текущий ключ подписи
+func (r *KeyRing) SigningKey() *Key {
+	return r.signing
+}
+
+// Sign подписывает claims текущим ключом и записывает его kid в заголовок
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.signing.Method, claims)
+	token.Header["kid"] = r.signing.ID
+	return token.SignedString(r.signing.signer)
+}
+
+// Parse проверяет подпись и срок действия токена. Ключ выбирается по kid,
+// а алгоритм токена должен совпадать с алгоритмом ключа.
+func (r *KeyRing) Parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, r.Keyfunc)
+}
+
+// Keyfunc реализует jwt.Keyfunc для связки
+func (r *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	key := r.legacy
+	if kid, ok := token.Header["kid"]; ok {
+		id, _ := kid.(string)
+		key = r.keys[id]
+	}
+	if key == nil {
+		return nil, ErrUnknownKey
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q for key %s", token.Method.Alg(), key.ID)
+	}
+	return key.verifier, nil
+}
+
+// JWK публичный ключ в формате RFC 7517
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS набор публичных ключей
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает публичные ключи связки; текущий ключ подписи идет первым.
+// HMAC ключи не публикуются.
+func (r *KeyRing) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, key := range r.ordered {
+		if jwk, ok := key.jwk(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// Handler отдает JWKS документ (обычно по пути /.well-known/jwks.json)
+func (r *KeyRing) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(r.JWKS())
+	})
+}
+
+func (k *Key) jwk() (JWK, bool) {
+	switch pub := k.verifier.(type) {
+	case ed25519.PublicKey:
+		return JWK{KeyType: "OKP", KeyID: k.ID, Use: "sig", Algorithm: k.Method.Alg(), Curve: "Ed25519",
+			X: b64(pub)}, true
+	case *rsa.PublicKey:
+		return JWK{KeyType: "RSA", KeyID: k.ID, Use: "sig", Algorithm: k.Method.Alg(),
+			N: b64(pub.N.Bytes()), E: b64(big.NewInt(int64(pub.E)).Bytes())}, true
+	}
+	return JWK{}, false
+}
+
+// thumbprint вычисляет JWK thumbprint (RFC 7638): SHA-256 от обязательных полей в алфавитном порядке
+func thumbprint(public crypto.PublicKey) string {
+	var canonical string
+	switch pub := public.(type) {
+	case ed25519.PublicKey:
+		canonical = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, b64(pub))
+	case *rsa.PublicKey:
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, b64(big.NewInt(int64(pub.E)).Bytes()), b64(pub.N.Bytes()))
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return b64(sum[:])
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,190 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEd25519(t *testing.T) *Key {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return NewEd25519Key(private)
+}
+
+func claims() jwt.MapClaims {
+	return jwt.MapClaims{"user_id": "u1", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestThumbprint_RFC8037(t *testing.T) {
+	// Пример из RFC 8037, приложение A.3
+	x, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(ed25519.PublicKey(x))
+	require.NoError(t, err)
+
+	key, err := ParsePEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", key.ID)
+	assert.False(t, key.CanSign())
+}
+
+func TestKeyRing_SignAndParse(t *testing.T) {
+	signing := newEd25519(t)
+	ring, err := NewKeyRing(signing)
+	require.NoError(t, err)
+
+	tokenString, err := ring.Sign(claims())
+	require.NoError(t, err)
+
+	token, err := ring.Parse(tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, signing.ID, token.Header["kid"])
+	assert.Equal(t, "EdDSA", token.Method.Alg())
+	assert.Equal(t, "u1", token.Claims.(jwt.MapClaims)["user_id"])
+
+	// Токен другой связки не принимается
+	other, err := NewKeyRing(newEd25519(t))
+	require.NoError(t, err)
+	_, err = other.Parse(tokenString)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	oldKey, newKey := newEd25519(t), newEd25519(t)
+	oldRing, err := NewKeyRing(oldKey)
+	require.NoError(t, err)
+	oldToken, err := oldRing.Sign(claims())
+	require.NoError(t, err)
+
+	// После ротации старый ключ остается только для проверки
+	ring, err := NewKeyRing(newKey, oldKey)
+	require.NoError(t, err)
+	_, err = ring.Parse(oldToken)
+	assert.NoError(t, err)
+
+	newToken, err := ring.Sign(claims())
+	require.NoError(t, err)
+	token, err := ring.Parse(newToken)
+	require.NoError(t, err)
+	assert.Equal(t, newKey.ID, token.Header["kid"])
+
+	// Старый ключ удален из конфигурации
+	ring, err = NewKeyRing(newKey)
+	require.NoError(t, err)
+	_, err = ring.Parse(oldToken)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyRing_LegacyHMAC(t *testing.T) {
+	secret := []byte("test-secret-key-for-testing-32chars")
+	legacyToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims()).SignedString(secret)
+	require.NoError(t, err)
+
+	// Токены без kid проверяются HMAC ключом
+	ring, err := NewKeyRing(newEd25519(t), NewHMACKey(secret))
+	require.NoError(t, err)
+	_, err = ring.Parse(legacyToken)
+	assert.NoError(t, err)
+
+	ring, err = NewKeyRing(newEd25519(t))
+	require.NoError(t, err)
+	_, err = ring.Parse(legacyToken)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyRing_RejectsAlgorithmMismatch(t *testing.T) {
+	signing := newEd25519(t)
+	ring, err := NewKeyRing(signing)
+	require.NoError(t, err)
+
+	// HS256 токен, подписанный публичным ключом, с kid асимметричного ключа
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+	token.Header["kid"] = signing.ID
+	forged, err := token.SignedString([]byte(signing.verifier.(ed25519.PublicKey)))
+	require.NoError(t, err)
+
+	_, err = ring.Parse(forged)
+	assert.Error(t, err)
+}
+
+func TestNewKeyRing_RequiresPrivateKey(t *testing.T) {
+	private := newEd25519(t)
+	public := &Key{ID: private.ID, Method: private.Method, verifier: private.verifier}
+
+	_, err := NewKeyRing(public)
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestLoadFile_RSA(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	pkcs1 := filepath.Join(dir, "signing.pem")
+	require.NoError(t, os.WriteFile(pkcs1, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)}), 0o600))
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+	public := filepath.Join(dir, "public.pem")
+	require.NoError(t, os.WriteFile(public, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644))
+
+	signing, err := LoadFile(pkcs1)
+	require.NoError(t, err)
+	verifyOnly, err := LoadFile(public)
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", signing.Method.Alg())
+	assert.Equal(t, signing.ID, verifyOnly.ID)
+
+	ring, err := NewKeyRing(signing)
+	require.NoError(t, err)
+	tokenString, err := ring.Sign(claims())
+	require.NoError(t, err)
+
+	// Другой сервис проверяет токен только по публичному ключу
+	_, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return verifyOnly.verifier, nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	assert.NoError(t, err)
+
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	_, err = ParsePEM(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(small)}))
+	assert.Error(t, err)
+}
+
+func TestKeyRing_JWKS(t *testing.T) {
+	signing, previous := newEd25519(t), newEd25519(t)
+	ring, err := NewKeyRing(signing, previous, NewHMACKey([]byte("secret")))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	ring.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var set JWKS
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+	require.Len(t, set.Keys, 2, "HMAC ключ не публикуется")
+	assert.Equal(t, signing.ID, set.Keys[0].KeyID)
+	assert.Equal(t, previous.ID, set.Keys[1].KeyID)
+	assert.Equal(t, "OKP", set.Keys[0].KeyType)
+	assert.Equal(t, "Ed25519", set.Keys[0].Curve)
+	assert.Equal(t, "EdDSA", set.Keys[0].Algorithm)
+
+	x, err := base64.RawURLEncoding.DecodeString(set.Keys[0].X)
+	require.NoError(t, err)
+	assert.Equal(t, signing.verifier, ed25519.PublicKey(x))
+}
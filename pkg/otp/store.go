@@ -0,0 +1,453 @@
+package otp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Назначения OTP кодов. Коды разных назначений хранятся независимо,
+// поэтому код для регистрации нельзя использовать, например, для сброса пароля.
+const (
+	PurposeRegister      = "register"
+	PurposeResetPassword = "reset_password"
+	PurposeLogin         = "login"
+	PurposeResetPin      = "reset_pin"
+	PurposeTrustDevice   = "trust_device"
+	PurposeChangePhone   = "change_phone"
+	PurposeChangeEmail   = "change_email" // ключ - ID пользователя и адрес, а не телефон
+)
+
+var (
+	// ErrNotFound - код не запрашивался или истёк
+	ErrNotFound = errors.New("otp: code not found or expired")
+	// ErrInvalidCode - неверный код
+	ErrInvalidCode = errors.New("otp: invalid code")
+	// ErrTooManyAttempts - превышено количество попыток, код аннулирован
+	ErrTooManyAttempts = errors.New("otp: too many attempts")
+	// ErrInvalidTicket - тикет верификации отсутствует, истёк или уже использован
+	ErrInvalidTicket = errors.New("otp: invalid verification ticket")
+)
+
+// CooldownError - повторная отправка запрошена раньше, чем истёк cooldown
+type CooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("otp: resend cooldown, retry after %v", e.RetryAfter)
+}
+
+// Config - параметры OTP
+type Config struct {
+	Length         int           // Количество цифр в коде
+	TTL            time.Duration // Время жизни кода
+	MaxAttempts    int           // Максимум попыток проверки одного кода
+	ResendCooldown time.Duration // Минимальный интервал между отправками на один номер
+	TicketTTL      time.Duration // Время жизни тикета после успешной проверки
+}
+
+// DefaultConfig возвращает параметры по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		Length:         5,
+		TTL:            5 * time.Minute,
+		MaxAttempts:    5,
+		ResendCooldown: 60 * time.Second,
+		TicketTTL:      15 * time.Minute,
+	}
+}
+
+// Store интерфейс хранилища OTP кодов.
+//
+// Issue генерирует новый код и возвращает его для отправки по SMS.
+// Verify проверяет код; успешно проверенный код удаляется (одноразовый).
+// Check проверяет код, не удаляя его, чтобы сверить несколько кодов до
+// использования любого из них; неверные попытки засчитываются как в Verify.
+// IssueTicket выдаёт тикет, подтверждающий владение номером, а
+// ConsumeTicket проверяет и аннулирует его.
+type Store interface {
+	Issue(ctx context.Context, purpose, phone string) (string, error)
+	Verify(ctx context.Context, purpose, phone, code string) error
+	Check(ctx context.Context, purpose, phone, code string) error
+	IssueTicket(ctx context.Context, purpose, phone string) (string, error)
+	ConsumeTicket(ctx context.Context, purpose, phone, ticket string) error
+}
+
+// generateCode генерирует цифровой код криптографически стойким генератором
+func generateCode(length int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", length, n), nil
+}
+
+// generateTicket генерирует случайный непрозрачный тикет
+func generateTicket() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashValue хэширует код/тикет с секретом, привязывая его к назначению и номеру
+func hashValue(secret []byte, purpose, phone, value string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose + ":" + phone + ":" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ============================================
+// REDIS STORE
+// ============================================
+
+// verifyScript атомарно увеличивает счётчик попыток и сверяет хэш.
+// Возвращает 1 - успех, 0 - неверный код, -1 - код не найден, -2 - лимит попыток.
+var verifyScript = redis.NewScript(`
+local h = redis.call('HGET', KEYS[1], 'hash')
+if not h then
+	return -1
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if h == ARGV[1] then
+	redis.call('DEL', KEYS[1])
+	return 1
+end
+if attempts >= tonumber(ARGV[2]) then
+	redis.call('DEL', KEYS[1])
+	return -2
+end
+return 0
+`)
+
+// issueScript атомарно проверяет cooldown и сохраняет новый код. Cooldown ставится
+// только после записи кода, чтобы сбой не оставил номер без кода и без повторной отправки.
+// Возвращает оставшийся cooldown в миллисекундах или 0, если код сохранён.
+var issueScript = redis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl > 0 then
+	return ttl
+end
+redis.call('DEL', KEYS[2])
+redis.call('HSET', KEYS[2], 'hash', ARGV[2], 'attempts', 0)
+redis.call('PEXPIRE', KEYS[2], ARGV[3])
+redis.call('SET', KEYS[1], 1, 'PX', ARGV[1])
+return 0
+`)
+
+// checkScript как verifyScript, но верный код не удаляет и попытку не засчитывает.
+var checkScript = redis.NewScript(`
+local h = redis.call('HGET', KEYS[1], 'hash')
+if not h then
+	return -1
+end
+if h == ARGV[1] then
+	return 1
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if attempts >= tonumber(ARGV[2]) then
+	redis.call('DEL', KEYS[1])
+	return -2
+end
+return 0
+`)
+
+// consumeTicketScript удаляет тикет только если хэш совпадает.
+// Возвращает 1 - тикет принят, 0 - тикет не найден или не совпадает.
+var consumeTicketScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	redis.call('DEL', KEYS[1])
+	return 1
+end
+return 0
+`)
+
+// RedisStore реализация Store с Redis (для нескольких инстансов)
+type RedisStore struct {
+	client *redis.Client
+	secret []byte
+	prefix string
+	cfg    Config
+}
+
+// NewRedisStore создает новый Redis OTP store
+func NewRedisStore(client *redis.Client, secret []byte, prefix string, cfg Config) *RedisStore {
+	return &RedisStore{
+		client: client,
+		secret: secret,
+		prefix: prefix,
+		cfg:    cfg,
+	}
+}
+
+func (s *RedisStore) key(kind, purpose, phone string) string {
+	return fmt.Sprintf("%sotp:%s:%s:%s", s.prefix, kind, purpose, phone)
+}
+
+// Issue генерирует и сохраняет новый код
+func (s *RedisStore) Issue(ctx context.Context, purpose, phone string) (string, error) {
+	code, err := generateCode(s.cfg.Length)
+	if err != nil {
+		return "", err
+	}
+
+	cooldownKey := s.key("cooldown", purpose, phone)
+	ttl, err := issueScript.Run(ctx, s.client,
+		[]string{cooldownKey, s.key("code", purpose, phone)},
+		s.cfg.ResendCooldown.Milliseconds(), hashValue(s.secret, purpose, phone, code), s.cfg.TTL.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return "", fmt.Errorf("redis issue code error: %w", err)
+	}
+	if ttl > 0 {
+		return "", &CooldownError{RetryAfter: time.Duration(ttl) * time.Millisecond}
+	}
+
+	return code, nil
+}
+
+// Verify проверяет код и удаляет его при успехе
+func (s *RedisStore) Verify(ctx context.Context, purpose, phone, code string) error {
+	return s.runVerify(ctx, verifyScript, purpose, phone, code)
+}
+
+// Check проверяет код, оставляя его для последующего Verify
+func (s *RedisStore) Check(ctx context.Context, purpose, phone, code string) error {
+	return s.runVerify(ctx, checkScript, purpose, phone, code)
+}
+
+func (s *RedisStore) runVerify(ctx context.Context, script *redis.Script, purpose, phone, code string) error {
+	res, err := script.Run(ctx, s.client,
+		[]string{s.key("code", purpose, phone)},
+		hashValue(s.secret, purpose, phone, code), s.cfg.MaxAttempts,
+	).Int()
+	if err != nil {
+		return fmt.Errorf("redis verify error: %w", err)
+	}
+
+	switch res {
+	case 1:
+		return nil
+	case -1:
+		return ErrNotFound
+	case -2:
+		return ErrTooManyAttempts
+	default:
+		return ErrInvalidCode
+	}
+}
+
+// IssueTicket выдаёт тикет верификации
+func (s *RedisStore) IssueTicket(ctx context.Context, purpose, phone string) (string, error) {
+	ticket, err := generateTicket()
+	if err != nil {
+		return "", err
+	}
+
+	ticketKey := s.key("ticket", purpose, phone)
+	if err := s.client.Set(ctx, ticketKey, hashValue(s.secret, purpose, phone, ticket), s.cfg.TicketTTL).Err(); err != nil {
+		return "", fmt.Errorf("redis set ticket error: %w", err)
+	}
+	return ticket, nil
+}
+
+// ConsumeTicket проверяет тикет и аннулирует его
+func (s *RedisStore) ConsumeTicket(ctx context.Context, purpose, phone, ticket string) error {
+	if ticket == "" {
+		return ErrInvalidTicket
+	}
+
+	res, err := consumeTicketScript.Run(ctx, s.client,
+		[]string{s.key("ticket", purpose, phone)},
+		hashValue(s.secret, purpose, phone, ticket),
+	).Int()
+	if err != nil {
+		return fmt.Errorf("redis consume ticket error: %w", err)
+	}
+	if res != 1 {
+		return ErrInvalidTicket
+	}
+	return nil
+}
+
+// ============================================
+// MEMORY STORE
+// ============================================
+
+// MemoryStore простой in-memory store (для development и single instance)
+type MemoryStore struct {
+	mu        sync.Mutex
+	secret    []byte
+	cfg       Config
+	codes     map[string]*codeEntry
+	cooldowns map[string]time.Time
+	tickets   map[string]ticketEntry
+}
+
+type codeEntry struct {
+	hash      string
+	attempts  int
+	expiresAt time.Time
+}
+
+type ticketEntry struct {
+	hash      string
+	expiresAt time.Time
+}
+
+// NewMemoryStore создает in-memory OTP store
+func NewMemoryStore(secret []byte, cfg Config) *MemoryStore {
+	store := &MemoryStore{
+		secret:    secret,
+		cfg:       cfg,
+		codes:     make(map[string]*codeEntry),
+		cooldowns: make(map[string]time.Time),
+		tickets:   make(map[string]ticketEntry),
+	}
+
+	// Запускаем очистку устаревших записей
+	go store.cleanupExpired()
+
+	return store
+}
+
+func memoryKey(purpose, phone string) string {
+	return purpose + ":" + phone
+}
+
+func (s *MemoryStore) Issue(ctx context.Context, purpose, phone string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := memoryKey(purpose, phone)
+	now := time.Now()
+	if until, ok := s.cooldowns[key]; ok && now.Before(until) {
+		return "", &CooldownError{RetryAfter: until.Sub(now)}
+	}
+
+	code, err := generateCode(s.cfg.Length)
+	if err != nil {
+		return "", err
+	}
+
+	s.codes[key] = &codeEntry{
+		hash:      hashValue(s.secret, purpose, phone, code),
+		expiresAt: now.Add(s.cfg.TTL),
+	}
+	s.cooldowns[key] = now.Add(s.cfg.ResendCooldown)
+
+	return code, nil
+}
+
+func (s *MemoryStore) Verify(ctx context.Context, purpose, phone, code string) error {
+	return s.verify(purpose, phone, code, true)
+}
+
+func (s *MemoryStore) Check(ctx context.Context, purpose, phone, code string) error {
+	return s.verify(purpose, phone, code, false)
+}
+
+// verify сверяет код; consume удаляет верный код, иначе он остаётся для Verify
+func (s *MemoryStore) verify(purpose, phone, code string, consume bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := memoryKey(purpose, phone)
+	entry, ok := s.codes[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(s.codes, key)
+		return ErrNotFound
+	}
+
+	expected := hashValue(s.secret, purpose, phone, code)
+	if subtle.ConstantTimeCompare([]byte(entry.hash), []byte(expected)) == 1 {
+		if consume {
+			delete(s.codes, key)
+		}
+		return nil
+	}
+	entry.attempts++
+	if entry.attempts >= s.cfg.MaxAttempts {
+		delete(s.codes, key)
+		return ErrTooManyAttempts
+	}
+	return ErrInvalidCode
+}
+
+func (s *MemoryStore) IssueTicket(ctx context.Context, purpose, phone string) (string, error) {
+	ticket, err := generateTicket()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tickets[memoryKey(purpose, phone)] = ticketEntry{
+		hash:      hashValue(s.secret, purpose, phone, ticket),
+		expiresAt: time.Now().Add(s.cfg.TicketTTL),
+	}
+	return ticket, nil
+}
+
+func (s *MemoryStore) ConsumeTicket(ctx context.Context, purpose, phone, ticket string) error {
+	if ticket == "" {
+		return ErrInvalidTicket
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := memoryKey(purpose, phone)
+	entry, ok := s.tickets[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(s.tickets, key)
+		return ErrInvalidTicket
+	}
+
+	expected := hashValue(s.secret, purpose, phone, ticket)
+	if subtle.ConstantTimeCompare([]byte(entry.hash), []byte(expected)) != 1 {
+		return ErrInvalidTicket
+	}
+	delete(s.tickets, key)
+	return nil
+}
+
+func (s *MemoryStore) cleanupExpired() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mu.Lock()
+		now := time.Now()
+		for key, entry := range s.codes {
+			if now.After(entry.expiresAt) {
+				delete(s.codes, key)
+			}
+		}
+		for key, until := range s.cooldowns {
+			if now.After(until) {
+				delete(s.cooldowns, key)
+			}
+		}
+		for key, entry := range s.tickets {
+			if now.After(entry.expiresAt) {
+				delete(s.tickets, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
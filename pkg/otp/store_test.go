@@ -0,0 +1,112 @@
+package otp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	cfg := DefaultConfig()
+	cfg.MaxAttempts = 3
+	return cfg
+}
+
+func TestMemoryStore_IssueAndVerify(t *testing.T) {
+	store := NewMemoryStore([]byte("test-secret"), testConfig())
+	ctx := context.Background()
+
+	code, err := store.Issue(ctx, PurposeRegister, "+998901234567")
+	require.NoError(t, err)
+	assert.Len(t, code, 5)
+
+	// Код другого назначения не принимается
+	assert.ErrorIs(t, store.Verify(ctx, "other", "+998901234567", code), ErrNotFound)
+
+	require.NoError(t, store.Verify(ctx, PurposeRegister, "+998901234567", code))
+
+	// Одноразовый
+	assert.ErrorIs(t, store.Verify(ctx, PurposeRegister, "+998901234567", code), ErrNotFound)
+}
+
+func TestMemoryStore_Cooldown(t *testing.T) {
+	store := NewMemoryStore([]byte("test-secret"), testConfig())
+	ctx := context.Background()
+
+	_, err := store.Issue(ctx, PurposeRegister, "+998901234567")
+	require.NoError(t, err)
+
+	_, err = store.Issue(ctx, PurposeRegister, "+998901234567")
+	var cooldown *CooldownError
+	require.True(t, errors.As(err, &cooldown))
+	assert.Greater(t, cooldown.RetryAfter, time.Duration(0))
+
+	// Другой номер не затронут
+	_, err = store.Issue(ctx, PurposeRegister, "+998907654321")
+	require.NoError(t, err)
+}
+
+func TestMemoryStore_MaxAttempts(t *testing.T) {
+	store := NewMemoryStore([]byte("test-secret"), testConfig())
+	ctx := context.Background()
+
+	code, err := store.Issue(ctx, PurposeRegister, "+998901234567")
+	require.NoError(t, err)
+
+	wrong := "1" + code
+	assert.ErrorIs(t, store.Verify(ctx, PurposeRegister, "+998901234567", wrong), ErrInvalidCode)
+	assert.ErrorIs(t, store.Verify(ctx, PurposeRegister, "+998901234567", wrong), ErrInvalidCode)
+	assert.ErrorIs(t, store.Verify(ctx, PurposeRegister, "+998901234567", wrong), ErrTooManyAttempts)
+
+	// После исчерпания попыток даже верный код не принимается
+	assert.ErrorIs(t, store.Verify(ctx, PurposeRegister, "+998901234567", code), ErrNotFound)
+}
+
+func TestMemoryStore_Check(t *testing.T) {
+	store := NewMemoryStore([]byte("test-secret"), testConfig())
+	ctx := context.Background()
+
+	code, err := store.Issue(ctx, PurposeChangePhone, "+998901234567")
+	require.NoError(t, err)
+
+	// Check не удаляет код, но неверные попытки считает
+	require.NoError(t, store.Check(ctx, PurposeChangePhone, "+998901234567", code))
+	assert.ErrorIs(t, store.Check(ctx, PurposeChangePhone, "+998901234567", "1"+code), ErrInvalidCode)
+	require.NoError(t, store.Verify(ctx, PurposeChangePhone, "+998901234567", code))
+	assert.ErrorIs(t, store.Check(ctx, PurposeChangePhone, "+998901234567", code), ErrNotFound)
+}
+
+func TestMemoryStore_Expired(t *testing.T) {
+	cfg := testConfig()
+	cfg.TTL = time.Millisecond
+	store := NewMemoryStore([]byte("test-secret"), cfg)
+	ctx := context.Background()
+
+	code, err := store.Issue(ctx, PurposeRegister, "+998901234567")
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	assert.ErrorIs(t, store.Verify(ctx, PurposeRegister, "+998901234567", code), ErrNotFound)
+}
+
+func TestMemoryStore_Tickets(t *testing.T) {
+	store := NewMemoryStore([]byte("test-secret"), testConfig())
+	ctx := context.Background()
+
+	ticket, err := store.IssueTicket(ctx, PurposeRegister, "+998901234567")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, store.ConsumeTicket(ctx, PurposeRegister, "+998901234567", ""), ErrInvalidTicket)
+	assert.ErrorIs(t, store.ConsumeTicket(ctx, PurposeRegister, "+998907654321", ticket), ErrInvalidTicket)
+
+	// Неверный тикет не аннулирует действующий
+	assert.ErrorIs(t, store.ConsumeTicket(ctx, PurposeRegister, "+998901234567", "bogus"), ErrInvalidTicket)
+	require.NoError(t, store.ConsumeTicket(ctx, PurposeRegister, "+998901234567", ticket))
+
+	// Одноразовый
+	assert.ErrorIs(t, store.ConsumeTicket(ctx, PurposeRegister, "+998901234567", ticket), ErrInvalidTicket)
+}
@@ -145,6 +145,8 @@ type OrderItem struct {
 	Quantity      int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	Price         float64                `protobuf:"fixed64,7,opt,name=price,proto3" json:"price,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	VariantName   string                 `protobuf:"bytes,9,opt,name=variant_name,json=variantName,proto3" json:"variant_name,omitempty"`
+	VariantValue  string                 `protobuf:"bytes,10,opt,name=variant_value,json=variantValue,proto3" json:"variant_value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -235,26 +237,46 @@ func (x *OrderItem) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *OrderItem) GetVariantName() string {
+	if x != nil {
+		return x.VariantName
+	}
+	return ""
+}
+
+func (x *OrderItem) GetVariantValue() string {
+	if x != nil {
+		return x.VariantValue
+	}
+	return ""
+}
+
 type Order struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ShopId        string                 `protobuf:"bytes,2,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
-	ShopName      string                 `protobuf:"bytes,3,opt,name=shop_name,json=shopName,proto3" json:"shop_name,omitempty"`
-	ClientName    string                 `protobuf:"bytes,4,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
-	ClientPhone   string                 `protobuf:"bytes,5,opt,name=client_phone,json=clientPhone,proto3" json:"client_phone,omitempty"`
-	ClientAddress string                 `protobuf:"bytes,6,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"`
-	TotalAmount   float64                `protobuf:"fixed64,7,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	DeliveryPrice float64                `protobuf:"fixed64,8,opt,name=delivery_price,json=deliveryPrice,proto3" json:"delivery_price,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,9,opt,name=status,proto3,enum=order.OrderStatus" json:"status,omitempty"`
-	ClientNote    string                 `protobuf:"bytes,10,opt,name=client_note,json=clientNote,proto3" json:"client_note,omitempty"`
-	SellerNote    string                 `protobuf:"bytes,11,opt,name=seller_note,json=sellerNote,proto3" json:"seller_note,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,12,rep,name=items,proto3" json:"items,omitempty"`
-	ItemsCount    int32                  `protobuf:"varint,13,opt,name=items_count,json=itemsCount,proto3" json:"items_count,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShopId             string                 `protobuf:"bytes,2,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	ShopName           string                 `protobuf:"bytes,3,opt,name=shop_name,json=shopName,proto3" json:"shop_name,omitempty"`
+	ClientName         string                 `protobuf:"bytes,4,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	ClientPhone        string                 `protobuf:"bytes,5,opt,name=client_phone,json=clientPhone,proto3" json:"client_phone,omitempty"`
+	ClientAddress      string                 `protobuf:"bytes,6,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"`
+	TotalAmount        float64                `protobuf:"fixed64,7,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	DeliveryPrice      float64                `protobuf:"fixed64,8,opt,name=delivery_price,json=deliveryPrice,proto3" json:"delivery_price,omitempty"`
+	Status             OrderStatus            `protobuf:"varint,9,opt,name=status,proto3,enum=order.OrderStatus" json:"status,omitempty"`
+	ClientNote         string                 `protobuf:"bytes,10,opt,name=client_note,json=clientNote,proto3" json:"client_note,omitempty"`
+	SellerNote         string                 `protobuf:"bytes,11,opt,name=seller_note,json=sellerNote,proto3" json:"seller_note,omitempty"`
+	Items              []*OrderItem           `protobuf:"bytes,12,rep,name=items,proto3" json:"items,omitempty"`
+	ItemsCount         int32                  `protobuf:"varint,13,opt,name=items_count,json=itemsCount,proto3" json:"items_count,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompletedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ConfirmedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=confirmed_at,json=confirmedAt,proto3" json:"confirmed_at,omitempty"`
+	CancellationReason string                 `protobuf:"bytes,18,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	RejectionNote      string                 `protobuf:"bytes,19,opt,name=rejection_note,json=rejectionNote,proto3" json:"rejection_note,omitempty"`
+	History            []*OrderStatusChange   `protobuf:"bytes,20,rep,name=history,proto3" json:"history,omitempty"`                         // Timeline, oldest first; filled in single-order responses only
+	UserId             string                 `protobuf:"bytes,21,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // Buyer's account; empty for guest orders not yet linked
+	CheckoutId         string                 `protobuf:"bytes,22,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"` // Shared by the orders of one Checkout; empty for CreateOrder
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
@@ -399,20 +421,174 @@ func (x *Order) GetCompletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Order) GetConfirmedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConfirmedAt
+	}
+	return nil
+}
+
+func (x *Order) GetCancellationReason() string {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return ""
+}
+
+func (x *Order) GetRejectionNote() string {
+	if x != nil {
+		return x.RejectionNote
+	}
+	return ""
+}
+
+func (x *Order) GetHistory() []*OrderStatusChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+// One entry of an order's status timeline. The first entry is the order's creation
+// and has no old_status.
+type OrderStatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OldStatus     OrderStatus            `protobuf:"varint,3,opt,name=old_status,json=oldStatus,proto3,enum=order.OrderStatus" json:"old_status,omitempty"`
+	NewStatus     OrderStatus            `protobuf:"varint,4,opt,name=new_status,json=newStatus,proto3,enum=order.OrderStatus" json:"new_status,omitempty"`
+	ActorId       string                 `protobuf:"bytes,5,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`       // Empty for guest orders
+	ActorRole     string                 `protobuf:"bytes,6,opt,name=actor_role,json=actorRole,proto3" json:"actor_role,omitempty"` // buyer, seller or admin
+	Note          string                 `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	Reason        string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusChange) Reset() {
+	*x = OrderStatusChange{}
+	mi := &file_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusChange) ProtoMessage() {}
+
+func (x *OrderStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusChange.ProtoReflect.Descriptor instead.
+func (*OrderStatusChange) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderStatusChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetOldStatus() OrderStatus {
+	if x != nil {
+		return x.OldStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderStatusChange) GetNewStatus() OrderStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderStatusChange) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetActorRole() string {
+	if x != nil {
+		return x.ActorRole
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 type OrderItemInput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
-	ProductImage  string                 `protobuf:"bytes,3,opt,name=product_image,json=productImage,proto3" json:"product_image,omitempty"`
+	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`    // Ignored: the name is taken from products
+	ProductImage  string                 `protobuf:"bytes,3,opt,name=product_image,json=productImage,proto3" json:"product_image,omitempty"` // Ignored: the image is taken from products
 	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`                                 // Ignored: the price is computed by the server
+	VariantName   string                 `protobuf:"bytes,6,opt,name=variant_name,json=variantName,proto3" json:"variant_name,omitempty"`    // Optional: ProductVariant.name
+	VariantValue  string                 `protobuf:"bytes,7,opt,name=variant_value,json=variantValue,proto3" json:"variant_value,omitempty"` // Optional: ProductVariant.value
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OrderItemInput) Reset() {
 	*x = OrderItemInput{}
-	mi := &file_order_proto_msgTypes[2]
+	mi := &file_order_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -424,7 +600,7 @@ func (x *OrderItemInput) String() string {
 func (*OrderItemInput) ProtoMessage() {}
 
 func (x *OrderItemInput) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[2]
+	mi := &file_order_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -437,7 +613,7 @@ func (x *OrderItemInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItemInput.ProtoReflect.Descriptor instead.
 func (*OrderItemInput) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{2}
+	return file_order_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *OrderItemInput) GetProductId() string {
@@ -475,23 +651,38 @@ func (x *OrderItemInput) GetPrice() float64 {
 	return 0
 }
 
+func (x *OrderItemInput) GetVariantName() string {
+	if x != nil {
+		return x.VariantName
+	}
+	return ""
+}
+
+func (x *OrderItemInput) GetVariantValue() string {
+	if x != nil {
+		return x.VariantValue
+	}
+	return ""
+}
+
 type CreateOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
 	ClientName    string                 `protobuf:"bytes,2,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
 	ClientPhone   string                 `protobuf:"bytes,3,opt,name=client_phone,json=clientPhone,proto3" json:"client_phone,omitempty"`
 	ClientAddress string                 `protobuf:"bytes,4,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"`
-	TotalAmount   float64                `protobuf:"fixed64,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	DeliveryPrice float64                `protobuf:"fixed64,6,opt,name=delivery_price,json=deliveryPrice,proto3" json:"delivery_price,omitempty"`
+	TotalAmount   float64                `protobuf:"fixed64,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`       // Total shown to the client; if set and out of date, the call fails with OrderPriceChanged
+	DeliveryPrice float64                `protobuf:"fixed64,6,opt,name=delivery_price,json=deliveryPrice,proto3" json:"delivery_price,omitempty"` // Ignored: delivery is computed from the products' delivery settings
 	ClientNote    string                 `protobuf:"bytes,7,opt,name=client_note,json=clientNote,proto3" json:"client_note,omitempty"`
 	Items         []*OrderItemInput      `protobuf:"bytes,8,rep,name=items,proto3" json:"items,omitempty"`
+	RegionId      string                 `protobuf:"bytes,9,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"` // Delivery region; empty means the shop's home region
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
 	*x = CreateOrderRequest{}
-	mi := &file_order_proto_msgTypes[3]
+	mi := &file_order_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -503,7 +694,7 @@ func (x *CreateOrderRequest) String() string {
 func (*CreateOrderRequest) ProtoMessage() {}
 
 func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[3]
+	mi := &file_order_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +707,7 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{3}
+	return file_order_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateOrderRequest) GetShopId() string {
@@ -575,6 +766,253 @@ func (x *CreateOrderRequest) GetItems() []*OrderItemInput {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetRegionId() string {
+	if x != nil {
+		return x.RegionId
+	}
+	return ""
+}
+
+// Sent as a status detail with FAILED_PRECONDITION when CreateOrderRequest.total_amount
+// no longer matches the server's prices. Items carry the current unit prices.
+type OrderPriceChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpectedTotal float64                `protobuf:"fixed64,1,opt,name=expected_total,json=expectedTotal,proto3" json:"expected_total,omitempty"`
+	TotalAmount   float64                `protobuf:"fixed64,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	DeliveryPrice float64                `protobuf:"fixed64,3,opt,name=delivery_price,json=deliveryPrice,proto3" json:"delivery_price,omitempty"`
+	Items         []*OrderItem           `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderPriceChanged) Reset() {
+	*x = OrderPriceChanged{}
+	mi := &file_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderPriceChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderPriceChanged) ProtoMessage() {}
+
+func (x *OrderPriceChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderPriceChanged.ProtoReflect.Descriptor instead.
+func (*OrderPriceChanged) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OrderPriceChanged) GetExpectedTotal() float64 {
+	if x != nil {
+		return x.ExpectedTotal
+	}
+	return 0
+}
+
+func (x *OrderPriceChanged) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *OrderPriceChanged) GetDeliveryPrice() float64 {
+	if x != nil {
+		return x.DeliveryPrice
+	}
+	return 0
+}
+
+func (x *OrderPriceChanged) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// A mixed basket from several shops. Items are grouped by the products' shop and one
+// order is created per shop; delivery is computed per shop.
+type CheckoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientName    string                 `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	ClientPhone   string                 `protobuf:"bytes,2,opt,name=client_phone,json=clientPhone,proto3" json:"client_phone,omitempty"`
+	ClientAddress string                 `protobuf:"bytes,3,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"`
+	ClientNote    string                 `protobuf:"bytes,4,opt,name=client_note,json=clientNote,proto3" json:"client_note,omitempty"`
+	Items         []*OrderItemInput      `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	TotalAmount   float64                `protobuf:"fixed64,6,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"` // Grand total shown to the client; if set and out of date, the call fails with OrderPriceChanged
+	RegionId      string                 `protobuf:"bytes,7,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`            // Delivery region; empty means each shop's home region
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutRequest) ProtoMessage() {}
+
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CheckoutRequest) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetClientPhone() string {
+	if x != nil {
+		return x.ClientPhone
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetClientAddress() string {
+	if x != nil {
+		return x.ClientAddress
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetClientNote() string {
+	if x != nil {
+		return x.ClientNote
+	}
+	return ""
+}
+
+func (x *CheckoutRequest) GetItems() []*OrderItemInput {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CheckoutRequest) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *CheckoutRequest) GetRegionId() string {
+	if x != nil {
+		return x.RegionId
+	}
+	return ""
+}
+
+type CheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutId    string                 `protobuf:"bytes,1,opt,name=checkout_id,json=checkoutId,proto3" json:"checkout_id,omitempty"`
+	Orders        []*Order               `protobuf:"bytes,2,rep,name=orders,proto3" json:"orders,omitempty"` // One per shop, in the order the shops first appear in the basket
+	Subtotal      float64                `protobuf:"fixed64,3,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	DeliveryPrice float64                `protobuf:"fixed64,4,opt,name=delivery_price,json=deliveryPrice,proto3" json:"delivery_price,omitempty"` // Sum of the orders' delivery prices
+	TotalAmount   float64                `protobuf:"fixed64,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckoutResponse) GetCheckoutId() string {
+	if x != nil {
+		return x.CheckoutId
+	}
+	return ""
+}
+
+func (x *CheckoutResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *CheckoutResponse) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *CheckoutResponse) GetDeliveryPrice() float64 {
+	if x != nil {
+		return x.DeliveryPrice
+	}
+	return 0
+}
+
+func (x *CheckoutResponse) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
 type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -584,7 +1022,7 @@ type GetOrderRequest struct {
 
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
-	mi := &file_order_proto_msgTypes[4]
+	mi := &file_order_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -596,7 +1034,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[4]
+	mi := &file_order_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,7 +1047,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{4}
+	return file_order_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetOrderRequest) GetId() string {
@@ -620,17 +1058,19 @@ func (x *GetOrderRequest) GetId() string {
 }
 
 type UpdateOrderStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=order.OrderStatus" json:"status,omitempty"`
-	SellerNote    string                 `protobuf:"bytes,3,opt,name=seller_note,json=sellerNote,proto3" json:"seller_note,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status               OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=order.OrderStatus" json:"status,omitempty"`
+	SellerNote           string                 `protobuf:"bytes,3,opt,name=seller_note,json=sellerNote,proto3" json:"seller_note,omitempty"`
+	CancellationReasonId string                 `protobuf:"bytes,4,opt,name=cancellation_reason_id,json=cancellationReasonId,proto3" json:"cancellation_reason_id,omitempty"` // CommonService.ListCancellationReasons; required when the shop cancels
+	RejectionNote        string                 `protobuf:"bytes,5,opt,name=rejection_note,json=rejectionNote,proto3" json:"rejection_note,omitempty"`                        // Optional explanation of the cancellation
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *UpdateOrderStatusRequest) Reset() {
 	*x = UpdateOrderStatusRequest{}
-	mi := &file_order_proto_msgTypes[5]
+	mi := &file_order_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -642,7 +1082,7 @@ func (x *UpdateOrderStatusRequest) String() string {
 func (*UpdateOrderStatusRequest) ProtoMessage() {}
 
 func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[5]
+	mi := &file_order_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -655,7 +1095,7 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{5}
+	return file_order_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateOrderStatusRequest) GetId() string {
@@ -679,6 +1119,108 @@ func (x *UpdateOrderStatusRequest) GetSellerNote() string {
 	return ""
 }
 
+func (x *UpdateOrderStatusRequest) GetCancellationReasonId() string {
+	if x != nil {
+		return x.CancellationReasonId
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetRejectionNote() string {
+	if x != nil {
+		return x.RejectionNote
+	}
+	return ""
+}
+
+type GetOrderHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetOrderHistoryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	History       []*OrderStatusChange   `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOrderHistoryResponse) GetHistory() []*OrderStatusChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
 type DeleteOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -688,7 +1230,7 @@ type DeleteOrderRequest struct {
 
 func (x *DeleteOrderRequest) Reset() {
 	*x = DeleteOrderRequest{}
-	mi := &file_order_proto_msgTypes[6]
+	mi := &file_order_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -700,7 +1242,7 @@ func (x *DeleteOrderRequest) String() string {
 func (*DeleteOrderRequest) ProtoMessage() {}
 
 func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[6]
+	mi := &file_order_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -713,7 +1255,7 @@ func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteOrderRequest.ProtoReflect.Descriptor instead.
 func (*DeleteOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{6}
+	return file_order_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteOrderRequest) GetId() string {
@@ -735,7 +1277,7 @@ type ListOrdersRequest struct {
 
 func (x *ListOrdersRequest) Reset() {
 	*x = ListOrdersRequest{}
-	mi := &file_order_proto_msgTypes[7]
+	mi := &file_order_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -747,7 +1289,7 @@ func (x *ListOrdersRequest) String() string {
 func (*ListOrdersRequest) ProtoMessage() {}
 
 func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[7]
+	mi := &file_order_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -760,7 +1302,7 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
 func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{7}
+	return file_order_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListOrdersRequest) GetShopId() string {
@@ -791,6 +1333,68 @@ func (x *ListOrdersRequest) GetLimit() int32 {
 	return 0
 }
 
+// Orders placed by the caller, across all shops. Guest orders made with the caller's
+// verified phone are included.
+type ListMyOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []OrderStatus          `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=order.OrderStatus" json:"statuses,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyOrdersRequest) Reset() {
+	*x = ListMyOrdersRequest{}
+	mi := &file_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyOrdersRequest) ProtoMessage() {}
+
+func (x *ListMyOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListMyOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListMyOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListMyOrdersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMyOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type ListOrdersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
@@ -803,7 +1407,7 @@ type ListOrdersResponse struct {
 
 func (x *ListOrdersResponse) Reset() {
 	*x = ListOrdersResponse{}
-	mi := &file_order_proto_msgTypes[8]
+	mi := &file_order_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -815,7 +1419,7 @@ func (x *ListOrdersResponse) String() string {
 func (*ListOrdersResponse) ProtoMessage() {}
 
 func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[8]
+	mi := &file_order_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -828,7 +1432,7 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
 func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{8}
+	return file_order_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListOrdersResponse) GetOrders() []*Order {
@@ -868,7 +1472,7 @@ type OrderResponse struct {
 
 func (x *OrderResponse) Reset() {
 	*x = OrderResponse{}
-	mi := &file_order_proto_msgTypes[9]
+	mi := &file_order_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -880,7 +1484,7 @@ func (x *OrderResponse) String() string {
 func (*OrderResponse) ProtoMessage() {}
 
 func (x *OrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[9]
+	mi := &file_order_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -893,7 +1497,7 @@ func (x *OrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
 func (*OrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{9}
+	return file_order_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *OrderResponse) GetOrder() *Order {
@@ -913,7 +1517,7 @@ type StreamOrdersRequest struct {
 
 func (x *StreamOrdersRequest) Reset() {
 	*x = StreamOrdersRequest{}
-	mi := &file_order_proto_msgTypes[10]
+	mi := &file_order_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -925,7 +1529,7 @@ func (x *StreamOrdersRequest) String() string {
 func (*StreamOrdersRequest) ProtoMessage() {}
 
 func (x *StreamOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[10]
+	mi := &file_order_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -938,7 +1542,7 @@ func (x *StreamOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamOrdersRequest.ProtoReflect.Descriptor instead.
 func (*StreamOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{10}
+	return file_order_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *StreamOrdersRequest) GetShopId() string {
@@ -965,7 +1569,7 @@ type OrderEvent struct {
 
 func (x *OrderEvent) Reset() {
 	*x = OrderEvent{}
-	mi := &file_order_proto_msgTypes[11]
+	mi := &file_order_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -977,7 +1581,7 @@ func (x *OrderEvent) String() string {
 func (*OrderEvent) ProtoMessage() {}
 
 func (x *OrderEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[11]
+	mi := &file_order_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -990,7 +1594,7 @@ func (x *OrderEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderEvent.ProtoReflect.Descriptor instead.
 func (*OrderEvent) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{11}
+	return file_order_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *OrderEvent) GetType() OrderEventType {
@@ -1011,7 +1615,7 @@ var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\x05order\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\x8a\x02\n" +
+	"\vorder.proto\x12\x05order\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\xd2\x02\n" +
 	"\tOrderItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1d\n" +
@@ -1022,7 +1626,10 @@ const file_order_proto_rawDesc = "" +
 	"\bquantity\x18\x06 \x01(\x05R\bquantity\x12\x14\n" +
 	"\x05price\x18\a \x01(\x01R\x05price\x129\n" +
 	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xee\x04\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12!\n" +
+	"\fvariant_name\x18\t \x01(\tR\vvariantName\x12#\n" +
+	"\rvariant_value\x18\n" +
+	" \x01(\tR\fvariantValue\"\xf3\x06\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\ashop_id\x18\x02 \x01(\tR\x06shopId\x12\x1b\n" +
@@ -1046,14 +1653,37 @@ const file_order_proto_rawDesc = "" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
-	"\fcompleted_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xa9\x01\n" +
+	"\fcompleted_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12=\n" +
+	"\fconfirmed_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\vconfirmedAt\x12/\n" +
+	"\x13cancellation_reason\x18\x12 \x01(\tR\x12cancellationReason\x12%\n" +
+	"\x0erejection_note\x18\x13 \x01(\tR\rrejectionNote\x122\n" +
+	"\ahistory\x18\x14 \x03(\v2\x18.order.OrderStatusChangeR\ahistory\x12\x17\n" +
+	"\auser_id\x18\x15 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vcheckout_id\x18\x16 \x01(\tR\n" +
+	"checkoutId\"\xc5\x02\n" +
+	"\x11OrderStatusChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x121\n" +
+	"\n" +
+	"old_status\x18\x03 \x01(\x0e2\x12.order.OrderStatusR\toldStatus\x121\n" +
+	"\n" +
+	"new_status\x18\x04 \x01(\x0e2\x12.order.OrderStatusR\tnewStatus\x12\x19\n" +
+	"\bactor_id\x18\x05 \x01(\tR\aactorId\x12\x1d\n" +
+	"\n" +
+	"actor_role\x18\x06 \x01(\tR\tactorRole\x12\x12\n" +
+	"\x04note\x18\a \x01(\tR\x04note\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf1\x01\n" +
 	"\x0eOrderItemInput\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
 	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12#\n" +
 	"\rproduct_image\x18\x03 \x01(\tR\fproductImage\x12\x1a\n" +
 	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\"\xb0\x02\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12!\n" +
+	"\fvariant_name\x18\x06 \x01(\tR\vvariantName\x12#\n" +
+	"\rvariant_value\x18\a \x01(\tR\fvariantValue\"\xcd\x02\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\ashop_id\x18\x01 \x01(\tR\x06shopId\x12\x1f\n" +
 	"\vclient_name\x18\x02 \x01(\tR\n" +
@@ -1064,21 +1694,54 @@ const file_order_proto_rawDesc = "" +
 	"\x0edelivery_price\x18\x06 \x01(\x01R\rdeliveryPrice\x12\x1f\n" +
 	"\vclient_note\x18\a \x01(\tR\n" +
 	"clientNote\x12+\n" +
-	"\x05items\x18\b \x03(\v2\x15.order.OrderItemInputR\x05items\"!\n" +
+	"\x05items\x18\b \x03(\v2\x15.order.OrderItemInputR\x05items\x12\x1b\n" +
+	"\tregion_id\x18\t \x01(\tR\bregionId\"\xac\x01\n" +
+	"\x11OrderPriceChanged\x12%\n" +
+	"\x0eexpected_total\x18\x01 \x01(\x01R\rexpectedTotal\x12!\n" +
+	"\ftotal_amount\x18\x02 \x01(\x01R\vtotalAmount\x12%\n" +
+	"\x0edelivery_price\x18\x03 \x01(\x01R\rdeliveryPrice\x12&\n" +
+	"\x05items\x18\x04 \x03(\v2\x10.order.OrderItemR\x05items\"\x8a\x02\n" +
+	"\x0fCheckoutRequest\x12\x1f\n" +
+	"\vclient_name\x18\x01 \x01(\tR\n" +
+	"clientName\x12!\n" +
+	"\fclient_phone\x18\x02 \x01(\tR\vclientPhone\x12%\n" +
+	"\x0eclient_address\x18\x03 \x01(\tR\rclientAddress\x12\x1f\n" +
+	"\vclient_note\x18\x04 \x01(\tR\n" +
+	"clientNote\x12+\n" +
+	"\x05items\x18\x05 \x03(\v2\x15.order.OrderItemInputR\x05items\x12!\n" +
+	"\ftotal_amount\x18\x06 \x01(\x01R\vtotalAmount\x12\x1b\n" +
+	"\tregion_id\x18\a \x01(\tR\bregionId\"\xbf\x01\n" +
+	"\x10CheckoutResponse\x12\x1f\n" +
+	"\vcheckout_id\x18\x01 \x01(\tR\n" +
+	"checkoutId\x12$\n" +
+	"\x06orders\x18\x02 \x03(\v2\f.order.OrderR\x06orders\x12\x1a\n" +
+	"\bsubtotal\x18\x03 \x01(\x01R\bsubtotal\x12%\n" +
+	"\x0edelivery_price\x18\x04 \x01(\x01R\rdeliveryPrice\x12!\n" +
+	"\ftotal_amount\x18\x05 \x01(\x01R\vtotalAmount\"!\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"w\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd4\x01\n" +
 	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x12.order.OrderStatusR\x06status\x12\x1f\n" +
 	"\vseller_note\x18\x03 \x01(\tR\n" +
-	"sellerNote\"$\n" +
+	"sellerNote\x124\n" +
+	"\x16cancellation_reason_id\x18\x04 \x01(\tR\x14cancellationReasonId\x12%\n" +
+	"\x0erejection_note\x18\x05 \x01(\tR\rrejectionNote\"3\n" +
+	"\x16GetOrderHistoryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"M\n" +
+	"\x17GetOrderHistoryResponse\x122\n" +
+	"\ahistory\x18\x01 \x03(\v2\x18.order.OrderStatusChangeR\ahistory\"$\n" +
 	"\x12DeleteOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x86\x01\n" +
 	"\x11ListOrdersRequest\x12\x17\n" +
 	"\ashop_id\x18\x01 \x01(\tR\x06shopId\x12.\n" +
 	"\bstatuses\x18\x02 \x03(\x0e2\x12.order.OrderStatusR\bstatuses\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"z\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"o\n" +
+	"\x13ListMyOrdersRequest\x12.\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x12.order.OrderStatusR\bstatuses\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"z\n" +
 	"\x12ListOrdersResponse\x12$\n" +
 	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
@@ -1105,14 +1768,17 @@ const file_order_proto_rawDesc = "" +
 	"\x18ORDER_EVENT_TYPE_CREATED\x10\x01\x12\x1c\n" +
 	"\x18ORDER_EVENT_TYPE_UPDATED\x10\x02\x12#\n" +
 	"\x1fORDER_EVENT_TYPE_STATUS_CHANGED\x10\x03\x12\x1c\n" +
-	"\x18ORDER_EVENT_TYPE_DELETED\x10\x042\x91\x03\n" +
+	"\x18ORDER_EVENT_TYPE_DELETED\x10\x042\xe7\x04\n" +
 	"\fOrderService\x12>\n" +
-	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x14.order.OrderResponse\x128\n" +
+	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x14.order.OrderResponse\x12;\n" +
+	"\bCheckout\x12\x16.order.CheckoutRequest\x1a\x17.order.CheckoutResponse\x128\n" +
 	"\bGetOrder\x12\x16.order.GetOrderRequest\x1a\x14.order.OrderResponse\x12J\n" +
-	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a\x14.order.OrderResponse\x127\n" +
+	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a\x14.order.OrderResponse\x12P\n" +
+	"\x0fGetOrderHistory\x12\x1d.order.GetOrderHistoryRequest\x1a\x1e.order.GetOrderHistoryResponse\x127\n" +
 	"\vDeleteOrder\x12\x19.order.DeleteOrderRequest\x1a\r.common.Empty\x12A\n" +
 	"\n" +
-	"ListOrders\x12\x18.order.ListOrdersRequest\x1a\x19.order.ListOrdersResponse\x12?\n" +
+	"ListOrders\x12\x18.order.ListOrdersRequest\x1a\x19.order.ListOrdersResponse\x12E\n" +
+	"\fListMyOrders\x12\x1a.order.ListMyOrdersRequest\x1a\x19.order.ListOrdersResponse\x12?\n" +
 	"\fStreamOrders\x12\x1a.order.StreamOrdersRequest\x1a\x11.order.OrderEvent0\x01B\x1cZ\x1amebellar-backend/pkg/pb;pbb\x06proto3"
 
 var (
@@ -1128,57 +1794,80 @@ func file_order_proto_rawDescGZIP() []byte {
 }
 
 var file_order_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_order_proto_goTypes = []any{
 	(OrderStatus)(0),                 // 0: order.OrderStatus
 	(OrderEventType)(0),              // 1: order.OrderEventType
 	(*OrderItem)(nil),                // 2: order.OrderItem
 	(*Order)(nil),                    // 3: order.Order
-	(*OrderItemInput)(nil),           // 4: order.OrderItemInput
-	(*CreateOrderRequest)(nil),       // 5: order.CreateOrderRequest
-	(*GetOrderRequest)(nil),          // 6: order.GetOrderRequest
-	(*UpdateOrderStatusRequest)(nil), // 7: order.UpdateOrderStatusRequest
-	(*DeleteOrderRequest)(nil),       // 8: order.DeleteOrderRequest
-	(*ListOrdersRequest)(nil),        // 9: order.ListOrdersRequest
-	(*ListOrdersResponse)(nil),       // 10: order.ListOrdersResponse
-	(*OrderResponse)(nil),            // 11: order.OrderResponse
-	(*StreamOrdersRequest)(nil),      // 12: order.StreamOrdersRequest
-	(*OrderEvent)(nil),               // 13: order.OrderEvent
-	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
-	(*Empty)(nil),                    // 15: common.Empty
+	(*OrderStatusChange)(nil),        // 4: order.OrderStatusChange
+	(*OrderItemInput)(nil),           // 5: order.OrderItemInput
+	(*CreateOrderRequest)(nil),       // 6: order.CreateOrderRequest
+	(*OrderPriceChanged)(nil),        // 7: order.OrderPriceChanged
+	(*CheckoutRequest)(nil),          // 8: order.CheckoutRequest
+	(*CheckoutResponse)(nil),         // 9: order.CheckoutResponse
+	(*GetOrderRequest)(nil),          // 10: order.GetOrderRequest
+	(*UpdateOrderStatusRequest)(nil), // 11: order.UpdateOrderStatusRequest
+	(*GetOrderHistoryRequest)(nil),   // 12: order.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil),  // 13: order.GetOrderHistoryResponse
+	(*DeleteOrderRequest)(nil),       // 14: order.DeleteOrderRequest
+	(*ListOrdersRequest)(nil),        // 15: order.ListOrdersRequest
+	(*ListMyOrdersRequest)(nil),      // 16: order.ListMyOrdersRequest
+	(*ListOrdersResponse)(nil),       // 17: order.ListOrdersResponse
+	(*OrderResponse)(nil),            // 18: order.OrderResponse
+	(*StreamOrdersRequest)(nil),      // 19: order.StreamOrdersRequest
+	(*OrderEvent)(nil),               // 20: order.OrderEvent
+	(*timestamppb.Timestamp)(nil),    // 21: google.protobuf.Timestamp
+	(*Empty)(nil),                    // 22: common.Empty
 }
 var file_order_proto_depIdxs = []int32{
-	14, // 0: order.OrderItem.created_at:type_name -> google.protobuf.Timestamp
+	21, // 0: order.OrderItem.created_at:type_name -> google.protobuf.Timestamp
 	0,  // 1: order.Order.status:type_name -> order.OrderStatus
 	2,  // 2: order.Order.items:type_name -> order.OrderItem
-	14, // 3: order.Order.created_at:type_name -> google.protobuf.Timestamp
-	14, // 4: order.Order.updated_at:type_name -> google.protobuf.Timestamp
-	14, // 5: order.Order.completed_at:type_name -> google.protobuf.Timestamp
-	4,  // 6: order.CreateOrderRequest.items:type_name -> order.OrderItemInput
-	0,  // 7: order.UpdateOrderStatusRequest.status:type_name -> order.OrderStatus
-	0,  // 8: order.ListOrdersRequest.statuses:type_name -> order.OrderStatus
-	3,  // 9: order.ListOrdersResponse.orders:type_name -> order.Order
-	3,  // 10: order.OrderResponse.order:type_name -> order.Order
-	0,  // 11: order.StreamOrdersRequest.statuses:type_name -> order.OrderStatus
-	1,  // 12: order.OrderEvent.type:type_name -> order.OrderEventType
-	3,  // 13: order.OrderEvent.order:type_name -> order.Order
-	5,  // 14: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
-	6,  // 15: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
-	7,  // 16: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
-	8,  // 17: order.OrderService.DeleteOrder:input_type -> order.DeleteOrderRequest
-	9,  // 18: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
-	12, // 19: order.OrderService.StreamOrders:input_type -> order.StreamOrdersRequest
-	11, // 20: order.OrderService.CreateOrder:output_type -> order.OrderResponse
-	11, // 21: order.OrderService.GetOrder:output_type -> order.OrderResponse
-	11, // 22: order.OrderService.UpdateOrderStatus:output_type -> order.OrderResponse
-	15, // 23: order.OrderService.DeleteOrder:output_type -> common.Empty
-	10, // 24: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
-	13, // 25: order.OrderService.StreamOrders:output_type -> order.OrderEvent
-	20, // [20:26] is the sub-list for method output_type
-	14, // [14:20] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	21, // 3: order.Order.created_at:type_name -> google.protobuf.Timestamp
+	21, // 4: order.Order.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 5: order.Order.completed_at:type_name -> google.protobuf.Timestamp
+	21, // 6: order.Order.confirmed_at:type_name -> google.protobuf.Timestamp
+	4,  // 7: order.Order.history:type_name -> order.OrderStatusChange
+	0,  // 8: order.OrderStatusChange.old_status:type_name -> order.OrderStatus
+	0,  // 9: order.OrderStatusChange.new_status:type_name -> order.OrderStatus
+	21, // 10: order.OrderStatusChange.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 11: order.CreateOrderRequest.items:type_name -> order.OrderItemInput
+	2,  // 12: order.OrderPriceChanged.items:type_name -> order.OrderItem
+	5,  // 13: order.CheckoutRequest.items:type_name -> order.OrderItemInput
+	3,  // 14: order.CheckoutResponse.orders:type_name -> order.Order
+	0,  // 15: order.UpdateOrderStatusRequest.status:type_name -> order.OrderStatus
+	4,  // 16: order.GetOrderHistoryResponse.history:type_name -> order.OrderStatusChange
+	0,  // 17: order.ListOrdersRequest.statuses:type_name -> order.OrderStatus
+	0,  // 18: order.ListMyOrdersRequest.statuses:type_name -> order.OrderStatus
+	3,  // 19: order.ListOrdersResponse.orders:type_name -> order.Order
+	3,  // 20: order.OrderResponse.order:type_name -> order.Order
+	0,  // 21: order.StreamOrdersRequest.statuses:type_name -> order.OrderStatus
+	1,  // 22: order.OrderEvent.type:type_name -> order.OrderEventType
+	3,  // 23: order.OrderEvent.order:type_name -> order.Order
+	6,  // 24: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	8,  // 25: order.OrderService.Checkout:input_type -> order.CheckoutRequest
+	10, // 26: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
+	11, // 27: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
+	12, // 28: order.OrderService.GetOrderHistory:input_type -> order.GetOrderHistoryRequest
+	14, // 29: order.OrderService.DeleteOrder:input_type -> order.DeleteOrderRequest
+	15, // 30: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
+	16, // 31: order.OrderService.ListMyOrders:input_type -> order.ListMyOrdersRequest
+	19, // 32: order.OrderService.StreamOrders:input_type -> order.StreamOrdersRequest
+	18, // 33: order.OrderService.CreateOrder:output_type -> order.OrderResponse
+	9,  // 34: order.OrderService.Checkout:output_type -> order.CheckoutResponse
+	18, // 35: order.OrderService.GetOrder:output_type -> order.OrderResponse
+	18, // 36: order.OrderService.UpdateOrderStatus:output_type -> order.OrderResponse
+	13, // 37: order.OrderService.GetOrderHistory:output_type -> order.GetOrderHistoryResponse
+	22, // 38: order.OrderService.DeleteOrder:output_type -> common.Empty
+	17, // 39: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
+	17, // 40: order.OrderService.ListMyOrders:output_type -> order.ListOrdersResponse
+	20, // 41: order.OrderService.StreamOrders:output_type -> order.OrderEvent
+	33, // [33:42] is the sub-list for method output_type
+	24, // [24:33] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -1193,7 +1882,7 @@ func file_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   12,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -275,8 +275,10 @@ type Product struct {
 	DiscountPercent int32 `protobuf:"varint,19,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent,omitempty"`
 	HasDiscount     bool  `protobuf:"varint,20,opt,name=has_discount,json=hasDiscount,proto3" json:"has_discount,omitempty"`
 	// Optional: shop info for list views
-	ShopName      string `protobuf:"bytes,21,opt,name=shop_name,json=shopName,proto3" json:"shop_name,omitempty"`
-	ShopLogo      string `protobuf:"bytes,22,opt,name=shop_logo,json=shopLogo,proto3" json:"shop_logo,omitempty"`
+	ShopName string `protobuf:"bytes,21,opt,name=shop_name,json=shopName,proto3" json:"shop_name,omitempty"`
+	ShopLogo string `protobuf:"bytes,22,opt,name=shop_logo,json=shopLogo,proto3" json:"shop_logo,omitempty"`
+	// Untracked products (no stock set) are always in stock; tracked ones while any stock is unreserved
+	InStock       bool `protobuf:"varint,23,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -465,6 +467,13 @@ func (x *Product) GetShopLogo() string {
 	return ""
 }
 
+func (x *Product) GetInStock() bool {
+	if x != nil {
+		return x.InStock
+	}
+	return false
+}
+
 // Filters for listing products
 type ProductFilters struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -475,8 +484,9 @@ type ProductFilters struct {
 	IsActive      bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
 	MinPrice      float64                `protobuf:"fixed64,6,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
 	MaxPrice      float64                `protobuf:"fixed64,7,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
-	Search        string                 `protobuf:"bytes,8,opt,name=search,proto3" json:"search,omitempty"`               // Search in name/description
-	SortBy        string                 `protobuf:"bytes,9,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"` // price_asc, price_desc, newest, popular
+	Search        string                 `protobuf:"bytes,8,opt,name=search,proto3" json:"search,omitempty"`                                  // Search in name/description
+	SortBy        string                 `protobuf:"bytes,9,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                    // price_asc, price_desc, newest, popular
+	InStockOnly   bool                   `protobuf:"varint,10,opt,name=in_stock_only,json=inStockOnly,proto3" json:"in_stock_only,omitempty"` // Leave out products that are out of stock
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -574,6 +584,13 @@ func (x *ProductFilters) GetSortBy() string {
 	return ""
 }
 
+func (x *ProductFilters) GetInStockOnly() bool {
+	if x != nil {
+		return x.InStockOnly
+	}
+	return false
+}
+
 type ListProductsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Filters       *ProductFilters        `protobuf:"bytes,1,opt,name=filters,proto3" json:"filters,omitempty"`
@@ -1806,6 +1823,592 @@ func (x *ListSellerProductsRequest) GetLimit() int32 {
 	return 0
 }
 
+// Stock of a product, or of one of its variants. A product is tracked either as a whole
+// or per variant; a product without stock levels is not tracked and never runs out.
+type StockLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VariantName   string                 `protobuf:"bytes,2,opt,name=variant_name,json=variantName,proto3" json:"variant_name,omitempty"` // Empty for product-level stock
+	VariantValue  string                 `protobuf:"bytes,3,opt,name=variant_value,json=variantValue,proto3" json:"variant_value,omitempty"`
+	OnHand        int32                  `protobuf:"varint,4,opt,name=on_hand,json=onHand,proto3" json:"on_hand,omitempty"` // Units in the warehouse
+	Reserved      int32                  `protobuf:"varint,5,opt,name=reserved,proto3" json:"reserved,omitempty"`           // Held by new, confirmed and shipping orders
+	Available     int32                  `protobuf:"varint,6,opt,name=available,proto3" json:"available,omitempty"`         // on_hand - reserved
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockLevel) Reset() {
+	*x = StockLevel{}
+	mi := &file_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockLevel) ProtoMessage() {}
+
+func (x *StockLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockLevel.ProtoReflect.Descriptor instead.
+func (*StockLevel) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StockLevel) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockLevel) GetVariantName() string {
+	if x != nil {
+		return x.VariantName
+	}
+	return ""
+}
+
+func (x *StockLevel) GetVariantValue() string {
+	if x != nil {
+		return x.VariantValue
+	}
+	return ""
+}
+
+func (x *StockLevel) GetOnHand() int32 {
+	if x != nil {
+		return x.OnHand
+	}
+	return 0
+}
+
+func (x *StockLevel) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *StockLevel) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *StockLevel) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type StockMovement struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId      string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VariantName    string                 `protobuf:"bytes,3,opt,name=variant_name,json=variantName,proto3" json:"variant_name,omitempty"`
+	VariantValue   string                 `protobuf:"bytes,4,opt,name=variant_value,json=variantValue,proto3" json:"variant_value,omitempty"`
+	Kind           string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"` // adjustment, reserve, release or commit
+	OnHandChange   int32                  `protobuf:"varint,6,opt,name=on_hand_change,json=onHandChange,proto3" json:"on_hand_change,omitempty"`
+	ReservedChange int32                  `protobuf:"varint,7,opt,name=reserved_change,json=reservedChange,proto3" json:"reserved_change,omitempty"`
+	OnHandAfter    int32                  `protobuf:"varint,8,opt,name=on_hand_after,json=onHandAfter,proto3" json:"on_hand_after,omitempty"`
+	ReservedAfter  int32                  `protobuf:"varint,9,opt,name=reserved_after,json=reservedAfter,proto3" json:"reserved_after,omitempty"`
+	OrderId        string                 `protobuf:"bytes,10,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // Set for reserve, release and commit
+	ActorId        string                 `protobuf:"bytes,11,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Note           string                 `protobuf:"bytes,12,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StockMovement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockMovement) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetVariantName() string {
+	if x != nil {
+		return x.VariantName
+	}
+	return ""
+}
+
+func (x *StockMovement) GetVariantValue() string {
+	if x != nil {
+		return x.VariantValue
+	}
+	return ""
+}
+
+func (x *StockMovement) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *StockMovement) GetOnHandChange() int32 {
+	if x != nil {
+		return x.OnHandChange
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReservedChange() int32 {
+	if x != nil {
+		return x.ReservedChange
+	}
+	return 0
+}
+
+func (x *StockMovement) GetOnHandAfter() int32 {
+	if x != nil {
+		return x.OnHandAfter
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReservedAfter() int32 {
+	if x != nil {
+		return x.ReservedAfter
+	}
+	return 0
+}
+
+func (x *StockMovement) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AdjustStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VariantName   string                 `protobuf:"bytes,2,opt,name=variant_name,json=variantName,proto3" json:"variant_name,omitempty"`    // Optional: ProductVariant.name
+	VariantValue  string                 `protobuf:"bytes,3,opt,name=variant_value,json=variantValue,proto3" json:"variant_value,omitempty"` // Optional: ProductVariant.value
+	Delta         int32                  `protobuf:"varint,4,opt,name=delta,proto3" json:"delta,omitempty"`                                  // Added to on_hand; negative for write-offs
+	OnHand        *int32                 `protobuf:"varint,5,opt,name=on_hand,json=onHand,proto3,oneof" json:"on_hand,omitempty"`            // Stocktake: sets on_hand instead of applying delta
+	Note          string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AdjustStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetVariantName() string {
+	if x != nil {
+		return x.VariantName
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetVariantValue() string {
+	if x != nil {
+		return x.VariantValue
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetOnHand() int32 {
+	if x != nil && x.OnHand != nil {
+		return *x.OnHand
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type StockLevelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stock         *StockLevel            `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockLevelResponse) Reset() {
+	*x = StockLevelResponse{}
+	mi := &file_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockLevelResponse) ProtoMessage() {}
+
+func (x *StockLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockLevelResponse.ProtoReflect.Descriptor instead.
+func (*StockLevelResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StockLevelResponse) GetStock() *StockLevel {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+type GetProductStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductStockRequest) Reset() {
+	*x = GetProductStockRequest{}
+	mi := &file_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductStockRequest) ProtoMessage() {}
+
+func (x *GetProductStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductStockRequest.ProtoReflect.Descriptor instead.
+func (*GetProductStockRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetProductStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type GetProductStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tracked       bool                   `protobuf:"varint,1,opt,name=tracked,proto3" json:"tracked,omitempty"`
+	Levels        []*StockLevel          `protobuf:"bytes,2,rep,name=levels,proto3" json:"levels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductStockResponse) Reset() {
+	*x = GetProductStockResponse{}
+	mi := &file_product_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductStockResponse) ProtoMessage() {}
+
+func (x *GetProductStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductStockResponse.ProtoReflect.Descriptor instead.
+func (*GetProductStockResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetProductStockResponse) GetTracked() bool {
+	if x != nil {
+		return x.Tracked
+	}
+	return false
+}
+
+func (x *GetProductStockResponse) GetLevels() []*StockLevel {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+type ListStockMovementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsRequest) Reset() {
+	*x = ListStockMovementsRequest{}
+	mi := &file_product_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsRequest) ProtoMessage() {}
+
+func (x *ListStockMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListStockMovementsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListStockMovementsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStockMovementsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListStockMovementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"` // Newest first
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsResponse) Reset() {
+	*x = ListStockMovementsResponse{}
+	mi := &file_product_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsResponse) ProtoMessage() {}
+
+func (x *ListStockMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListStockMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *ListStockMovementsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListStockMovementsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStockMovementsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 var File_product_proto protoreflect.FileDescriptor
 
 const file_product_proto_rawDesc = "" +
@@ -1831,7 +2434,7 @@ const file_product_proto_rawDesc = "" +
 	"\x06images\x18\x04 \x03(\tR\x06images\x127\n" +
 	"\n" +
 	"attributes\x18\x05 \x01(\v2\x17.google.protobuf.StructR\n" +
-	"attributes\"\xa8\x06\n" +
+	"attributes\"\xc3\x06\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\ashop_id\x18\x02 \x01(\tR\x06shopId\x12\x1f\n" +
@@ -1860,7 +2463,8 @@ const file_product_proto_rawDesc = "" +
 	"\x10discount_percent\x18\x13 \x01(\x05R\x0fdiscountPercent\x12!\n" +
 	"\fhas_discount\x18\x14 \x01(\bR\vhasDiscount\x12\x1b\n" +
 	"\tshop_name\x18\x15 \x01(\tR\bshopName\x12\x1b\n" +
-	"\tshop_logo\x18\x16 \x01(\tR\bshopLogo\"\x88\x02\n" +
+	"\tshop_logo\x18\x16 \x01(\tR\bshopLogo\x12\x19\n" +
+	"\bin_stock\x18\x17 \x01(\bR\ainStock\"\xac\x02\n" +
 	"\x0eProductFilters\x12\x1f\n" +
 	"\vcategory_id\x18\x01 \x01(\tR\n" +
 	"categoryId\x12\x17\n" +
@@ -1872,7 +2476,9 @@ const file_product_proto_rawDesc = "" +
 	"\tmin_price\x18\x06 \x01(\x01R\bminPrice\x12\x1b\n" +
 	"\tmax_price\x18\a \x01(\x01R\bmaxPrice\x12\x16\n" +
 	"\x06search\x18\b \x01(\tR\x06search\x12\x17\n" +
-	"\asort_by\x18\t \x01(\tR\x06sortBy\"r\n" +
+	"\asort_by\x18\t \x01(\tR\x06sortBy\x12\"\n" +
+	"\rin_stock_only\x18\n" +
+	" \x01(\bR\vinStockOnly\"r\n" +
 	"\x13ListProductsRequest\x121\n" +
 	"\afilters\x18\x01 \x01(\v2\x17.product.ProductFiltersR\afilters\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
@@ -1981,7 +2587,64 @@ const file_product_proto_rawDesc = "" +
 	"\ashop_id\x18\x01 \x01(\tR\x06shopId\x121\n" +
 	"\afilters\x18\x02 \x01(\v2\x17.product.ProductFiltersR\afilters\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit2\x87\b\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x81\x02\n" +
+	"\n" +
+	"StockLevel\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fvariant_name\x18\x02 \x01(\tR\vvariantName\x12#\n" +
+	"\rvariant_value\x18\x03 \x01(\tR\fvariantValue\x12\x17\n" +
+	"\aon_hand\x18\x04 \x01(\x05R\x06onHand\x12\x1a\n" +
+	"\breserved\x18\x05 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\x06 \x01(\x05R\tavailable\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb9\x03\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12!\n" +
+	"\fvariant_name\x18\x03 \x01(\tR\vvariantName\x12#\n" +
+	"\rvariant_value\x18\x04 \x01(\tR\fvariantValue\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12$\n" +
+	"\x0eon_hand_change\x18\x06 \x01(\x05R\fonHandChange\x12'\n" +
+	"\x0freserved_change\x18\a \x01(\x05R\x0ereservedChange\x12\"\n" +
+	"\ron_hand_after\x18\b \x01(\x05R\vonHandAfter\x12%\n" +
+	"\x0ereserved_after\x18\t \x01(\x05R\rreservedAfter\x12\x19\n" +
+	"\border_id\x18\n" +
+	" \x01(\tR\aorderId\x12\x19\n" +
+	"\bactor_id\x18\v \x01(\tR\aactorId\x12\x12\n" +
+	"\x04note\x18\f \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xcf\x01\n" +
+	"\x12AdjustStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fvariant_name\x18\x02 \x01(\tR\vvariantName\x12#\n" +
+	"\rvariant_value\x18\x03 \x01(\tR\fvariantValue\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\x05R\x05delta\x12\x1c\n" +
+	"\aon_hand\x18\x05 \x01(\x05H\x00R\x06onHand\x88\x01\x01\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04noteB\n" +
+	"\n" +
+	"\b_on_hand\"?\n" +
+	"\x12StockLevelResponse\x12)\n" +
+	"\x05stock\x18\x01 \x01(\v2\x13.product.StockLevelR\x05stock\"7\n" +
+	"\x16GetProductStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"`\n" +
+	"\x17GetProductStockResponse\x12\x18\n" +
+	"\atracked\x18\x01 \x01(\bR\atracked\x12+\n" +
+	"\x06levels\x18\x02 \x03(\v2\x13.product.StockLevelR\x06levels\"d\n" +
+	"\x19ListStockMovementsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x92\x01\n" +
+	"\x1aListStockMovementsResponse\x124\n" +
+	"\tmovements\x18\x01 \x03(\v2\x16.product.StockMovementR\tmovements\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit2\x85\n" +
+	"\n" +
 	"\x0eProductService\x12B\n" +
 	"\n" +
 	"GetProduct\x12\x1a.product.GetProductRequest\x1a\x18.product.ProductResponse\x12K\n" +
@@ -1993,7 +2656,10 @@ const file_product_proto_rawDesc = "" +
 	"\rCreateProduct\x12\x1d.product.CreateProductRequest\x1a\x18.product.ProductResponse\x12H\n" +
 	"\rUpdateProduct\x12\x1d.product.UpdateProductRequest\x1a\x18.product.ProductResponse\x12=\n" +
 	"\rDeleteProduct\x12\x1d.product.DeleteProductRequest\x1a\r.common.Empty\x12T\n" +
-	"\x13ToggleProductStatus\x12#.product.ToggleProductStatusRequest\x1a\x18.product.ProductResponse\x12Q\n" +
+	"\x13ToggleProductStatus\x12#.product.ToggleProductStatusRequest\x1a\x18.product.ProductResponse\x12G\n" +
+	"\vAdjustStock\x12\x1b.product.AdjustStockRequest\x1a\x1b.product.StockLevelResponse\x12T\n" +
+	"\x0fGetProductStock\x12\x1f.product.GetProductStockRequest\x1a .product.GetProductStockResponse\x12]\n" +
+	"\x12ListStockMovements\x12\".product.ListStockMovementsRequest\x1a#.product.ListStockMovementsResponse\x12Q\n" +
 	"\x12UploadProductImage\x12\x1b.product.UploadImageRequest\x1a\x1c.product.UploadImageResponse(\x01\x12W\n" +
 	"\x13UploadProductImages\x12\x1b.product.UploadImageRequest\x1a!.product.BulkUploadImagesResponse(\x01B\x1cZ\x1amebellar-backend/pkg/pb;pbb\x06proto3"
 
@@ -2009,7 +2675,7 @@ func file_product_proto_rawDescGZIP() []byte {
 	return file_product_proto_rawDescData
 }
 
-var file_product_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_product_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_product_proto_goTypes = []any{
 	(*RegionalPriceGroup)(nil),                       // 0: product.RegionalPriceGroup
 	(*DeliverySettings)(nil),                         // 1: product.DeliverySettings
@@ -2034,67 +2700,86 @@ var file_product_proto_goTypes = []any{
 	(*ListProductsGroupedBySubcategoryRequest)(nil),  // 20: product.ListProductsGroupedBySubcategoryRequest
 	(*ListProductsGroupedBySubcategoryResponse)(nil), // 21: product.ListProductsGroupedBySubcategoryResponse
 	(*ListSellerProductsRequest)(nil),                // 22: product.ListSellerProductsRequest
-	(*structpb.Struct)(nil),                          // 23: google.protobuf.Struct
-	(*LocalizedString)(nil),                          // 24: common.LocalizedString
-	(*timestamppb.Timestamp)(nil),                    // 25: google.protobuf.Timestamp
-	(*Empty)(nil),                                    // 26: common.Empty
+	(*StockLevel)(nil),                               // 23: product.StockLevel
+	(*StockMovement)(nil),                            // 24: product.StockMovement
+	(*AdjustStockRequest)(nil),                       // 25: product.AdjustStockRequest
+	(*StockLevelResponse)(nil),                       // 26: product.StockLevelResponse
+	(*GetProductStockRequest)(nil),                   // 27: product.GetProductStockRequest
+	(*GetProductStockResponse)(nil),                  // 28: product.GetProductStockResponse
+	(*ListStockMovementsRequest)(nil),                // 29: product.ListStockMovementsRequest
+	(*ListStockMovementsResponse)(nil),               // 30: product.ListStockMovementsResponse
+	(*structpb.Struct)(nil),                          // 31: google.protobuf.Struct
+	(*LocalizedString)(nil),                          // 32: common.LocalizedString
+	(*timestamppb.Timestamp)(nil),                    // 33: google.protobuf.Timestamp
+	(*Empty)(nil),                                    // 34: common.Empty
 }
 var file_product_proto_depIdxs = []int32{
 	0,  // 0: product.DeliverySettings.regional_prices:type_name -> product.RegionalPriceGroup
-	23, // 1: product.ProductVariant.attributes:type_name -> google.protobuf.Struct
-	24, // 2: product.Product.name:type_name -> common.LocalizedString
-	24, // 3: product.Product.description:type_name -> common.LocalizedString
-	23, // 4: product.Product.specs:type_name -> google.protobuf.Struct
+	31, // 1: product.ProductVariant.attributes:type_name -> google.protobuf.Struct
+	32, // 2: product.Product.name:type_name -> common.LocalizedString
+	32, // 3: product.Product.description:type_name -> common.LocalizedString
+	31, // 4: product.Product.specs:type_name -> google.protobuf.Struct
 	2,  // 5: product.Product.variants:type_name -> product.ProductVariant
 	1,  // 6: product.Product.delivery_settings:type_name -> product.DeliverySettings
-	25, // 7: product.Product.created_at:type_name -> google.protobuf.Timestamp
+	33, // 7: product.Product.created_at:type_name -> google.protobuf.Timestamp
 	4,  // 8: product.ListProductsRequest.filters:type_name -> product.ProductFilters
 	3,  // 9: product.ListProductsResponse.products:type_name -> product.Product
 	3,  // 10: product.ProductResponse.product:type_name -> product.Product
-	24, // 11: product.CreateProductRequest.name:type_name -> common.LocalizedString
-	24, // 12: product.CreateProductRequest.description:type_name -> common.LocalizedString
-	23, // 13: product.CreateProductRequest.specs:type_name -> google.protobuf.Struct
+	32, // 11: product.CreateProductRequest.name:type_name -> common.LocalizedString
+	32, // 12: product.CreateProductRequest.description:type_name -> common.LocalizedString
+	31, // 13: product.CreateProductRequest.specs:type_name -> google.protobuf.Struct
 	2,  // 14: product.CreateProductRequest.variants:type_name -> product.ProductVariant
 	1,  // 15: product.CreateProductRequest.delivery_settings:type_name -> product.DeliverySettings
-	24, // 16: product.UpdateProductRequest.name:type_name -> common.LocalizedString
-	24, // 17: product.UpdateProductRequest.description:type_name -> common.LocalizedString
-	23, // 18: product.UpdateProductRequest.specs:type_name -> google.protobuf.Struct
+	32, // 16: product.UpdateProductRequest.name:type_name -> common.LocalizedString
+	32, // 17: product.UpdateProductRequest.description:type_name -> common.LocalizedString
+	31, // 18: product.UpdateProductRequest.specs:type_name -> google.protobuf.Struct
 	2,  // 19: product.UpdateProductRequest.variants:type_name -> product.ProductVariant
 	1,  // 20: product.UpdateProductRequest.delivery_settings:type_name -> product.DeliverySettings
 	14, // 21: product.UploadImageRequest.metadata:type_name -> product.ImageMetadata
-	24, // 22: product.CategoryProductsGroup.category_name:type_name -> common.LocalizedString
+	32, // 22: product.CategoryProductsGroup.category_name:type_name -> common.LocalizedString
 	3,  // 23: product.CategoryProductsGroup.products:type_name -> product.Product
 	19, // 24: product.ListProductsGroupedBySubcategoryResponse.groups:type_name -> product.CategoryProductsGroup
 	4,  // 25: product.ListSellerProductsRequest.filters:type_name -> product.ProductFilters
-	7,  // 26: product.ProductService.GetProduct:input_type -> product.GetProductRequest
-	5,  // 27: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
-	17, // 28: product.ProductService.ListNewArrivals:input_type -> product.ListNewArrivalsRequest
-	18, // 29: product.ProductService.ListPopularProducts:input_type -> product.ListPopularProductsRequest
-	20, // 30: product.ProductService.ListProductsGroupedBySubcategory:input_type -> product.ListProductsGroupedBySubcategoryRequest
-	22, // 31: product.ProductService.ListSellerProducts:input_type -> product.ListSellerProductsRequest
-	9,  // 32: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
-	10, // 33: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
-	11, // 34: product.ProductService.DeleteProduct:input_type -> product.DeleteProductRequest
-	12, // 35: product.ProductService.ToggleProductStatus:input_type -> product.ToggleProductStatusRequest
-	13, // 36: product.ProductService.UploadProductImage:input_type -> product.UploadImageRequest
-	13, // 37: product.ProductService.UploadProductImages:input_type -> product.UploadImageRequest
-	8,  // 38: product.ProductService.GetProduct:output_type -> product.ProductResponse
-	6,  // 39: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
-	6,  // 40: product.ProductService.ListNewArrivals:output_type -> product.ListProductsResponse
-	6,  // 41: product.ProductService.ListPopularProducts:output_type -> product.ListProductsResponse
-	21, // 42: product.ProductService.ListProductsGroupedBySubcategory:output_type -> product.ListProductsGroupedBySubcategoryResponse
-	6,  // 43: product.ProductService.ListSellerProducts:output_type -> product.ListProductsResponse
-	8,  // 44: product.ProductService.CreateProduct:output_type -> product.ProductResponse
-	8,  // 45: product.ProductService.UpdateProduct:output_type -> product.ProductResponse
-	26, // 46: product.ProductService.DeleteProduct:output_type -> common.Empty
-	8,  // 47: product.ProductService.ToggleProductStatus:output_type -> product.ProductResponse
-	15, // 48: product.ProductService.UploadProductImage:output_type -> product.UploadImageResponse
-	16, // 49: product.ProductService.UploadProductImages:output_type -> product.BulkUploadImagesResponse
-	38, // [38:50] is the sub-list for method output_type
-	26, // [26:38] is the sub-list for method input_type
-	26, // [26:26] is the sub-list for extension type_name
-	26, // [26:26] is the sub-list for extension extendee
-	0,  // [0:26] is the sub-list for field type_name
+	33, // 26: product.StockLevel.updated_at:type_name -> google.protobuf.Timestamp
+	33, // 27: product.StockMovement.created_at:type_name -> google.protobuf.Timestamp
+	23, // 28: product.StockLevelResponse.stock:type_name -> product.StockLevel
+	23, // 29: product.GetProductStockResponse.levels:type_name -> product.StockLevel
+	24, // 30: product.ListStockMovementsResponse.movements:type_name -> product.StockMovement
+	7,  // 31: product.ProductService.GetProduct:input_type -> product.GetProductRequest
+	5,  // 32: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
+	17, // 33: product.ProductService.ListNewArrivals:input_type -> product.ListNewArrivalsRequest
+	18, // 34: product.ProductService.ListPopularProducts:input_type -> product.ListPopularProductsRequest
+	20, // 35: product.ProductService.ListProductsGroupedBySubcategory:input_type -> product.ListProductsGroupedBySubcategoryRequest
+	22, // 36: product.ProductService.ListSellerProducts:input_type -> product.ListSellerProductsRequest
+	9,  // 37: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
+	10, // 38: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
+	11, // 39: product.ProductService.DeleteProduct:input_type -> product.DeleteProductRequest
+	12, // 40: product.ProductService.ToggleProductStatus:input_type -> product.ToggleProductStatusRequest
+	25, // 41: product.ProductService.AdjustStock:input_type -> product.AdjustStockRequest
+	27, // 42: product.ProductService.GetProductStock:input_type -> product.GetProductStockRequest
+	29, // 43: product.ProductService.ListStockMovements:input_type -> product.ListStockMovementsRequest
+	13, // 44: product.ProductService.UploadProductImage:input_type -> product.UploadImageRequest
+	13, // 45: product.ProductService.UploadProductImages:input_type -> product.UploadImageRequest
+	8,  // 46: product.ProductService.GetProduct:output_type -> product.ProductResponse
+	6,  // 47: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
+	6,  // 48: product.ProductService.ListNewArrivals:output_type -> product.ListProductsResponse
+	6,  // 49: product.ProductService.ListPopularProducts:output_type -> product.ListProductsResponse
+	21, // 50: product.ProductService.ListProductsGroupedBySubcategory:output_type -> product.ListProductsGroupedBySubcategoryResponse
+	6,  // 51: product.ProductService.ListSellerProducts:output_type -> product.ListProductsResponse
+	8,  // 52: product.ProductService.CreateProduct:output_type -> product.ProductResponse
+	8,  // 53: product.ProductService.UpdateProduct:output_type -> product.ProductResponse
+	34, // 54: product.ProductService.DeleteProduct:output_type -> common.Empty
+	8,  // 55: product.ProductService.ToggleProductStatus:output_type -> product.ProductResponse
+	26, // 56: product.ProductService.AdjustStock:output_type -> product.StockLevelResponse
+	28, // 57: product.ProductService.GetProductStock:output_type -> product.GetProductStockResponse
+	30, // 58: product.ProductService.ListStockMovements:output_type -> product.ListStockMovementsResponse
+	15, // 59: product.ProductService.UploadProductImage:output_type -> product.UploadImageResponse
+	16, // 60: product.ProductService.UploadProductImages:output_type -> product.BulkUploadImagesResponse
+	46, // [46:61] is the sub-list for method output_type
+	31, // [31:46] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_product_proto_init() }
@@ -2108,13 +2793,14 @@ func file_product_proto_init() {
 		(*UploadImageRequest_Metadata)(nil),
 		(*UploadImageRequest_Chunk)(nil),
 	}
+	file_product_proto_msgTypes[25].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_proto_rawDesc), len(file_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,6 +29,9 @@ const (
 	ProductService_UpdateProduct_FullMethodName                    = "/product.ProductService/UpdateProduct"
 	ProductService_DeleteProduct_FullMethodName                    = "/product.ProductService/DeleteProduct"
 	ProductService_ToggleProductStatus_FullMethodName              = "/product.ProductService/ToggleProductStatus"
+	ProductService_AdjustStock_FullMethodName                      = "/product.ProductService/AdjustStock"
+	ProductService_GetProductStock_FullMethodName                  = "/product.ProductService/GetProductStock"
+	ProductService_ListStockMovements_FullMethodName               = "/product.ProductService/ListStockMovements"
 	ProductService_UploadProductImage_FullMethodName               = "/product.ProductService/UploadProductImage"
 	ProductService_UploadProductImages_FullMethodName              = "/product.ProductService/UploadProductImages"
 )
@@ -49,6 +52,10 @@ type ProductServiceClient interface {
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*ProductResponse, error)
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*Empty, error)
 	ToggleProductStatus(ctx context.Context, in *ToggleProductStatusRequest, opts ...grpc.CallOption) (*ProductResponse, error)
+	// Inventory (requires auth + shop role)
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockLevelResponse, error)
+	GetProductStock(ctx context.Context, in *GetProductStockRequest, opts ...grpc.CallOption) (*GetProductStockResponse, error)
+	ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error)
 	// Image upload via streaming
 	UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadImageRequest, UploadImageResponse], error)
 	UploadProductImages(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadImageRequest, BulkUploadImagesResponse], error)
@@ -162,6 +169,36 @@ func (c *productServiceClient) ToggleProductStatus(ctx context.Context, in *Togg
 	return out, nil
 }
 
+func (c *productServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockLevelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockLevelResponse)
+	err := c.cc.Invoke(ctx, ProductService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetProductStock(ctx context.Context, in *GetProductStockRequest, opts ...grpc.CallOption) (*GetProductStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductStockResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetProductStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStockMovementsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListStockMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadImageRequest, UploadImageResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[0], ProductService_UploadProductImage_FullMethodName, cOpts...)
@@ -204,6 +241,10 @@ type ProductServiceServer interface {
 	UpdateProduct(context.Context, *UpdateProductRequest) (*ProductResponse, error)
 	DeleteProduct(context.Context, *DeleteProductRequest) (*Empty, error)
 	ToggleProductStatus(context.Context, *ToggleProductStatusRequest) (*ProductResponse, error)
+	// Inventory (requires auth + shop role)
+	AdjustStock(context.Context, *AdjustStockRequest) (*StockLevelResponse, error)
+	GetProductStock(context.Context, *GetProductStockRequest) (*GetProductStockResponse, error)
+	ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error)
 	// Image upload via streaming
 	UploadProductImage(grpc.ClientStreamingServer[UploadImageRequest, UploadImageResponse]) error
 	UploadProductImages(grpc.ClientStreamingServer[UploadImageRequest, BulkUploadImagesResponse]) error
@@ -247,6 +288,15 @@ func (UnimplementedProductServiceServer) DeleteProduct(context.Context, *DeleteP
 func (UnimplementedProductServiceServer) ToggleProductStatus(context.Context, *ToggleProductStatusRequest) (*ProductResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ToggleProductStatus not implemented")
 }
+func (UnimplementedProductServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*StockLevelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedProductServiceServer) GetProductStock(context.Context, *GetProductStockRequest) (*GetProductStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductStock not implemented")
+}
+func (UnimplementedProductServiceServer) ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListStockMovements not implemented")
+}
 func (UnimplementedProductServiceServer) UploadProductImage(grpc.ClientStreamingServer[UploadImageRequest, UploadImageResponse]) error {
 	return status.Error(codes.Unimplemented, "method UploadProductImage not implemented")
 }
@@ -454,6 +504,60 @@ func _ProductService_ToggleProductStatus_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProductStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductStock(ctx, req.(*GetProductStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListStockMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListStockMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListStockMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListStockMovements(ctx, req.(*ListStockMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductService_UploadProductImage_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ProductServiceServer).UploadProductImage(&grpc.GenericServerStream[UploadImageRequest, UploadImageResponse]{ServerStream: stream})
 }
@@ -515,6 +619,18 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ToggleProductStatus",
 			Handler:    _ProductService_ToggleProductStatus_Handler,
 		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _ProductService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "GetProductStock",
+			Handler:    _ProductService_GetProductStock_Handler,
+		},
+		{
+			MethodName: "ListStockMovements",
+			Handler:    _ProductService_ListStockMovements_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2276,6 +2276,826 @@ func (x *UploadShopImageResponse) GetMessage() string {
 	return ""
 }
 
+// Staff member of a shop. The owner is listed too, with an empty id.
+type ShopMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShopId        string                 `protobuf:"bytes,2,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // empty until the invite is accepted
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	FullName      string                 `protobuf:"bytes,5,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`     // owner | manager | operator | viewer
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // invited | active
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShopMember) Reset() {
+	*x = ShopMember{}
+	mi := &file_shop_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShopMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShopMember) ProtoMessage() {}
+
+func (x *ShopMember) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShopMember.ProtoReflect.Descriptor instead.
+func (*ShopMember) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ShopMember) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShopMember) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+func (x *ShopMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShopMember) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *ShopMember) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *ShopMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ShopMember) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ShopMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type InviteShopMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // manager | operator | viewer
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteShopMemberRequest) Reset() {
+	*x = InviteShopMemberRequest{}
+	mi := &file_shop_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteShopMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteShopMemberRequest) ProtoMessage() {}
+
+func (x *InviteShopMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteShopMemberRequest.ProtoReflect.Descriptor instead.
+func (*InviteShopMemberRequest) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *InviteShopMemberRequest) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+func (x *InviteShopMemberRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *InviteShopMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ShopMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *ShopMember            `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShopMemberResponse) Reset() {
+	*x = ShopMemberResponse{}
+	mi := &file_shop_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShopMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShopMemberResponse) ProtoMessage() {}
+
+func (x *ShopMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShopMemberResponse.ProtoReflect.Descriptor instead.
+func (*ShopMemberResponse) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ShopMemberResponse) GetMember() *ShopMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type ListShopMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShopMembersRequest) Reset() {
+	*x = ListShopMembersRequest{}
+	mi := &file_shop_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShopMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShopMembersRequest) ProtoMessage() {}
+
+func (x *ListShopMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShopMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListShopMembersRequest) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListShopMembersRequest) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+type ListShopMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*ShopMember          `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShopMembersResponse) Reset() {
+	*x = ListShopMembersResponse{}
+	mi := &file_shop_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShopMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShopMembersResponse) ProtoMessage() {}
+
+func (x *ListShopMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShopMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListShopMembersResponse) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListShopMembersResponse) GetMembers() []*ShopMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type UpdateShopMemberRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShopMemberRoleRequest) Reset() {
+	*x = UpdateShopMemberRoleRequest{}
+	mi := &file_shop_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShopMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShopMemberRoleRequest) ProtoMessage() {}
+
+func (x *UpdateShopMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShopMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShopMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateShopMemberRoleRequest) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+func (x *UpdateShopMemberRoleRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *UpdateShopMemberRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type RemoveShopMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveShopMemberRequest) Reset() {
+	*x = RemoveShopMemberRequest{}
+	mi := &file_shop_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveShopMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveShopMemberRequest) ProtoMessage() {}
+
+func (x *RemoveShopMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveShopMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveShopMemberRequest) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RemoveShopMemberRequest) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+func (x *RemoveShopMemberRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+// Accepts the invite sent to the caller's phone number.
+type AcceptShopInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptShopInviteRequest) Reset() {
+	*x = AcceptShopInviteRequest{}
+	mi := &file_shop_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptShopInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptShopInviteRequest) ProtoMessage() {}
+
+func (x *AcceptShopInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptShopInviteRequest.ProtoReflect.Descriptor instead.
+func (*AcceptShopInviteRequest) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AcceptShopInviteRequest) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+// API key of a shop. Calls made with it act as the user who created it, limited to its scopes.
+// Send it in the x-api-key metadata header instead of authorization.
+type ApiKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShopId        string                 `protobuf:"bytes,2,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Prefix        string                 `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"` // first characters of the key, for telling keys apart
+	Scopes        []string               `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"` // products:read | products:write | orders:read | orders:write
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // empty means no expiry
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	mi := &file_shop_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKey) ProtoMessage() {}
+
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ApiKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApiKey) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+func (x *ApiKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ApiKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *ApiKey) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ApiKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *ApiKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ApiKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresInDays int32                  `protobuf:"varint,4,opt,name=expires_in_days,json=expiresInDays,proto3" json:"expires_in_days,omitempty"` // 0 = no expiry
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyRequest) Reset() {
+	*x = CreateApiKeyRequest{}
+	mi := &file_shop_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyRequest) ProtoMessage() {}
+
+func (x *CreateApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateApiKeyRequest) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+func (x *CreateApiKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateApiKeyRequest) GetExpiresInDays() int32 {
+	if x != nil {
+		return x.ExpiresInDays
+	}
+	return 0
+}
+
+type CreateApiKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"` // shown only once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyResponse) Reset() {
+	*x = CreateApiKeyResponse{}
+	mi := &file_shop_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyResponse) ProtoMessage() {}
+
+func (x *CreateApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateApiKeyResponse) GetApiKey() *ApiKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateApiKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ListApiKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysRequest) Reset() {
+	*x = ListApiKeysRequest{}
+	mi := &file_shop_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysRequest) ProtoMessage() {}
+
+func (x *ListApiKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListApiKeysRequest) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListApiKeysRequest) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+type ListApiKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*ApiKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysResponse) Reset() {
+	*x = ListApiKeysResponse{}
+	mi := &file_shop_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysResponse) ProtoMessage() {}
+
+func (x *ListApiKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListApiKeysResponse) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListApiKeysResponse) GetApiKeys() []*ApiKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type RevokeApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShopId        string                 `protobuf:"bytes,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyRequest) Reset() {
+	*x = RevokeApiKeyRequest{}
+	mi := &file_shop_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyRequest) ProtoMessage() {}
+
+func (x *RevokeApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_shop_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RevokeApiKeyRequest) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+func (x *RevokeApiKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type AdminListShopsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SellerId      string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
@@ -2291,7 +3111,7 @@ type AdminListShopsRequest struct {
 
 func (x *AdminListShopsRequest) Reset() {
 	*x = AdminListShopsRequest{}
-	mi := &file_shop_proto_msgTypes[29]
+	mi := &file_shop_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2303,7 +3123,7 @@ func (x *AdminListShopsRequest) String() string {
 func (*AdminListShopsRequest) ProtoMessage() {}
 
 func (x *AdminListShopsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shop_proto_msgTypes[29]
+	mi := &file_shop_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2316,7 +3136,7 @@ func (x *AdminListShopsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdminListShopsRequest.ProtoReflect.Descriptor instead.
 func (*AdminListShopsRequest) Descriptor() ([]byte, []int) {
-	return file_shop_proto_rawDescGZIP(), []int{29}
+	return file_shop_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *AdminListShopsRequest) GetSellerId() string {
@@ -2383,7 +3203,7 @@ type AdminUpdateShopRequest struct {
 
 func (x *AdminUpdateShopRequest) Reset() {
 	*x = AdminUpdateShopRequest{}
-	mi := &file_shop_proto_msgTypes[30]
+	mi := &file_shop_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2395,7 +3215,7 @@ func (x *AdminUpdateShopRequest) String() string {
 func (*AdminUpdateShopRequest) ProtoMessage() {}
 
 func (x *AdminUpdateShopRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shop_proto_msgTypes[30]
+	mi := &file_shop_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2408,7 +3228,7 @@ func (x *AdminUpdateShopRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdminUpdateShopRequest.ProtoReflect.Descriptor instead.
 func (*AdminUpdateShopRequest) Descriptor() ([]byte, []int) {
-	return file_shop_proto_rawDescGZIP(), []int{30}
+	return file_shop_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *AdminUpdateShopRequest) GetId() string {
@@ -2692,7 +3512,66 @@ const file_shop_proto_rawDesc = "" +
 	"\x17UploadShopImageResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
 	"\timage_url\x18\x02 \x01(\tR\bimageUrl\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\xd9\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xe8\x01\n" +
+	"\n" +
+	"ShopMember\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\ashop_id\x18\x02 \x01(\tR\x06shopId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x1b\n" +
+	"\tfull_name\x18\x05 \x01(\tR\bfullName\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\\\n" +
+	"\x17InviteShopMemberRequest\x12\x17\n" +
+	"\ashop_id\x18\x01 \x01(\tR\x06shopId\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\">\n" +
+	"\x12ShopMemberResponse\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.shop.ShopMemberR\x06member\"1\n" +
+	"\x16ListShopMembersRequest\x12\x17\n" +
+	"\ashop_id\x18\x01 \x01(\tR\x06shopId\"E\n" +
+	"\x17ListShopMembersResponse\x12*\n" +
+	"\amembers\x18\x01 \x03(\v2\x10.shop.ShopMemberR\amembers\"g\n" +
+	"\x1bUpdateShopMemberRoleRequest\x12\x17\n" +
+	"\ashop_id\x18\x01 \x01(\tR\x06shopId\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"O\n" +
+	"\x17RemoveShopMemberRequest\x12\x17\n" +
+	"\ashop_id\x18\x01 \x01(\tR\x06shopId\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\"2\n" +
+	"\x17AcceptShopInviteRequest\x12\x17\n" +
+	"\ashop_id\x18\x01 \x01(\tR\x06shopId\"\xc8\x02\n" +
+	"\x06ApiKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\ashop_id\x18\x02 \x01(\tR\x06shopId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x04 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x82\x01\n" +
+	"\x13CreateApiKeyRequest\x12\x17\n" +
+	"\ashop_id\x18\x01 \x01(\tR\x06shopId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12&\n" +
+	"\x0fexpires_in_days\x18\x04 \x01(\x05R\rexpiresInDays\"O\n" +
+	"\x14CreateApiKeyResponse\x12%\n" +
+	"\aapi_key\x18\x01 \x01(\v2\f.shop.ApiKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"-\n" +
+	"\x12ListApiKeysRequest\x12\x17\n" +
+	"\ashop_id\x18\x01 \x01(\tR\x06shopId\">\n" +
+	"\x13ListApiKeysResponse\x12'\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\f.shop.ApiKeyR\aapiKeys\">\n" +
+	"\x13RevokeApiKeyRequest\x12\x17\n" +
+	"\ashop_id\x18\x01 \x01(\tR\x06shopId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xd9\x01\n" +
 	"\x15AdminListShopsRequest\x12\x1b\n" +
 	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12\x1b\n" +
 	"\tregion_id\x18\x02 \x01(\x05R\bregionId\x12\x1f\n" +
@@ -2716,7 +3595,7 @@ const file_shop_proto_rawDesc = "" +
 	"\x05_nameB\x0e\n" +
 	"\f_descriptionB\n" +
 	"\n" +
-	"\b_address2\xc6\t\n" +
+	"\b_address2\x8c\x0e\n" +
 	"\vShopService\x12?\n" +
 	"\rGetShopBySlug\x12\x1a.shop.GetShopBySlugRequest\x1a\x12.shop.ShopResponse\x12`\n" +
 	"\x16GetPublicSellerProfile\x12#.shop.GetPublicSellerProfileRequest\x1a!.shop.PublicSellerProfileResponse\x12?\n" +
@@ -2733,7 +3612,15 @@ const file_shop_proto_rawDesc = "" +
 	"\x0fUpgradeToSeller\x12\x1c.shop.UpgradeToSellerRequest\x1a\x1d.shop.UpgradeToSellerResponse\x12T\n" +
 	"\x13UpdateSellerProfile\x12 .shop.UpdateSellerProfileRequest\x1a\x1b.shop.SellerProfileResponse\x12L\n" +
 	"\x0fUpdateLegalInfo\x12\x1c.shop.UpdateLegalInfoRequest\x1a\x1b.shop.SellerProfileResponse\x12Z\n" +
-	"\x13DeleteSellerAccount\x12 .shop.DeleteSellerAccountRequest\x1a!.shop.DeleteSellerAccountResponse\x12P\n" +
+	"\x13DeleteSellerAccount\x12 .shop.DeleteSellerAccountRequest\x1a!.shop.DeleteSellerAccountResponse\x12K\n" +
+	"\x10InviteShopMember\x12\x1d.shop.InviteShopMemberRequest\x1a\x18.shop.ShopMemberResponse\x12N\n" +
+	"\x0fListShopMembers\x12\x1c.shop.ListShopMembersRequest\x1a\x1d.shop.ListShopMembersResponse\x12S\n" +
+	"\x14UpdateShopMemberRole\x12!.shop.UpdateShopMemberRoleRequest\x1a\x18.shop.ShopMemberResponse\x12@\n" +
+	"\x10RemoveShopMember\x12\x1d.shop.RemoveShopMemberRequest\x1a\r.common.Empty\x12K\n" +
+	"\x10AcceptShopInvite\x12\x1d.shop.AcceptShopInviteRequest\x1a\x18.shop.ShopMemberResponse\x12E\n" +
+	"\fCreateApiKey\x12\x19.shop.CreateApiKeyRequest\x1a\x1a.shop.CreateApiKeyResponse\x12B\n" +
+	"\vListApiKeys\x12\x18.shop.ListApiKeysRequest\x1a\x19.shop.ListApiKeysResponse\x128\n" +
+	"\fRevokeApiKey\x12\x19.shop.RevokeApiKeyRequest\x1a\r.common.Empty\x12P\n" +
 	"\x0fUploadShopImage\x12\x1c.shop.UploadShopImageRequest\x1a\x1d.shop.UploadShopImageResponse(\x01\x12F\n" +
 	"\x0eAdminListShops\x12\x1b.shop.AdminListShopsRequest\x1a\x17.shop.ListShopsResponse\x128\n" +
 	"\fAdminGetShop\x12\x14.shop.GetShopRequest\x1a\x12.shop.ShopResponse\x12C\n" +
@@ -2752,7 +3639,7 @@ func file_shop_proto_rawDescGZIP() []byte {
 	return file_shop_proto_rawDescData
 }
 
-var file_shop_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_shop_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_shop_proto_goTypes = []any{
 	(*DaySchedule)(nil),                   // 0: shop.DaySchedule
 	(*WorkingHours)(nil),                  // 1: shop.WorkingHours
@@ -2783,11 +3670,25 @@ var file_shop_proto_goTypes = []any{
 	(*UploadShopImageRequest)(nil),        // 26: shop.UploadShopImageRequest
 	(*ShopImageMetadata)(nil),             // 27: shop.ShopImageMetadata
 	(*UploadShopImageResponse)(nil),       // 28: shop.UploadShopImageResponse
-	(*AdminListShopsRequest)(nil),         // 29: shop.AdminListShopsRequest
-	(*AdminUpdateShopRequest)(nil),        // 30: shop.AdminUpdateShopRequest
-	(*LocalizedString)(nil),               // 31: common.LocalizedString
-	(*timestamppb.Timestamp)(nil),         // 32: google.protobuf.Timestamp
-	(*Empty)(nil),                         // 33: common.Empty
+	(*ShopMember)(nil),                    // 29: shop.ShopMember
+	(*InviteShopMemberRequest)(nil),       // 30: shop.InviteShopMemberRequest
+	(*ShopMemberResponse)(nil),            // 31: shop.ShopMemberResponse
+	(*ListShopMembersRequest)(nil),        // 32: shop.ListShopMembersRequest
+	(*ListShopMembersResponse)(nil),       // 33: shop.ListShopMembersResponse
+	(*UpdateShopMemberRoleRequest)(nil),   // 34: shop.UpdateShopMemberRoleRequest
+	(*RemoveShopMemberRequest)(nil),       // 35: shop.RemoveShopMemberRequest
+	(*AcceptShopInviteRequest)(nil),       // 36: shop.AcceptShopInviteRequest
+	(*ApiKey)(nil),                        // 37: shop.ApiKey
+	(*CreateApiKeyRequest)(nil),           // 38: shop.CreateApiKeyRequest
+	(*CreateApiKeyResponse)(nil),          // 39: shop.CreateApiKeyResponse
+	(*ListApiKeysRequest)(nil),            // 40: shop.ListApiKeysRequest
+	(*ListApiKeysResponse)(nil),           // 41: shop.ListApiKeysResponse
+	(*RevokeApiKeyRequest)(nil),           // 42: shop.RevokeApiKeyRequest
+	(*AdminListShopsRequest)(nil),         // 43: shop.AdminListShopsRequest
+	(*AdminUpdateShopRequest)(nil),        // 44: shop.AdminUpdateShopRequest
+	(*LocalizedString)(nil),               // 45: common.LocalizedString
+	(*timestamppb.Timestamp)(nil),         // 46: google.protobuf.Timestamp
+	(*Empty)(nil),                         // 47: common.Empty
 }
 var file_shop_proto_depIdxs = []int32{
 	0,  // 0: shop.WorkingHours.monday:type_name -> shop.DaySchedule
@@ -2797,84 +3698,108 @@ var file_shop_proto_depIdxs = []int32{
 	0,  // 4: shop.WorkingHours.friday:type_name -> shop.DaySchedule
 	0,  // 5: shop.WorkingHours.saturday:type_name -> shop.DaySchedule
 	0,  // 6: shop.WorkingHours.sunday:type_name -> shop.DaySchedule
-	31, // 7: shop.Shop.name:type_name -> common.LocalizedString
-	31, // 8: shop.Shop.description:type_name -> common.LocalizedString
-	31, // 9: shop.Shop.address:type_name -> common.LocalizedString
-	31, // 10: shop.Shop.region_name:type_name -> common.LocalizedString
+	45, // 7: shop.Shop.name:type_name -> common.LocalizedString
+	45, // 8: shop.Shop.description:type_name -> common.LocalizedString
+	45, // 9: shop.Shop.address:type_name -> common.LocalizedString
+	45, // 10: shop.Shop.region_name:type_name -> common.LocalizedString
 	1,  // 11: shop.Shop.working_hours:type_name -> shop.WorkingHours
-	32, // 12: shop.Shop.created_at:type_name -> google.protobuf.Timestamp
-	32, // 13: shop.Shop.updated_at:type_name -> google.protobuf.Timestamp
-	31, // 14: shop.SellerProfile.address:type_name -> common.LocalizedString
+	46, // 12: shop.Shop.created_at:type_name -> google.protobuf.Timestamp
+	46, // 13: shop.Shop.updated_at:type_name -> google.protobuf.Timestamp
+	45, // 14: shop.SellerProfile.address:type_name -> common.LocalizedString
 	2,  // 15: shop.SellerProfile.social_links:type_name -> shop.SocialLinks
 	1,  // 16: shop.SellerProfile.working_hours:type_name -> shop.WorkingHours
-	32, // 17: shop.SellerProfile.created_at:type_name -> google.protobuf.Timestamp
-	32, // 18: shop.SellerProfile.updated_at:type_name -> google.protobuf.Timestamp
-	31, // 19: shop.PublicSellerProfile.address:type_name -> common.LocalizedString
+	46, // 17: shop.SellerProfile.created_at:type_name -> google.protobuf.Timestamp
+	46, // 18: shop.SellerProfile.updated_at:type_name -> google.protobuf.Timestamp
+	45, // 19: shop.PublicSellerProfile.address:type_name -> common.LocalizedString
 	2,  // 20: shop.PublicSellerProfile.social_links:type_name -> shop.SocialLinks
 	1,  // 21: shop.PublicSellerProfile.working_hours:type_name -> shop.WorkingHours
 	3,  // 22: shop.ListShopsResponse.shops:type_name -> shop.Shop
 	3,  // 23: shop.GetMyShopsResponse.shops:type_name -> shop.Shop
 	3,  // 24: shop.ShopResponse.shop:type_name -> shop.Shop
-	31, // 25: shop.CreateShopRequest.name:type_name -> common.LocalizedString
-	31, // 26: shop.CreateShopRequest.description:type_name -> common.LocalizedString
-	31, // 27: shop.CreateShopRequest.address:type_name -> common.LocalizedString
+	45, // 25: shop.CreateShopRequest.name:type_name -> common.LocalizedString
+	45, // 26: shop.CreateShopRequest.description:type_name -> common.LocalizedString
+	45, // 27: shop.CreateShopRequest.address:type_name -> common.LocalizedString
 	1,  // 28: shop.CreateShopRequest.working_hours:type_name -> shop.WorkingHours
-	31, // 29: shop.UpdateShopRequest.name:type_name -> common.LocalizedString
-	31, // 30: shop.UpdateShopRequest.description:type_name -> common.LocalizedString
-	31, // 31: shop.UpdateShopRequest.address:type_name -> common.LocalizedString
+	45, // 29: shop.UpdateShopRequest.name:type_name -> common.LocalizedString
+	45, // 30: shop.UpdateShopRequest.description:type_name -> common.LocalizedString
+	45, // 31: shop.UpdateShopRequest.address:type_name -> common.LocalizedString
 	1,  // 32: shop.UpdateShopRequest.working_hours:type_name -> shop.WorkingHours
 	4,  // 33: shop.SellerProfileResponse.profile:type_name -> shop.SellerProfile
 	5,  // 34: shop.PublicSellerProfileResponse.profile:type_name -> shop.PublicSellerProfile
-	31, // 35: shop.UpgradeToSellerRequest.address:type_name -> common.LocalizedString
+	45, // 35: shop.UpgradeToSellerRequest.address:type_name -> common.LocalizedString
 	2,  // 36: shop.UpgradeToSellerRequest.social_links:type_name -> shop.SocialLinks
 	1,  // 37: shop.UpgradeToSellerRequest.working_hours:type_name -> shop.WorkingHours
 	4,  // 38: shop.UpgradeToSellerResponse.profile:type_name -> shop.SellerProfile
-	31, // 39: shop.UpdateSellerProfileRequest.address:type_name -> common.LocalizedString
+	45, // 39: shop.UpdateSellerProfileRequest.address:type_name -> common.LocalizedString
 	2,  // 40: shop.UpdateSellerProfileRequest.social_links:type_name -> shop.SocialLinks
 	1,  // 41: shop.UpdateSellerProfileRequest.working_hours:type_name -> shop.WorkingHours
 	27, // 42: shop.UploadShopImageRequest.metadata:type_name -> shop.ShopImageMetadata
-	31, // 43: shop.AdminUpdateShopRequest.name:type_name -> common.LocalizedString
-	31, // 44: shop.AdminUpdateShopRequest.description:type_name -> common.LocalizedString
-	31, // 45: shop.AdminUpdateShopRequest.address:type_name -> common.LocalizedString
-	11, // 46: shop.ShopService.GetShopBySlug:input_type -> shop.GetShopBySlugRequest
-	17, // 47: shop.ShopService.GetPublicSellerProfile:input_type -> shop.GetPublicSellerProfileRequest
-	8,  // 48: shop.ShopService.GetMyShops:input_type -> shop.GetMyShopsRequest
-	10, // 49: shop.ShopService.GetShop:input_type -> shop.GetShopRequest
-	13, // 50: shop.ShopService.CreateShop:input_type -> shop.CreateShopRequest
-	14, // 51: shop.ShopService.UpdateShop:input_type -> shop.UpdateShopRequest
-	15, // 52: shop.ShopService.DeleteShop:input_type -> shop.DeleteShopRequest
-	16, // 53: shop.ShopService.GetSellerProfile:input_type -> shop.GetSellerProfileRequest
-	20, // 54: shop.ShopService.UpgradeToSeller:input_type -> shop.UpgradeToSellerRequest
-	22, // 55: shop.ShopService.UpdateSellerProfile:input_type -> shop.UpdateSellerProfileRequest
-	23, // 56: shop.ShopService.UpdateLegalInfo:input_type -> shop.UpdateLegalInfoRequest
-	24, // 57: shop.ShopService.DeleteSellerAccount:input_type -> shop.DeleteSellerAccountRequest
-	26, // 58: shop.ShopService.UploadShopImage:input_type -> shop.UploadShopImageRequest
-	29, // 59: shop.ShopService.AdminListShops:input_type -> shop.AdminListShopsRequest
-	10, // 60: shop.ShopService.AdminGetShop:input_type -> shop.GetShopRequest
-	30, // 61: shop.ShopService.AdminUpdateShop:input_type -> shop.AdminUpdateShopRequest
-	15, // 62: shop.ShopService.AdminDeleteShop:input_type -> shop.DeleteShopRequest
-	12, // 63: shop.ShopService.GetShopBySlug:output_type -> shop.ShopResponse
-	19, // 64: shop.ShopService.GetPublicSellerProfile:output_type -> shop.PublicSellerProfileResponse
-	9,  // 65: shop.ShopService.GetMyShops:output_type -> shop.GetMyShopsResponse
-	12, // 66: shop.ShopService.GetShop:output_type -> shop.ShopResponse
-	12, // 67: shop.ShopService.CreateShop:output_type -> shop.ShopResponse
-	12, // 68: shop.ShopService.UpdateShop:output_type -> shop.ShopResponse
-	33, // 69: shop.ShopService.DeleteShop:output_type -> common.Empty
-	18, // 70: shop.ShopService.GetSellerProfile:output_type -> shop.SellerProfileResponse
-	21, // 71: shop.ShopService.UpgradeToSeller:output_type -> shop.UpgradeToSellerResponse
-	18, // 72: shop.ShopService.UpdateSellerProfile:output_type -> shop.SellerProfileResponse
-	18, // 73: shop.ShopService.UpdateLegalInfo:output_type -> shop.SellerProfileResponse
-	25, // 74: shop.ShopService.DeleteSellerAccount:output_type -> shop.DeleteSellerAccountResponse
-	28, // 75: shop.ShopService.UploadShopImage:output_type -> shop.UploadShopImageResponse
-	7,  // 76: shop.ShopService.AdminListShops:output_type -> shop.ListShopsResponse
-	12, // 77: shop.ShopService.AdminGetShop:output_type -> shop.ShopResponse
-	12, // 78: shop.ShopService.AdminUpdateShop:output_type -> shop.ShopResponse
-	33, // 79: shop.ShopService.AdminDeleteShop:output_type -> common.Empty
-	63, // [63:80] is the sub-list for method output_type
-	46, // [46:63] is the sub-list for method input_type
-	46, // [46:46] is the sub-list for extension type_name
-	46, // [46:46] is the sub-list for extension extendee
-	0,  // [0:46] is the sub-list for field type_name
+	46, // 43: shop.ShopMember.created_at:type_name -> google.protobuf.Timestamp
+	29, // 44: shop.ShopMemberResponse.member:type_name -> shop.ShopMember
+	29, // 45: shop.ListShopMembersResponse.members:type_name -> shop.ShopMember
+	46, // 46: shop.ApiKey.last_used_at:type_name -> google.protobuf.Timestamp
+	46, // 47: shop.ApiKey.expires_at:type_name -> google.protobuf.Timestamp
+	46, // 48: shop.ApiKey.created_at:type_name -> google.protobuf.Timestamp
+	37, // 49: shop.CreateApiKeyResponse.api_key:type_name -> shop.ApiKey
+	37, // 50: shop.ListApiKeysResponse.api_keys:type_name -> shop.ApiKey
+	45, // 51: shop.AdminUpdateShopRequest.name:type_name -> common.LocalizedString
+	45, // 52: shop.AdminUpdateShopRequest.description:type_name -> common.LocalizedString
+	45, // 53: shop.AdminUpdateShopRequest.address:type_name -> common.LocalizedString
+	11, // 54: shop.ShopService.GetShopBySlug:input_type -> shop.GetShopBySlugRequest
+	17, // 55: shop.ShopService.GetPublicSellerProfile:input_type -> shop.GetPublicSellerProfileRequest
+	8,  // 56: shop.ShopService.GetMyShops:input_type -> shop.GetMyShopsRequest
+	10, // 57: shop.ShopService.GetShop:input_type -> shop.GetShopRequest
+	13, // 58: shop.ShopService.CreateShop:input_type -> shop.CreateShopRequest
+	14, // 59: shop.ShopService.UpdateShop:input_type -> shop.UpdateShopRequest
+	15, // 60: shop.ShopService.DeleteShop:input_type -> shop.DeleteShopRequest
+	16, // 61: shop.ShopService.GetSellerProfile:input_type -> shop.GetSellerProfileRequest
+	20, // 62: shop.ShopService.UpgradeToSeller:input_type -> shop.UpgradeToSellerRequest
+	22, // 63: shop.ShopService.UpdateSellerProfile:input_type -> shop.UpdateSellerProfileRequest
+	23, // 64: shop.ShopService.UpdateLegalInfo:input_type -> shop.UpdateLegalInfoRequest
+	24, // 65: shop.ShopService.DeleteSellerAccount:input_type -> shop.DeleteSellerAccountRequest
+	30, // 66: shop.ShopService.InviteShopMember:input_type -> shop.InviteShopMemberRequest
+	32, // 67: shop.ShopService.ListShopMembers:input_type -> shop.ListShopMembersRequest
+	34, // 68: shop.ShopService.UpdateShopMemberRole:input_type -> shop.UpdateShopMemberRoleRequest
+	35, // 69: shop.ShopService.RemoveShopMember:input_type -> shop.RemoveShopMemberRequest
+	36, // 70: shop.ShopService.AcceptShopInvite:input_type -> shop.AcceptShopInviteRequest
+	38, // 71: shop.ShopService.CreateApiKey:input_type -> shop.CreateApiKeyRequest
+	40, // 72: shop.ShopService.ListApiKeys:input_type -> shop.ListApiKeysRequest
+	42, // 73: shop.ShopService.RevokeApiKey:input_type -> shop.RevokeApiKeyRequest
+	26, // 74: shop.ShopService.UploadShopImage:input_type -> shop.UploadShopImageRequest
+	43, // 75: shop.ShopService.AdminListShops:input_type -> shop.AdminListShopsRequest
+	10, // 76: shop.ShopService.AdminGetShop:input_type -> shop.GetShopRequest
+	44, // 77: shop.ShopService.AdminUpdateShop:input_type -> shop.AdminUpdateShopRequest
+	15, // 78: shop.ShopService.AdminDeleteShop:input_type -> shop.DeleteShopRequest
+	12, // 79: shop.ShopService.GetShopBySlug:output_type -> shop.ShopResponse
+	19, // 80: shop.ShopService.GetPublicSellerProfile:output_type -> shop.PublicSellerProfileResponse
+	9,  // 81: shop.ShopService.GetMyShops:output_type -> shop.GetMyShopsResponse
+	12, // 82: shop.ShopService.GetShop:output_type -> shop.ShopResponse
+	12, // 83: shop.ShopService.CreateShop:output_type -> shop.ShopResponse
+	12, // 84: shop.ShopService.UpdateShop:output_type -> shop.ShopResponse
+	47, // 85: shop.ShopService.DeleteShop:output_type -> common.Empty
+	18, // 86: shop.ShopService.GetSellerProfile:output_type -> shop.SellerProfileResponse
+	21, // 87: shop.ShopService.UpgradeToSeller:output_type -> shop.UpgradeToSellerResponse
+	18, // 88: shop.ShopService.UpdateSellerProfile:output_type -> shop.SellerProfileResponse
+	18, // 89: shop.ShopService.UpdateLegalInfo:output_type -> shop.SellerProfileResponse
+	25, // 90: shop.ShopService.DeleteSellerAccount:output_type -> shop.DeleteSellerAccountResponse
+	31, // 91: shop.ShopService.InviteShopMember:output_type -> shop.ShopMemberResponse
+	33, // 92: shop.ShopService.ListShopMembers:output_type -> shop.ListShopMembersResponse
+	31, // 93: shop.ShopService.UpdateShopMemberRole:output_type -> shop.ShopMemberResponse
+	47, // 94: shop.ShopService.RemoveShopMember:output_type -> common.Empty
+	31, // 95: shop.ShopService.AcceptShopInvite:output_type -> shop.ShopMemberResponse
+	39, // 96: shop.ShopService.CreateApiKey:output_type -> shop.CreateApiKeyResponse
+	41, // 97: shop.ShopService.ListApiKeys:output_type -> shop.ListApiKeysResponse
+	47, // 98: shop.ShopService.RevokeApiKey:output_type -> common.Empty
+	28, // 99: shop.ShopService.UploadShopImage:output_type -> shop.UploadShopImageResponse
+	7,  // 100: shop.ShopService.AdminListShops:output_type -> shop.ListShopsResponse
+	12, // 101: shop.ShopService.AdminGetShop:output_type -> shop.ShopResponse
+	12, // 102: shop.ShopService.AdminUpdateShop:output_type -> shop.ShopResponse
+	47, // 103: shop.ShopService.AdminDeleteShop:output_type -> common.Empty
+	79, // [79:104] is the sub-list for method output_type
+	54, // [54:79] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
 }
 
 func init() { file_shop_proto_init() }
@@ -2889,14 +3814,14 @@ func file_shop_proto_init() {
 		(*UploadShopImageRequest_Metadata)(nil),
 		(*UploadShopImageRequest_Chunk)(nil),
 	}
-	file_shop_proto_msgTypes[30].OneofWrappers = []any{}
+	file_shop_proto_msgTypes[44].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shop_proto_rawDesc), len(file_shop_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -31,6 +31,14 @@ const (
 	ShopService_UpdateSellerProfile_FullMethodName    = "/shop.ShopService/UpdateSellerProfile"
 	ShopService_UpdateLegalInfo_FullMethodName        = "/shop.ShopService/UpdateLegalInfo"
 	ShopService_DeleteSellerAccount_FullMethodName    = "/shop.ShopService/DeleteSellerAccount"
+	ShopService_InviteShopMember_FullMethodName       = "/shop.ShopService/InviteShopMember"
+	ShopService_ListShopMembers_FullMethodName        = "/shop.ShopService/ListShopMembers"
+	ShopService_UpdateShopMemberRole_FullMethodName   = "/shop.ShopService/UpdateShopMemberRole"
+	ShopService_RemoveShopMember_FullMethodName       = "/shop.ShopService/RemoveShopMember"
+	ShopService_AcceptShopInvite_FullMethodName       = "/shop.ShopService/AcceptShopInvite"
+	ShopService_CreateApiKey_FullMethodName           = "/shop.ShopService/CreateApiKey"
+	ShopService_ListApiKeys_FullMethodName            = "/shop.ShopService/ListApiKeys"
+	ShopService_RevokeApiKey_FullMethodName           = "/shop.ShopService/RevokeApiKey"
 	ShopService_UploadShopImage_FullMethodName        = "/shop.ShopService/UploadShopImage"
 	ShopService_AdminListShops_FullMethodName         = "/shop.ShopService/AdminListShops"
 	ShopService_AdminGetShop_FullMethodName           = "/shop.ShopService/AdminGetShop"
@@ -57,6 +65,16 @@ type ShopServiceClient interface {
 	UpdateSellerProfile(ctx context.Context, in *UpdateSellerProfileRequest, opts ...grpc.CallOption) (*SellerProfileResponse, error)
 	UpdateLegalInfo(ctx context.Context, in *UpdateLegalInfoRequest, opts ...grpc.CallOption) (*SellerProfileResponse, error)
 	DeleteSellerAccount(ctx context.Context, in *DeleteSellerAccountRequest, opts ...grpc.CallOption) (*DeleteSellerAccountResponse, error)
+	// Shop staff (owner and managers; managers only manage operators and viewers)
+	InviteShopMember(ctx context.Context, in *InviteShopMemberRequest, opts ...grpc.CallOption) (*ShopMemberResponse, error)
+	ListShopMembers(ctx context.Context, in *ListShopMembersRequest, opts ...grpc.CallOption) (*ListShopMembersResponse, error)
+	UpdateShopMemberRole(ctx context.Context, in *UpdateShopMemberRoleRequest, opts ...grpc.CallOption) (*ShopMemberResponse, error)
+	RemoveShopMember(ctx context.Context, in *RemoveShopMemberRequest, opts ...grpc.CallOption) (*Empty, error)
+	AcceptShopInvite(ctx context.Context, in *AcceptShopInviteRequest, opts ...grpc.CallOption) (*ShopMemberResponse, error)
+	// API keys (owner and managers)
+	CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error)
+	ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error)
+	RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*Empty, error)
 	// Shop image upload via streaming
 	UploadShopImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadShopImageRequest, UploadShopImageResponse], error)
 	// Admin endpoints (requires admin/moderator role)
@@ -194,6 +212,86 @@ func (c *shopServiceClient) DeleteSellerAccount(ctx context.Context, in *DeleteS
 	return out, nil
 }
 
+func (c *shopServiceClient) InviteShopMember(ctx context.Context, in *InviteShopMemberRequest, opts ...grpc.CallOption) (*ShopMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShopMemberResponse)
+	err := c.cc.Invoke(ctx, ShopService_InviteShopMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListShopMembers(ctx context.Context, in *ListShopMembersRequest, opts ...grpc.CallOption) (*ListShopMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShopMembersResponse)
+	err := c.cc.Invoke(ctx, ShopService_ListShopMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) UpdateShopMemberRole(ctx context.Context, in *UpdateShopMemberRoleRequest, opts ...grpc.CallOption) (*ShopMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShopMemberResponse)
+	err := c.cc.Invoke(ctx, ShopService_UpdateShopMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) RemoveShopMember(ctx context.Context, in *RemoveShopMemberRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ShopService_RemoveShopMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) AcceptShopInvite(ctx context.Context, in *AcceptShopInviteRequest, opts ...grpc.CallOption) (*ShopMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShopMemberResponse)
+	err := c.cc.Invoke(ctx, ShopService_AcceptShopInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateApiKeyResponse)
+	err := c.cc.Invoke(ctx, ShopService_CreateApiKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListApiKeysResponse)
+	err := c.cc.Invoke(ctx, ShopService_ListApiKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopServiceClient) RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ShopService_RevokeApiKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shopServiceClient) UploadShopImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadShopImageRequest, UploadShopImageResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &ShopService_ServiceDesc.Streams[0], ShopService_UploadShopImage_FullMethodName, cOpts...)
@@ -266,6 +364,16 @@ type ShopServiceServer interface {
 	UpdateSellerProfile(context.Context, *UpdateSellerProfileRequest) (*SellerProfileResponse, error)
 	UpdateLegalInfo(context.Context, *UpdateLegalInfoRequest) (*SellerProfileResponse, error)
 	DeleteSellerAccount(context.Context, *DeleteSellerAccountRequest) (*DeleteSellerAccountResponse, error)
+	// Shop staff (owner and managers; managers only manage operators and viewers)
+	InviteShopMember(context.Context, *InviteShopMemberRequest) (*ShopMemberResponse, error)
+	ListShopMembers(context.Context, *ListShopMembersRequest) (*ListShopMembersResponse, error)
+	UpdateShopMemberRole(context.Context, *UpdateShopMemberRoleRequest) (*ShopMemberResponse, error)
+	RemoveShopMember(context.Context, *RemoveShopMemberRequest) (*Empty, error)
+	AcceptShopInvite(context.Context, *AcceptShopInviteRequest) (*ShopMemberResponse, error)
+	// API keys (owner and managers)
+	CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error)
+	RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*Empty, error)
 	// Shop image upload via streaming
 	UploadShopImage(grpc.ClientStreamingServer[UploadShopImageRequest, UploadShopImageResponse]) error
 	// Admin endpoints (requires admin/moderator role)
@@ -319,6 +427,30 @@ func (UnimplementedShopServiceServer) UpdateLegalInfo(context.Context, *UpdateLe
 func (UnimplementedShopServiceServer) DeleteSellerAccount(context.Context, *DeleteSellerAccountRequest) (*DeleteSellerAccountResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteSellerAccount not implemented")
 }
+func (UnimplementedShopServiceServer) InviteShopMember(context.Context, *InviteShopMemberRequest) (*ShopMemberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InviteShopMember not implemented")
+}
+func (UnimplementedShopServiceServer) ListShopMembers(context.Context, *ListShopMembersRequest) (*ListShopMembersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListShopMembers not implemented")
+}
+func (UnimplementedShopServiceServer) UpdateShopMemberRole(context.Context, *UpdateShopMemberRoleRequest) (*ShopMemberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateShopMemberRole not implemented")
+}
+func (UnimplementedShopServiceServer) RemoveShopMember(context.Context, *RemoveShopMemberRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveShopMember not implemented")
+}
+func (UnimplementedShopServiceServer) AcceptShopInvite(context.Context, *AcceptShopInviteRequest) (*ShopMemberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptShopInvite not implemented")
+}
+func (UnimplementedShopServiceServer) CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateApiKey not implemented")
+}
+func (UnimplementedShopServiceServer) ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListApiKeys not implemented")
+}
+func (UnimplementedShopServiceServer) RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeApiKey not implemented")
+}
 func (UnimplementedShopServiceServer) UploadShopImage(grpc.ClientStreamingServer[UploadShopImageRequest, UploadShopImageResponse]) error {
 	return status.Error(codes.Unimplemented, "method UploadShopImage not implemented")
 }
@@ -571,6 +703,150 @@ func _ShopService_DeleteSellerAccount_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShopService_InviteShopMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteShopMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).InviteShopMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_InviteShopMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).InviteShopMember(ctx, req.(*InviteShopMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListShopMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShopMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListShopMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_ListShopMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListShopMembers(ctx, req.(*ListShopMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_UpdateShopMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShopMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).UpdateShopMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_UpdateShopMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).UpdateShopMemberRole(ctx, req.(*UpdateShopMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_RemoveShopMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveShopMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).RemoveShopMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_RemoveShopMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).RemoveShopMember(ctx, req.(*RemoveShopMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_AcceptShopInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptShopInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).AcceptShopInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_AcceptShopInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).AcceptShopInvite(ctx, req.(*AcceptShopInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).CreateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_CreateApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).CreateApiKey(ctx, req.(*CreateApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_ListApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).ListApiKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_ListApiKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).ListApiKeys(ctx, req.(*ListApiKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopService_RevokeApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopServiceServer).RevokeApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShopService_RevokeApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopServiceServer).RevokeApiKey(ctx, req.(*RevokeApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ShopService_UploadShopImage_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ShopServiceServer).UploadShopImage(&grpc.GenericServerStream[UploadShopImageRequest, UploadShopImageResponse]{ServerStream: stream})
 }
@@ -705,6 +981,38 @@ var ShopService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSellerAccount",
 			Handler:    _ShopService_DeleteSellerAccount_Handler,
 		},
+		{
+			MethodName: "InviteShopMember",
+			Handler:    _ShopService_InviteShopMember_Handler,
+		},
+		{
+			MethodName: "ListShopMembers",
+			Handler:    _ShopService_ListShopMembers_Handler,
+		},
+		{
+			MethodName: "UpdateShopMemberRole",
+			Handler:    _ShopService_UpdateShopMemberRole_Handler,
+		},
+		{
+			MethodName: "RemoveShopMember",
+			Handler:    _ShopService_RemoveShopMember_Handler,
+		},
+		{
+			MethodName: "AcceptShopInvite",
+			Handler:    _ShopService_AcceptShopInvite_Handler,
+		},
+		{
+			MethodName: "CreateApiKey",
+			Handler:    _ShopService_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "ListApiKeys",
+			Handler:    _ShopService_ListApiKeys_Handler,
+		},
+		{
+			MethodName: "RevokeApiKey",
+			Handler:    _ShopService_RevokeApiKey_Handler,
+		},
 		{
 			MethodName: "AdminListShops",
 			Handler:    _ShopService_AdminListShops_Handler,
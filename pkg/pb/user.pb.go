@@ -428,11 +428,12 @@ func (x *RequestPhoneChangeRequest) GetNewPhone() string {
 }
 
 type RequestPhoneChangeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Success              bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message              string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	OldPhoneCodeRequired bool                   `protobuf:"varint,3,opt,name=old_phone_code_required,json=oldPhoneCodeRequired,proto3" json:"old_phone_code_required,omitempty"` // a code was also sent to the current number
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *RequestPhoneChangeResponse) Reset() {
@@ -479,10 +480,18 @@ func (x *RequestPhoneChangeResponse) GetMessage() string {
 	return ""
 }
 
+func (x *RequestPhoneChangeResponse) GetOldPhoneCodeRequired() bool {
+	if x != nil {
+		return x.OldPhoneCodeRequired
+	}
+	return false
+}
+
 type VerifyPhoneChangeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NewPhone      string                 `protobuf:"bytes,1,opt,name=new_phone,json=newPhone,proto3" json:"new_phone,omitempty"`
-	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                                       // code sent to the new number
+	OldPhoneCode  string                 `protobuf:"bytes,3,opt,name=old_phone_code,json=oldPhoneCode,proto3" json:"old_phone_code,omitempty"` // code sent to the current number, when required
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -531,6 +540,13 @@ func (x *VerifyPhoneChangeRequest) GetCode() string {
 	return ""
 }
 
+func (x *VerifyPhoneChangeRequest) GetOldPhoneCode() string {
+	if x != nil {
+		return x.OldPhoneCode
+	}
+	return ""
+}
+
 type VerifyPhoneChangeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -993,32 +1009,28 @@ func (x *VerifyPinResponse) GetMessage() string {
 	return ""
 }
 
-// Avatar upload
-type UploadAvatarRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Data:
-	//
-	//	*UploadAvatarRequest_Metadata
-	//	*UploadAvatarRequest_Chunk
-	Data          isUploadAvatarRequest_Data `protobuf_oneof:"data"`
+type ChangePinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldPin        string                 `protobuf:"bytes,1,opt,name=old_pin,json=oldPin,proto3" json:"old_pin,omitempty"`
+	NewPin        string                 `protobuf:"bytes,2,opt,name=new_pin,json=newPin,proto3" json:"new_pin,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadAvatarRequest) Reset() {
-	*x = UploadAvatarRequest{}
+func (x *ChangePinRequest) Reset() {
+	*x = ChangePinRequest{}
 	mi := &file_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadAvatarRequest) String() string {
+func (x *ChangePinRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadAvatarRequest) ProtoMessage() {}
+func (*ChangePinRequest) ProtoMessage() {}
 
-func (x *UploadAvatarRequest) ProtoReflect() protoreflect.Message {
+func (x *ChangePinRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1030,74 +1042,47 @@ func (x *UploadAvatarRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadAvatarRequest.ProtoReflect.Descriptor instead.
-func (*UploadAvatarRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangePinRequest.ProtoReflect.Descriptor instead.
+func (*ChangePinRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *UploadAvatarRequest) GetData() isUploadAvatarRequest_Data {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *UploadAvatarRequest) GetMetadata() *AvatarMetadata {
+func (x *ChangePinRequest) GetOldPin() string {
 	if x != nil {
-		if x, ok := x.Data.(*UploadAvatarRequest_Metadata); ok {
-			return x.Metadata
-		}
+		return x.OldPin
 	}
-	return nil
+	return ""
 }
 
-func (x *UploadAvatarRequest) GetChunk() []byte {
+func (x *ChangePinRequest) GetNewPin() string {
 	if x != nil {
-		if x, ok := x.Data.(*UploadAvatarRequest_Chunk); ok {
-			return x.Chunk
-		}
+		return x.NewPin
 	}
-	return nil
-}
-
-type isUploadAvatarRequest_Data interface {
-	isUploadAvatarRequest_Data()
-}
-
-type UploadAvatarRequest_Metadata struct {
-	Metadata *AvatarMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
-}
-
-type UploadAvatarRequest_Chunk struct {
-	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+	return ""
 }
 
-func (*UploadAvatarRequest_Metadata) isUploadAvatarRequest_Data() {}
-
-func (*UploadAvatarRequest_Chunk) isUploadAvatarRequest_Data() {}
-
-type AvatarMetadata struct {
+type ChangePinResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AvatarMetadata) Reset() {
-	*x = AvatarMetadata{}
+func (x *ChangePinResponse) Reset() {
+	*x = ChangePinResponse{}
 	mi := &file_user_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AvatarMetadata) String() string {
+func (x *ChangePinResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AvatarMetadata) ProtoMessage() {}
+func (*ChangePinResponse) ProtoMessage() {}
 
-func (x *AvatarMetadata) ProtoReflect() protoreflect.Message {
+func (x *ChangePinResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1109,48 +1094,47 @@ func (x *AvatarMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AvatarMetadata.ProtoReflect.Descriptor instead.
-func (*AvatarMetadata) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangePinResponse.ProtoReflect.Descriptor instead.
+func (*ChangePinResponse) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *AvatarMetadata) GetFilename() string {
+func (x *ChangePinResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Filename
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *AvatarMetadata) GetContentType() string {
+func (x *ChangePinResponse) GetMessage() string {
 	if x != nil {
-		return x.ContentType
+		return x.Message
 	}
 	return ""
 }
 
-type UploadAvatarResponse struct {
+// Locked or forgotten PIN: RequestPinReset sends an SMS code to the user's phone,
+// ResetPin sets a new PIN with that code and unlocks it.
+type RequestPinResetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	AvatarUrl     string                 `protobuf:"bytes,2,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadAvatarResponse) Reset() {
-	*x = UploadAvatarResponse{}
+func (x *RequestPinResetRequest) Reset() {
+	*x = RequestPinResetRequest{}
 	mi := &file_user_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadAvatarResponse) String() string {
+func (x *RequestPinResetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadAvatarResponse) ProtoMessage() {}
+func (*RequestPinResetRequest) ProtoMessage() {}
 
-func (x *UploadAvatarResponse) ProtoReflect() protoreflect.Message {
+func (x *RequestPinResetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1162,57 +1146,33 @@ func (x *UploadAvatarResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadAvatarResponse.ProtoReflect.Descriptor instead.
-func (*UploadAvatarResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RequestPinResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPinResetRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *UploadAvatarResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *UploadAvatarResponse) GetAvatarUrl() string {
-	if x != nil {
-		return x.AvatarUrl
-	}
-	return ""
-}
-
-func (x *UploadAvatarResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-type AdminListUsersRequest struct {
+type RequestPinResetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // Filter by role
-	ActiveOnly    bool                   `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
-	Search        string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"` // Search in name/phone
-	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdminListUsersRequest) Reset() {
-	*x = AdminListUsersRequest{}
+func (x *RequestPinResetResponse) Reset() {
+	*x = RequestPinResetResponse{}
 	mi := &file_user_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminListUsersRequest) String() string {
+func (x *RequestPinResetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminListUsersRequest) ProtoMessage() {}
+func (*RequestPinResetResponse) ProtoMessage() {}
 
-func (x *AdminListUsersRequest) ProtoReflect() protoreflect.Message {
+func (x *RequestPinResetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1224,70 +1184,47 @@ func (x *AdminListUsersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminListUsersRequest.ProtoReflect.Descriptor instead.
-func (*AdminListUsersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RequestPinResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPinResetResponse) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *AdminListUsersRequest) GetRole() string {
-	if x != nil {
-		return x.Role
-	}
-	return ""
-}
-
-func (x *AdminListUsersRequest) GetActiveOnly() bool {
+func (x *RequestPinResetResponse) GetSuccess() bool {
 	if x != nil {
-		return x.ActiveOnly
+		return x.Success
 	}
 	return false
 }
 
-func (x *AdminListUsersRequest) GetSearch() string {
+func (x *RequestPinResetResponse) GetMessage() string {
 	if x != nil {
-		return x.Search
+		return x.Message
 	}
 	return ""
 }
 
-func (x *AdminListUsersRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *AdminListUsersRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-type AdminListUsersResponse struct {
+type ResetPinRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	NewPin        string                 `protobuf:"bytes,2,opt,name=new_pin,json=newPin,proto3" json:"new_pin,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdminListUsersResponse) Reset() {
-	*x = AdminListUsersResponse{}
+func (x *ResetPinRequest) Reset() {
+	*x = ResetPinRequest{}
 	mi := &file_user_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminListUsersResponse) String() string {
+func (x *ResetPinRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminListUsersResponse) ProtoMessage() {}
+func (*ResetPinRequest) ProtoMessage() {}
 
-func (x *AdminListUsersResponse) ProtoReflect() protoreflect.Message {
+func (x *ResetPinRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1299,60 +1236,47 @@ func (x *AdminListUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminListUsersResponse.ProtoReflect.Descriptor instead.
-func (*AdminListUsersResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResetPinRequest.ProtoReflect.Descriptor instead.
+func (*ResetPinRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *AdminListUsersResponse) GetUsers() []*User {
-	if x != nil {
-		return x.Users
-	}
-	return nil
-}
-
-func (x *AdminListUsersResponse) GetTotal() int32 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-func (x *AdminListUsersResponse) GetPage() int32 {
+func (x *ResetPinRequest) GetCode() string {
 	if x != nil {
-		return x.Page
+		return x.Code
 	}
-	return 0
+	return ""
 }
 
-func (x *AdminListUsersResponse) GetLimit() int32 {
+func (x *ResetPinRequest) GetNewPin() string {
 	if x != nil {
-		return x.Limit
+		return x.NewPin
 	}
-	return 0
+	return ""
 }
 
-type AdminGetUserRequest struct {
+type ResetPinResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdminGetUserRequest) Reset() {
-	*x = AdminGetUserRequest{}
+func (x *ResetPinResponse) Reset() {
+	*x = ResetPinResponse{}
 	mi := &file_user_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminGetUserRequest) String() string {
+func (x *ResetPinResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminGetUserRequest) ProtoMessage() {}
+func (*ResetPinResponse) ProtoMessage() {}
 
-func (x *AdminGetUserRequest) ProtoReflect() protoreflect.Message {
+func (x *ResetPinResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1364,41 +1288,51 @@ func (x *AdminGetUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminGetUserRequest.ProtoReflect.Descriptor instead.
-func (*AdminGetUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResetPinResponse.ProtoReflect.Descriptor instead.
+func (*ResetPinResponse) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *AdminGetUserRequest) GetId() string {
+func (x *ResetPinResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Id
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResetPinResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-type AdminUpdateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Role          *string                `protobuf:"bytes,2,opt,name=role,proto3,oneof" json:"role,omitempty"`
-	IsActive      *bool                  `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+// Avatar upload
+type UploadAvatarRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadAvatarRequest_Metadata
+	//	*UploadAvatarRequest_Chunk
+	Data          isUploadAvatarRequest_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdminUpdateUserRequest) Reset() {
-	*x = AdminUpdateUserRequest{}
+func (x *UploadAvatarRequest) Reset() {
+	*x = UploadAvatarRequest{}
 	mi := &file_user_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminUpdateUserRequest) String() string {
+func (x *UploadAvatarRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminUpdateUserRequest) ProtoMessage() {}
+func (*UploadAvatarRequest) ProtoMessage() {}
 
-func (x *AdminUpdateUserRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadAvatarRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1410,23 +1344,913 @@ func (x *AdminUpdateUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminUpdateUserRequest.ProtoReflect.Descriptor instead.
-func (*AdminUpdateUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadAvatarRequest.ProtoReflect.Descriptor instead.
+func (*UploadAvatarRequest) Descriptor() ([]byte, []int) {
 	return file_user_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *AdminUpdateUserRequest) GetId() string {
+func (x *UploadAvatarRequest) GetData() isUploadAvatarRequest_Data {
 	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *AdminUpdateUserRequest) GetRole() string {
-	if x != nil && x.Role != nil {
-		return *x.Role
+		return x.Data
 	}
-	return ""
+	return nil
+}
+
+func (x *UploadAvatarRequest) GetMetadata() *AvatarMetadata {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAvatarRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadAvatarRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAvatarRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAvatarRequest_Data interface {
+	isUploadAvatarRequest_Data()
+}
+
+type UploadAvatarRequest_Metadata struct {
+	Metadata *AvatarMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadAvatarRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAvatarRequest_Metadata) isUploadAvatarRequest_Data() {}
+
+func (*UploadAvatarRequest_Chunk) isUploadAvatarRequest_Data() {}
+
+type AvatarMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvatarMetadata) Reset() {
+	*x = AvatarMetadata{}
+	mi := &file_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvatarMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvatarMetadata) ProtoMessage() {}
+
+func (x *AvatarMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvatarMetadata.ProtoReflect.Descriptor instead.
+func (*AvatarMetadata) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AvatarMetadata) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AvatarMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type UploadAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,2,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAvatarResponse) Reset() {
+	*x = UploadAvatarResponse{}
+	mi := &file_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarResponse) ProtoMessage() {}
+
+func (x *UploadAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAvatarResponse.ProtoReflect.Descriptor instead.
+func (*UploadAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UploadAvatarResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UploadAvatarResponse) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *UploadAvatarResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Device sessions
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceName    string                 `protobuf:"bytes,2,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceOs      string                 `protobuf:"bytes,4,opt,name=device_os,json=deviceOs,proto3" json:"device_os,omitempty"` // iOS, Android
+	OsVersion     string                 `protobuf:"bytes,5,opt,name=os_version,json=osVersion,proto3" json:"os_version,omitempty"`
+	AppType       string                 `protobuf:"bytes,6,opt,name=app_type,json=appType,proto3" json:"app_type,omitempty"` // client, seller, admin
+	AppVersion    string                 `protobuf:"bytes,7,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	IsCurrent     bool                   `protobuf:"varint,9,opt,name=is_current,json=isCurrent,proto3" json:"is_current,omitempty"` // Session the request was made from
+	IsTrusted     bool                   `protobuf:"varint,10,opt,name=is_trusted,json=isTrusted,proto3" json:"is_trusted,omitempty"`
+	LastActive    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_active,json=lastActive,proto3" json:"last_active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TrustedUntil  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=trusted_until,json=trustedUntil,proto3" json:"trusted_until,omitempty"` // set while is_trusted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceOs() string {
+	if x != nil {
+		return x.DeviceOs
+	}
+	return ""
+}
+
+func (x *Session) GetOsVersion() string {
+	if x != nil {
+		return x.OsVersion
+	}
+	return ""
+}
+
+func (x *Session) GetAppType() string {
+	if x != nil {
+		return x.AppType
+	}
+	return ""
+}
+
+func (x *Session) GetAppVersion() string {
+	if x != nil {
+		return x.AppVersion
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetIsCurrent() bool {
+	if x != nil {
+		return x.IsCurrent
+	}
+	return false
+}
+
+func (x *Session) GetIsTrusted() bool {
+	if x != nil {
+		return x.IsTrusted
+	}
+	return false
+}
+
+func (x *Session) GetLastActive() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActive
+	}
+	return nil
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetTrustedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TrustedUntil
+	}
+	return nil
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{28}
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RevokeSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RevokeSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Revokes PIN login for a trusted device, the session stays signed in
+type UntrustSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UntrustSessionRequest) Reset() {
+	*x = UntrustSessionRequest{}
+	mi := &file_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UntrustSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UntrustSessionRequest) ProtoMessage() {}
+
+func (x *UntrustSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UntrustSessionRequest.ProtoReflect.Descriptor instead.
+func (*UntrustSessionRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UntrustSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type UntrustSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UntrustSessionResponse) Reset() {
+	*x = UntrustSessionResponse{}
+	mi := &file_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UntrustSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UntrustSessionResponse) ProtoMessage() {}
+
+func (x *UntrustSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UntrustSessionResponse.ProtoReflect.Descriptor instead.
+func (*UntrustSessionResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UntrustSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UntrustSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RevokeOtherSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOtherSessionsRequest) Reset() {
+	*x = RevokeOtherSessionsRequest{}
+	mi := &file_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOtherSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOtherSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeOtherSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOtherSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeOtherSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{34}
+}
+
+type RevokeOtherSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RevokedCount  int32                  `protobuf:"varint,3,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOtherSessionsResponse) Reset() {
+	*x = RevokeOtherSessionsResponse{}
+	mi := &file_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOtherSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOtherSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeOtherSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOtherSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeOtherSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RevokeOtherSessionsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeOtherSessionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RevokeOtherSessionsResponse) GetRevokedCount() int32 {
+	if x != nil {
+		return x.RevokedCount
+	}
+	return 0
+}
+
+type AdminListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // Filter by role
+	ActiveOnly    bool                   `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	Search        string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"` // Search in name/phone
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminListUsersRequest) Reset() {
+	*x = AdminListUsersRequest{}
+	mi := &file_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminListUsersRequest) ProtoMessage() {}
+
+func (x *AdminListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminListUsersRequest.ProtoReflect.Descriptor instead.
+func (*AdminListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AdminListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *AdminListUsersRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+func (x *AdminListUsersRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *AdminListUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AdminListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AdminListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminListUsersResponse) Reset() {
+	*x = AdminListUsersResponse{}
+	mi := &file_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminListUsersResponse) ProtoMessage() {}
+
+func (x *AdminListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminListUsersResponse.ProtoReflect.Descriptor instead.
+func (*AdminListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AdminListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *AdminListUsersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AdminListUsersResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AdminListUsersResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AdminGetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminGetUserRequest) Reset() {
+	*x = AdminGetUserRequest{}
+	mi := &file_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGetUserRequest) ProtoMessage() {}
+
+func (x *AdminGetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminGetUserRequest.ProtoReflect.Descriptor instead.
+func (*AdminGetUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AdminGetUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AdminUpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role          *string                `protobuf:"bytes,2,opt,name=role,proto3,oneof" json:"role,omitempty"`
+	IsActive      *bool                  `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminUpdateUserRequest) Reset() {
+	*x = AdminUpdateUserRequest{}
+	mi := &file_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUpdateUserRequest) ProtoMessage() {}
+
+func (x *AdminUpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*AdminUpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AdminUpdateUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdminUpdateUserRequest) GetRole() string {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return ""
 }
 
 func (x *AdminUpdateUserRequest) GetIsActive() bool {
@@ -1445,7 +2269,7 @@ type AdminDeleteUserRequest struct {
 
 func (x *AdminDeleteUserRequest) Reset() {
 	*x = AdminDeleteUserRequest{}
-	mi := &file_user_proto_msgTypes[25]
+	mi := &file_user_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1457,7 +2281,7 @@ func (x *AdminDeleteUserRequest) String() string {
 func (*AdminDeleteUserRequest) ProtoMessage() {}
 
 func (x *AdminDeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[25]
+	mi := &file_user_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1470,7 +2294,7 @@ func (x *AdminDeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdminDeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*AdminDeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{25}
+	return file_user_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *AdminDeleteUserRequest) GetId() string {
@@ -1522,13 +2346,15 @@ const file_user_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"8\n" +
 	"\x19RequestPhoneChangeRequest\x12\x1b\n" +
-	"\tnew_phone\x18\x01 \x01(\tR\bnewPhone\"P\n" +
+	"\tnew_phone\x18\x01 \x01(\tR\bnewPhone\"\x87\x01\n" +
 	"\x1aRequestPhoneChangeResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"K\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x125\n" +
+	"\x17old_phone_code_required\x18\x03 \x01(\bR\x14oldPhoneCodeRequired\"q\n" +
 	"\x18VerifyPhoneChangeRequest\x12\x1b\n" +
 	"\tnew_phone\x18\x01 \x01(\tR\bnewPhone\x12\x12\n" +
-	"\x04code\x18\x02 \x01(\tR\x04code\"o\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12$\n" +
+	"\x0eold_phone_code\x18\x03 \x01(\tR\foldPhoneCode\"o\n" +
 	"\x19VerifyPhoneChangeResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1e\n" +
@@ -1556,6 +2382,22 @@ const file_user_proto_rawDesc = "" +
 	"\x03pin\x18\x01 \x01(\tR\x03pin\"G\n" +
 	"\x11VerifyPinResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"D\n" +
+	"\x10ChangePinRequest\x12\x17\n" +
+	"\aold_pin\x18\x01 \x01(\tR\x06oldPin\x12\x17\n" +
+	"\anew_pin\x18\x02 \x01(\tR\x06newPin\"G\n" +
+	"\x11ChangePinResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x18\n" +
+	"\x16RequestPinResetRequest\"M\n" +
+	"\x17RequestPinResetResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\">\n" +
+	"\x0fResetPinRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x17\n" +
+	"\anew_pin\x18\x02 \x01(\tR\x06newPin\"F\n" +
+	"\x10ResetPinResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"i\n" +
 	"\x13UploadAvatarRequest\x122\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x14.user.AvatarMetadataH\x00R\bmetadata\x12\x16\n" +
@@ -1568,7 +2410,50 @@ const file_user_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
 	"\n" +
 	"avatar_url\x18\x02 \x01(\tR\tavatarUrl\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x8e\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xe5\x03\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vdevice_name\x18\x02 \x01(\tR\n" +
+	"deviceName\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12\x1b\n" +
+	"\tdevice_os\x18\x04 \x01(\tR\bdeviceOs\x12\x1d\n" +
+	"\n" +
+	"os_version\x18\x05 \x01(\tR\tosVersion\x12\x19\n" +
+	"\bapp_type\x18\x06 \x01(\tR\aappType\x12\x1f\n" +
+	"\vapp_version\x18\a \x01(\tR\n" +
+	"appVersion\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\b \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"is_current\x18\t \x01(\bR\tisCurrent\x12\x1d\n" +
+	"\n" +
+	"is_trusted\x18\n" +
+	" \x01(\bR\tisTrusted\x12;\n" +
+	"\vlast_active\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastActive\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12?\n" +
+	"\rtrusted_until\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\ftrustedUntil\"\x15\n" +
+	"\x13ListSessionsRequest\"A\n" +
+	"\x14ListSessionsResponse\x12)\n" +
+	"\bsessions\x18\x01 \x03(\v2\r.user.SessionR\bsessions\"5\n" +
+	"\x14RevokeSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"K\n" +
+	"\x15RevokeSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"6\n" +
+	"\x15UntrustSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"L\n" +
+	"\x16UntrustSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x1c\n" +
+	"\x1aRevokeOtherSessionsRequest\"v\n" +
+	"\x1bRevokeOtherSessionsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rrevoked_count\x18\x03 \x01(\x05R\frevokedCount\"\x8e\x01\n" +
 	"\x15AdminListUsersRequest\x12\x12\n" +
 	"\x04role\x18\x01 \x01(\tR\x04role\x12\x1f\n" +
 	"\vactive_only\x18\x02 \x01(\bR\n" +
@@ -1592,7 +2477,7 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"_is_active\"(\n" +
 	"\x16AdminDeleteUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\x8a\b\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x8d\f\n" +
 	"\vUserService\x12<\n" +
 	"\n" +
 	"GetProfile\x12\x17.user.GetProfileRequest\x1a\x15.user.ProfileResponse\x12B\n" +
@@ -1603,8 +2488,15 @@ const file_user_proto_rawDesc = "" +
 	"\x12RequestEmailChange\x12\x1f.user.RequestEmailChangeRequest\x1a .user.RequestEmailChangeResponse\x12T\n" +
 	"\x11VerifyEmailChange\x12\x1e.user.VerifyEmailChangeRequest\x1a\x1f.user.VerifyEmailChangeResponse\x123\n" +
 	"\x06SetPin\x12\x13.user.SetPinRequest\x1a\x14.user.SetPinResponse\x12<\n" +
-	"\tVerifyPin\x12\x16.user.VerifyPinRequest\x1a\x17.user.VerifyPinResponse\x12G\n" +
-	"\fUploadAvatar\x12\x19.user.UploadAvatarRequest\x1a\x1a.user.UploadAvatarResponse(\x01\x12K\n" +
+	"\tVerifyPin\x12\x16.user.VerifyPinRequest\x1a\x17.user.VerifyPinResponse\x12<\n" +
+	"\tChangePin\x12\x16.user.ChangePinRequest\x1a\x17.user.ChangePinResponse\x12N\n" +
+	"\x0fRequestPinReset\x12\x1c.user.RequestPinResetRequest\x1a\x1d.user.RequestPinResetResponse\x129\n" +
+	"\bResetPin\x12\x15.user.ResetPinRequest\x1a\x16.user.ResetPinResponse\x12G\n" +
+	"\fUploadAvatar\x12\x19.user.UploadAvatarRequest\x1a\x1a.user.UploadAvatarResponse(\x01\x12E\n" +
+	"\fListSessions\x12\x19.user.ListSessionsRequest\x1a\x1a.user.ListSessionsResponse\x12H\n" +
+	"\rRevokeSession\x12\x1a.user.RevokeSessionRequest\x1a\x1b.user.RevokeSessionResponse\x12Z\n" +
+	"\x13RevokeOtherSessions\x12 .user.RevokeOtherSessionsRequest\x1a!.user.RevokeOtherSessionsResponse\x12K\n" +
+	"\x0eUntrustSession\x12\x1b.user.UntrustSessionRequest\x1a\x1c.user.UntrustSessionResponse\x12K\n" +
 	"\x0eAdminListUsers\x12\x1b.user.AdminListUsersRequest\x1a\x1c.user.AdminListUsersResponse\x12@\n" +
 	"\fAdminGetUser\x12\x19.user.AdminGetUserRequest\x1a\x15.user.ProfileResponse\x12F\n" +
 	"\x0fAdminUpdateUser\x12\x1c.user.AdminUpdateUserRequest\x1a\x15.user.ProfileResponse\x12>\n" +
@@ -1622,78 +2514,111 @@ func file_user_proto_rawDescGZIP() []byte {
 	return file_user_proto_rawDescData
 }
 
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_user_proto_goTypes = []any{
-	(*User)(nil),                       // 0: user.User
-	(*GetProfileRequest)(nil),          // 1: user.GetProfileRequest
-	(*ProfileResponse)(nil),            // 2: user.ProfileResponse
-	(*UpdateProfileRequest)(nil),       // 3: user.UpdateProfileRequest
-	(*DeleteAccountRequest)(nil),       // 4: user.DeleteAccountRequest
-	(*DeleteAccountResponse)(nil),      // 5: user.DeleteAccountResponse
-	(*RequestPhoneChangeRequest)(nil),  // 6: user.RequestPhoneChangeRequest
-	(*RequestPhoneChangeResponse)(nil), // 7: user.RequestPhoneChangeResponse
-	(*VerifyPhoneChangeRequest)(nil),   // 8: user.VerifyPhoneChangeRequest
-	(*VerifyPhoneChangeResponse)(nil),  // 9: user.VerifyPhoneChangeResponse
-	(*RequestEmailChangeRequest)(nil),  // 10: user.RequestEmailChangeRequest
-	(*RequestEmailChangeResponse)(nil), // 11: user.RequestEmailChangeResponse
-	(*VerifyEmailChangeRequest)(nil),   // 12: user.VerifyEmailChangeRequest
-	(*VerifyEmailChangeResponse)(nil),  // 13: user.VerifyEmailChangeResponse
-	(*SetPinRequest)(nil),              // 14: user.SetPinRequest
-	(*SetPinResponse)(nil),             // 15: user.SetPinResponse
-	(*VerifyPinRequest)(nil),           // 16: user.VerifyPinRequest
-	(*VerifyPinResponse)(nil),          // 17: user.VerifyPinResponse
-	(*UploadAvatarRequest)(nil),        // 18: user.UploadAvatarRequest
-	(*AvatarMetadata)(nil),             // 19: user.AvatarMetadata
-	(*UploadAvatarResponse)(nil),       // 20: user.UploadAvatarResponse
-	(*AdminListUsersRequest)(nil),      // 21: user.AdminListUsersRequest
-	(*AdminListUsersResponse)(nil),     // 22: user.AdminListUsersResponse
-	(*AdminGetUserRequest)(nil),        // 23: user.AdminGetUserRequest
-	(*AdminUpdateUserRequest)(nil),     // 24: user.AdminUpdateUserRequest
-	(*AdminDeleteUserRequest)(nil),     // 25: user.AdminDeleteUserRequest
-	(*timestamppb.Timestamp)(nil),      // 26: google.protobuf.Timestamp
-	(*Empty)(nil),                      // 27: common.Empty
+	(*User)(nil),                        // 0: user.User
+	(*GetProfileRequest)(nil),           // 1: user.GetProfileRequest
+	(*ProfileResponse)(nil),             // 2: user.ProfileResponse
+	(*UpdateProfileRequest)(nil),        // 3: user.UpdateProfileRequest
+	(*DeleteAccountRequest)(nil),        // 4: user.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),       // 5: user.DeleteAccountResponse
+	(*RequestPhoneChangeRequest)(nil),   // 6: user.RequestPhoneChangeRequest
+	(*RequestPhoneChangeResponse)(nil),  // 7: user.RequestPhoneChangeResponse
+	(*VerifyPhoneChangeRequest)(nil),    // 8: user.VerifyPhoneChangeRequest
+	(*VerifyPhoneChangeResponse)(nil),   // 9: user.VerifyPhoneChangeResponse
+	(*RequestEmailChangeRequest)(nil),   // 10: user.RequestEmailChangeRequest
+	(*RequestEmailChangeResponse)(nil),  // 11: user.RequestEmailChangeResponse
+	(*VerifyEmailChangeRequest)(nil),    // 12: user.VerifyEmailChangeRequest
+	(*VerifyEmailChangeResponse)(nil),   // 13: user.VerifyEmailChangeResponse
+	(*SetPinRequest)(nil),               // 14: user.SetPinRequest
+	(*SetPinResponse)(nil),              // 15: user.SetPinResponse
+	(*VerifyPinRequest)(nil),            // 16: user.VerifyPinRequest
+	(*VerifyPinResponse)(nil),           // 17: user.VerifyPinResponse
+	(*ChangePinRequest)(nil),            // 18: user.ChangePinRequest
+	(*ChangePinResponse)(nil),           // 19: user.ChangePinResponse
+	(*RequestPinResetRequest)(nil),      // 20: user.RequestPinResetRequest
+	(*RequestPinResetResponse)(nil),     // 21: user.RequestPinResetResponse
+	(*ResetPinRequest)(nil),             // 22: user.ResetPinRequest
+	(*ResetPinResponse)(nil),            // 23: user.ResetPinResponse
+	(*UploadAvatarRequest)(nil),         // 24: user.UploadAvatarRequest
+	(*AvatarMetadata)(nil),              // 25: user.AvatarMetadata
+	(*UploadAvatarResponse)(nil),        // 26: user.UploadAvatarResponse
+	(*Session)(nil),                     // 27: user.Session
+	(*ListSessionsRequest)(nil),         // 28: user.ListSessionsRequest
+	(*ListSessionsResponse)(nil),        // 29: user.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),        // 30: user.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),       // 31: user.RevokeSessionResponse
+	(*UntrustSessionRequest)(nil),       // 32: user.UntrustSessionRequest
+	(*UntrustSessionResponse)(nil),      // 33: user.UntrustSessionResponse
+	(*RevokeOtherSessionsRequest)(nil),  // 34: user.RevokeOtherSessionsRequest
+	(*RevokeOtherSessionsResponse)(nil), // 35: user.RevokeOtherSessionsResponse
+	(*AdminListUsersRequest)(nil),       // 36: user.AdminListUsersRequest
+	(*AdminListUsersResponse)(nil),      // 37: user.AdminListUsersResponse
+	(*AdminGetUserRequest)(nil),         // 38: user.AdminGetUserRequest
+	(*AdminUpdateUserRequest)(nil),      // 39: user.AdminUpdateUserRequest
+	(*AdminDeleteUserRequest)(nil),      // 40: user.AdminDeleteUserRequest
+	(*timestamppb.Timestamp)(nil),       // 41: google.protobuf.Timestamp
+	(*Empty)(nil),                       // 42: common.Empty
 }
 var file_user_proto_depIdxs = []int32{
-	26, // 0: user.User.created_at:type_name -> google.protobuf.Timestamp
-	26, // 1: user.User.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 0: user.User.created_at:type_name -> google.protobuf.Timestamp
+	41, // 1: user.User.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: user.ProfileResponse.user:type_name -> user.User
 	0,  // 3: user.VerifyPhoneChangeResponse.user:type_name -> user.User
 	0,  // 4: user.VerifyEmailChangeResponse.user:type_name -> user.User
-	19, // 5: user.UploadAvatarRequest.metadata:type_name -> user.AvatarMetadata
-	0,  // 6: user.AdminListUsersResponse.users:type_name -> user.User
-	1,  // 7: user.UserService.GetProfile:input_type -> user.GetProfileRequest
-	3,  // 8: user.UserService.UpdateProfile:input_type -> user.UpdateProfileRequest
-	4,  // 9: user.UserService.DeleteAccount:input_type -> user.DeleteAccountRequest
-	6,  // 10: user.UserService.RequestPhoneChange:input_type -> user.RequestPhoneChangeRequest
-	8,  // 11: user.UserService.VerifyPhoneChange:input_type -> user.VerifyPhoneChangeRequest
-	10, // 12: user.UserService.RequestEmailChange:input_type -> user.RequestEmailChangeRequest
-	12, // 13: user.UserService.VerifyEmailChange:input_type -> user.VerifyEmailChangeRequest
-	14, // 14: user.UserService.SetPin:input_type -> user.SetPinRequest
-	16, // 15: user.UserService.VerifyPin:input_type -> user.VerifyPinRequest
-	18, // 16: user.UserService.UploadAvatar:input_type -> user.UploadAvatarRequest
-	21, // 17: user.UserService.AdminListUsers:input_type -> user.AdminListUsersRequest
-	23, // 18: user.UserService.AdminGetUser:input_type -> user.AdminGetUserRequest
-	24, // 19: user.UserService.AdminUpdateUser:input_type -> user.AdminUpdateUserRequest
-	25, // 20: user.UserService.AdminDeleteUser:input_type -> user.AdminDeleteUserRequest
-	2,  // 21: user.UserService.GetProfile:output_type -> user.ProfileResponse
-	2,  // 22: user.UserService.UpdateProfile:output_type -> user.ProfileResponse
-	5,  // 23: user.UserService.DeleteAccount:output_type -> user.DeleteAccountResponse
-	7,  // 24: user.UserService.RequestPhoneChange:output_type -> user.RequestPhoneChangeResponse
-	9,  // 25: user.UserService.VerifyPhoneChange:output_type -> user.VerifyPhoneChangeResponse
-	11, // 26: user.UserService.RequestEmailChange:output_type -> user.RequestEmailChangeResponse
-	13, // 27: user.UserService.VerifyEmailChange:output_type -> user.VerifyEmailChangeResponse
-	15, // 28: user.UserService.SetPin:output_type -> user.SetPinResponse
-	17, // 29: user.UserService.VerifyPin:output_type -> user.VerifyPinResponse
-	20, // 30: user.UserService.UploadAvatar:output_type -> user.UploadAvatarResponse
-	22, // 31: user.UserService.AdminListUsers:output_type -> user.AdminListUsersResponse
-	2,  // 32: user.UserService.AdminGetUser:output_type -> user.ProfileResponse
-	2,  // 33: user.UserService.AdminUpdateUser:output_type -> user.ProfileResponse
-	27, // 34: user.UserService.AdminDeleteUser:output_type -> common.Empty
-	21, // [21:35] is the sub-list for method output_type
-	7,  // [7:21] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	25, // 5: user.UploadAvatarRequest.metadata:type_name -> user.AvatarMetadata
+	41, // 6: user.Session.last_active:type_name -> google.protobuf.Timestamp
+	41, // 7: user.Session.created_at:type_name -> google.protobuf.Timestamp
+	41, // 8: user.Session.trusted_until:type_name -> google.protobuf.Timestamp
+	27, // 9: user.ListSessionsResponse.sessions:type_name -> user.Session
+	0,  // 10: user.AdminListUsersResponse.users:type_name -> user.User
+	1,  // 11: user.UserService.GetProfile:input_type -> user.GetProfileRequest
+	3,  // 12: user.UserService.UpdateProfile:input_type -> user.UpdateProfileRequest
+	4,  // 13: user.UserService.DeleteAccount:input_type -> user.DeleteAccountRequest
+	6,  // 14: user.UserService.RequestPhoneChange:input_type -> user.RequestPhoneChangeRequest
+	8,  // 15: user.UserService.VerifyPhoneChange:input_type -> user.VerifyPhoneChangeRequest
+	10, // 16: user.UserService.RequestEmailChange:input_type -> user.RequestEmailChangeRequest
+	12, // 17: user.UserService.VerifyEmailChange:input_type -> user.VerifyEmailChangeRequest
+	14, // 18: user.UserService.SetPin:input_type -> user.SetPinRequest
+	16, // 19: user.UserService.VerifyPin:input_type -> user.VerifyPinRequest
+	18, // 20: user.UserService.ChangePin:input_type -> user.ChangePinRequest
+	20, // 21: user.UserService.RequestPinReset:input_type -> user.RequestPinResetRequest
+	22, // 22: user.UserService.ResetPin:input_type -> user.ResetPinRequest
+	24, // 23: user.UserService.UploadAvatar:input_type -> user.UploadAvatarRequest
+	28, // 24: user.UserService.ListSessions:input_type -> user.ListSessionsRequest
+	30, // 25: user.UserService.RevokeSession:input_type -> user.RevokeSessionRequest
+	34, // 26: user.UserService.RevokeOtherSessions:input_type -> user.RevokeOtherSessionsRequest
+	32, // 27: user.UserService.UntrustSession:input_type -> user.UntrustSessionRequest
+	36, // 28: user.UserService.AdminListUsers:input_type -> user.AdminListUsersRequest
+	38, // 29: user.UserService.AdminGetUser:input_type -> user.AdminGetUserRequest
+	39, // 30: user.UserService.AdminUpdateUser:input_type -> user.AdminUpdateUserRequest
+	40, // 31: user.UserService.AdminDeleteUser:input_type -> user.AdminDeleteUserRequest
+	2,  // 32: user.UserService.GetProfile:output_type -> user.ProfileResponse
+	2,  // 33: user.UserService.UpdateProfile:output_type -> user.ProfileResponse
+	5,  // 34: user.UserService.DeleteAccount:output_type -> user.DeleteAccountResponse
+	7,  // 35: user.UserService.RequestPhoneChange:output_type -> user.RequestPhoneChangeResponse
+	9,  // 36: user.UserService.VerifyPhoneChange:output_type -> user.VerifyPhoneChangeResponse
+	11, // 37: user.UserService.RequestEmailChange:output_type -> user.RequestEmailChangeResponse
+	13, // 38: user.UserService.VerifyEmailChange:output_type -> user.VerifyEmailChangeResponse
+	15, // 39: user.UserService.SetPin:output_type -> user.SetPinResponse
+	17, // 40: user.UserService.VerifyPin:output_type -> user.VerifyPinResponse
+	19, // 41: user.UserService.ChangePin:output_type -> user.ChangePinResponse
+	21, // 42: user.UserService.RequestPinReset:output_type -> user.RequestPinResetResponse
+	23, // 43: user.UserService.ResetPin:output_type -> user.ResetPinResponse
+	26, // 44: user.UserService.UploadAvatar:output_type -> user.UploadAvatarResponse
+	29, // 45: user.UserService.ListSessions:output_type -> user.ListSessionsResponse
+	31, // 46: user.UserService.RevokeSession:output_type -> user.RevokeSessionResponse
+	35, // 47: user.UserService.RevokeOtherSessions:output_type -> user.RevokeOtherSessionsResponse
+	33, // 48: user.UserService.UntrustSession:output_type -> user.UntrustSessionResponse
+	37, // 49: user.UserService.AdminListUsers:output_type -> user.AdminListUsersResponse
+	2,  // 50: user.UserService.AdminGetUser:output_type -> user.ProfileResponse
+	2,  // 51: user.UserService.AdminUpdateUser:output_type -> user.ProfileResponse
+	42, // 52: user.UserService.AdminDeleteUser:output_type -> common.Empty
+	32, // [32:53] is the sub-list for method output_type
+	11, // [11:32] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -1703,18 +2628,18 @@ func file_user_proto_init() {
 	}
 	file_common_proto_init()
 	file_user_proto_msgTypes[3].OneofWrappers = []any{}
-	file_user_proto_msgTypes[18].OneofWrappers = []any{
+	file_user_proto_msgTypes[24].OneofWrappers = []any{
 		(*UploadAvatarRequest_Metadata)(nil),
 		(*UploadAvatarRequest_Chunk)(nil),
 	}
-	file_user_proto_msgTypes[24].OneofWrappers = []any{}
+	file_user_proto_msgTypes[39].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   26,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,20 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_GetProfile_FullMethodName         = "/user.UserService/GetProfile"
-	UserService_UpdateProfile_FullMethodName      = "/user.UserService/UpdateProfile"
-	UserService_DeleteAccount_FullMethodName      = "/user.UserService/DeleteAccount"
-	UserService_RequestPhoneChange_FullMethodName = "/user.UserService/RequestPhoneChange"
-	UserService_VerifyPhoneChange_FullMethodName  = "/user.UserService/VerifyPhoneChange"
-	UserService_RequestEmailChange_FullMethodName = "/user.UserService/RequestEmailChange"
-	UserService_VerifyEmailChange_FullMethodName  = "/user.UserService/VerifyEmailChange"
-	UserService_SetPin_FullMethodName             = "/user.UserService/SetPin"
-	UserService_VerifyPin_FullMethodName          = "/user.UserService/VerifyPin"
-	UserService_UploadAvatar_FullMethodName       = "/user.UserService/UploadAvatar"
-	UserService_AdminListUsers_FullMethodName     = "/user.UserService/AdminListUsers"
-	UserService_AdminGetUser_FullMethodName       = "/user.UserService/AdminGetUser"
-	UserService_AdminUpdateUser_FullMethodName    = "/user.UserService/AdminUpdateUser"
-	UserService_AdminDeleteUser_FullMethodName    = "/user.UserService/AdminDeleteUser"
+	UserService_GetProfile_FullMethodName          = "/user.UserService/GetProfile"
+	UserService_UpdateProfile_FullMethodName       = "/user.UserService/UpdateProfile"
+	UserService_DeleteAccount_FullMethodName       = "/user.UserService/DeleteAccount"
+	UserService_RequestPhoneChange_FullMethodName  = "/user.UserService/RequestPhoneChange"
+	UserService_VerifyPhoneChange_FullMethodName   = "/user.UserService/VerifyPhoneChange"
+	UserService_RequestEmailChange_FullMethodName  = "/user.UserService/RequestEmailChange"
+	UserService_VerifyEmailChange_FullMethodName   = "/user.UserService/VerifyEmailChange"
+	UserService_SetPin_FullMethodName              = "/user.UserService/SetPin"
+	UserService_VerifyPin_FullMethodName           = "/user.UserService/VerifyPin"
+	UserService_ChangePin_FullMethodName           = "/user.UserService/ChangePin"
+	UserService_RequestPinReset_FullMethodName     = "/user.UserService/RequestPinReset"
+	UserService_ResetPin_FullMethodName            = "/user.UserService/ResetPin"
+	UserService_UploadAvatar_FullMethodName        = "/user.UserService/UploadAvatar"
+	UserService_ListSessions_FullMethodName        = "/user.UserService/ListSessions"
+	UserService_RevokeSession_FullMethodName       = "/user.UserService/RevokeSession"
+	UserService_RevokeOtherSessions_FullMethodName = "/user.UserService/RevokeOtherSessions"
+	UserService_UntrustSession_FullMethodName      = "/user.UserService/UntrustSession"
+	UserService_AdminListUsers_FullMethodName      = "/user.UserService/AdminListUsers"
+	UserService_AdminGetUser_FullMethodName        = "/user.UserService/AdminGetUser"
+	UserService_AdminUpdateUser_FullMethodName     = "/user.UserService/AdminUpdateUser"
+	UserService_AdminDeleteUser_FullMethodName     = "/user.UserService/AdminDeleteUser"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -52,8 +59,16 @@ type UserServiceClient interface {
 	// PIN management
 	SetPin(ctx context.Context, in *SetPinRequest, opts ...grpc.CallOption) (*SetPinResponse, error)
 	VerifyPin(ctx context.Context, in *VerifyPinRequest, opts ...grpc.CallOption) (*VerifyPinResponse, error)
+	ChangePin(ctx context.Context, in *ChangePinRequest, opts ...grpc.CallOption) (*ChangePinResponse, error)
+	RequestPinReset(ctx context.Context, in *RequestPinResetRequest, opts ...grpc.CallOption) (*RequestPinResetResponse, error)
+	ResetPin(ctx context.Context, in *ResetPinRequest, opts ...grpc.CallOption) (*ResetPinResponse, error)
 	// Avatar upload via streaming
 	UploadAvatar(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAvatarRequest, UploadAvatarResponse], error)
+	// Device sessions
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	RevokeOtherSessions(ctx context.Context, in *RevokeOtherSessionsRequest, opts ...grpc.CallOption) (*RevokeOtherSessionsResponse, error)
+	UntrustSession(ctx context.Context, in *UntrustSessionRequest, opts ...grpc.CallOption) (*UntrustSessionResponse, error)
 	// Admin endpoints (requires admin/moderator role)
 	AdminListUsers(ctx context.Context, in *AdminListUsersRequest, opts ...grpc.CallOption) (*AdminListUsersResponse, error)
 	AdminGetUser(ctx context.Context, in *AdminGetUserRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
@@ -159,6 +174,36 @@ func (c *userServiceClient) VerifyPin(ctx context.Context, in *VerifyPinRequest,
 	return out, nil
 }
 
+func (c *userServiceClient) ChangePin(ctx context.Context, in *ChangePinRequest, opts ...grpc.CallOption) (*ChangePinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePinResponse)
+	err := c.cc.Invoke(ctx, UserService_ChangePin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RequestPinReset(ctx context.Context, in *RequestPinResetRequest, opts ...grpc.CallOption) (*RequestPinResetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestPinResetResponse)
+	err := c.cc.Invoke(ctx, UserService_RequestPinReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ResetPin(ctx context.Context, in *ResetPinRequest, opts ...grpc.CallOption) (*ResetPinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPinResponse)
+	err := c.cc.Invoke(ctx, UserService_ResetPin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) UploadAvatar(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAvatarRequest, UploadAvatarResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_UploadAvatar_FullMethodName, cOpts...)
@@ -172,6 +217,46 @@ func (c *userServiceClient) UploadAvatar(ctx context.Context, opts ...grpc.CallO
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type UserService_UploadAvatarClient = grpc.ClientStreamingClient[UploadAvatarRequest, UploadAvatarResponse]
 
+func (c *userServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeSessionResponse)
+	err := c.cc.Invoke(ctx, UserService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeOtherSessions(ctx context.Context, in *RevokeOtherSessionsRequest, opts ...grpc.CallOption) (*RevokeOtherSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeOtherSessionsResponse)
+	err := c.cc.Invoke(ctx, UserService_RevokeOtherSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UntrustSession(ctx context.Context, in *UntrustSessionRequest, opts ...grpc.CallOption) (*UntrustSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UntrustSessionResponse)
+	err := c.cc.Invoke(ctx, UserService_UntrustSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) AdminListUsers(ctx context.Context, in *AdminListUsersRequest, opts ...grpc.CallOption) (*AdminListUsersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AdminListUsersResponse)
@@ -229,8 +314,16 @@ type UserServiceServer interface {
 	// PIN management
 	SetPin(context.Context, *SetPinRequest) (*SetPinResponse, error)
 	VerifyPin(context.Context, *VerifyPinRequest) (*VerifyPinResponse, error)
+	ChangePin(context.Context, *ChangePinRequest) (*ChangePinResponse, error)
+	RequestPinReset(context.Context, *RequestPinResetRequest) (*RequestPinResetResponse, error)
+	ResetPin(context.Context, *ResetPinRequest) (*ResetPinResponse, error)
 	// Avatar upload via streaming
 	UploadAvatar(grpc.ClientStreamingServer[UploadAvatarRequest, UploadAvatarResponse]) error
+	// Device sessions
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	RevokeOtherSessions(context.Context, *RevokeOtherSessionsRequest) (*RevokeOtherSessionsResponse, error)
+	UntrustSession(context.Context, *UntrustSessionRequest) (*UntrustSessionResponse, error)
 	// Admin endpoints (requires admin/moderator role)
 	AdminListUsers(context.Context, *AdminListUsersRequest) (*AdminListUsersResponse, error)
 	AdminGetUser(context.Context, *AdminGetUserRequest) (*ProfileResponse, error)
@@ -273,9 +366,30 @@ func (UnimplementedUserServiceServer) SetPin(context.Context, *SetPinRequest) (*
 func (UnimplementedUserServiceServer) VerifyPin(context.Context, *VerifyPinRequest) (*VerifyPinResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method VerifyPin not implemented")
 }
+func (UnimplementedUserServiceServer) ChangePin(context.Context, *ChangePinRequest) (*ChangePinResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ChangePin not implemented")
+}
+func (UnimplementedUserServiceServer) RequestPinReset(context.Context, *RequestPinResetRequest) (*RequestPinResetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestPinReset not implemented")
+}
+func (UnimplementedUserServiceServer) ResetPin(context.Context, *ResetPinRequest) (*ResetPinResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetPin not implemented")
+}
 func (UnimplementedUserServiceServer) UploadAvatar(grpc.ClientStreamingServer[UploadAvatarRequest, UploadAvatarResponse]) error {
 	return status.Error(codes.Unimplemented, "method UploadAvatar not implemented")
 }
+func (UnimplementedUserServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeOtherSessions(context.Context, *RevokeOtherSessionsRequest) (*RevokeOtherSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeOtherSessions not implemented")
+}
+func (UnimplementedUserServiceServer) UntrustSession(context.Context, *UntrustSessionRequest) (*UntrustSessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UntrustSession not implemented")
+}
 func (UnimplementedUserServiceServer) AdminListUsers(context.Context, *AdminListUsersRequest) (*AdminListUsersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AdminListUsers not implemented")
 }
@@ -471,6 +585,60 @@ func _UserService_VerifyPin_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ChangePin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangePin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ChangePin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangePin(ctx, req.(*ChangePinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RequestPinReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPinResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequestPinReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RequestPinReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequestPinReset(ctx, req.(*RequestPinResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ResetPin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ResetPin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ResetPin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ResetPin(ctx, req.(*ResetPinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_UploadAvatar_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(UserServiceServer).UploadAvatar(&grpc.GenericServerStream[UploadAvatarRequest, UploadAvatarResponse]{ServerStream: stream})
 }
@@ -478,6 +646,78 @@ func _UserService_UploadAvatar_Handler(srv interface{}, stream grpc.ServerStream
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type UserService_UploadAvatarServer = grpc.ClientStreamingServer[UploadAvatarRequest, UploadAvatarResponse]
 
+func _UserService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeOtherSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeOtherSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeOtherSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeOtherSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeOtherSessions(ctx, req.(*RevokeOtherSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UntrustSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UntrustSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UntrustSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UntrustSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UntrustSession(ctx, req.(*UntrustSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_AdminListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AdminListUsersRequest)
 	if err := dec(in); err != nil {
@@ -593,6 +833,34 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyPin",
 			Handler:    _UserService_VerifyPin_Handler,
 		},
+		{
+			MethodName: "ChangePin",
+			Handler:    _UserService_ChangePin_Handler,
+		},
+		{
+			MethodName: "RequestPinReset",
+			Handler:    _UserService_RequestPinReset_Handler,
+		},
+		{
+			MethodName: "ResetPin",
+			Handler:    _UserService_ResetPin_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _UserService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _UserService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "RevokeOtherSessions",
+			Handler:    _UserService_RevokeOtherSessions_Handler,
+		},
+		{
+			MethodName: "UntrustSession",
+			Handler:    _UserService_UntrustSession_Handler,
+		},
 		{
 			MethodName: "AdminListUsers",
 			Handler:    _UserService_AdminListUsers_Handler,
@@ -84,6 +84,16 @@ func NewMemoryLimiter(rps int, burst int) *MemoryLimiter {
 	}
 }
 
+// NewMemoryWindowLimiter создает in-memory rate limiter на limit запросов за window,
+// как NewRedisLimiter: сразу доступно limit запросов, затем по одному каждые window/limit
+func NewMemoryWindowLimiter(limit int, window time.Duration) *MemoryLimiter {
+	return &MemoryLimiter{
+		limiters: make(map[string]*rate.Limiter),
+		limit:    rate.Every(window / time.Duration(limit)),
+		burst:    limit,
+	}
+}
+
 // Allow проверяет, разрешен ли запрос
 func (l *MemoryLimiter) Allow(key string) (bool, error) {
 	limiter, exists := l.limiters[key]
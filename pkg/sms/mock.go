@@ -10,12 +10,17 @@ type MockSMSService struct {
 	LastCode      string
 	LastMessage   string
 	ShouldFail    bool
+	Codes         map[string]string // последний код для каждого номера
 }
 
 func (m *MockSMSService) SendOTP(phone, code string) error {
 	m.SendOTPCalled = true
 	m.LastPhone = phone
 	m.LastCode = code
+	if m.Codes == nil {
+		m.Codes = map[string]string{}
+	}
+	m.Codes[phone] = code
 
 	if m.ShouldFail {
 		return fmt.Errorf("mock SMS error")
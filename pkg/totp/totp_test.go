@@ -0,0 +1,83 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Секрет из RFC 6238, приложение B ("12345678901234567890")
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeAt_RFC6238Vectors(t *testing.T) {
+	// Последние 6 цифр 8-значных кодов SHA1 из RFC 6238
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		code, err := CodeAt(rfcSecret, Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "t=%d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	now := time.Now()
+
+	code, err := CodeAt(secret, Step(now))
+	require.NoError(t, err)
+	step, ok := Validate(secret, code, now, 0)
+	require.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	// Повторное использование того же шага запрещено
+	_, ok = Validate(secret, code, now, step)
+	assert.False(t, ok)
+
+	// Код предыдущего шага допустим (расхождение часов), более старый - нет
+	prev, _ := CodeAt(secret, Step(now)-1)
+	_, ok = Validate(secret, prev, now, 0)
+	assert.True(t, ok)
+	old, _ := CodeAt(secret, Step(now)-3)
+	_, ok = Validate(secret, old, now, 0)
+	assert.False(t, ok)
+
+	_, ok = Validate(secret, "12345", now, 0)
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI(rfcSecret, "Mebellar", "+998901234567")
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/Mebellar:+998901234567", parsed.Path)
+	assert.Equal(t, rfcSecret, parsed.Query().Get("secret"))
+	assert.Equal(t, "Mebellar", parsed.Query().Get("issuer"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Len(t, code, 9)
+		assert.Equal(t, 4, strings.Index(code, "-"))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
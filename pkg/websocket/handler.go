@@ -6,9 +6,13 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
+	"mebellar-backend/internal/grpc/middleware"
+	"mebellar-backend/pkg/jwtkeys"
+
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var upgrader = websocket.Upgrader{
@@ -20,11 +24,27 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
-var jwtSecret string
+var jwtKeys *jwtkeys.KeyRing
+
+// SetKeyRing sets the JWT keys used to verify access tokens
+func SetKeyRing(keys *jwtkeys.KeyRing) {
+	jwtKeys = keys
+}
+
+var tokenVersions *middleware.TokenVersions
 
-// SetJWTSecret sets the JWT secret for authentication
-func SetJWTSecret(secret string) {
-	jwtSecret = secret
+// SetTokenVersions shares the gRPC interceptor's token version store (and its cache).
+// Without it versions are read from the database on every connection.
+func SetTokenVersions(versions *middleware.TokenVersions) {
+	tokenVersions = versions
+}
+
+var sessions *middleware.Sessions
+
+// SetSessions shares the gRPC interceptor's session store (and its cache).
+// Without it sessions are read from the database on every connection.
+func SetSessions(s *middleware.Sessions) {
+	sessions = s
 }
 
 // HandleWebSocket handles WebSocket connection requests
@@ -46,31 +66,34 @@ func HandleWebSocket(db *sql.DB) http.HandlerFunc {
 		}
 
 		// 2. Validate JWT token
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
-			log.Printf("❌ WebSocket: Invalid token: %v", err)
+		if jwtKeys == nil {
+			log.Printf("❌ WebSocket: JWT keys are not configured")
 			http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
 			return
 		}
-
-		// 3. Extract user info from token
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			log.Printf("❌ WebSocket: Invalid token claims")
-			http.Error(w, "Unauthorized: Invalid token claims", http.StatusUnauthorized)
+		// Same checks as the gRPC interceptor: access tokens only, revoked tokens rejected
+		versions, live := tokenVersions, sessions
+		if versions == nil {
+			versions = middleware.NewTokenVersions(db, nil)
+		}
+		if live == nil {
+			live = middleware.NewSessions(db, nil)
+		}
+		claims, err := middleware.ParseAccessToken(r.Context(), jwtKeys, versions, live, tokenStr)
+		if status.Code(err) == codes.Internal {
+			log.Printf("❌ WebSocket: Token check failed: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			return
 		}
-
-		userID, _ := claims["user_id"].(string)
-		if userID == "" {
-			log.Printf("❌ WebSocket: No user_id in token")
-			http.Error(w, "Unauthorized: No user_id", http.StatusUnauthorized)
+		if err != nil {
+			log.Printf("❌ WebSocket: Invalid token: %v", err)
+			http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
 			return
 		}
 
+		// 3. Extract user info from token
+		userID, _ := claims["user_id"].(string)
+
 		// 4. Get shop_id from query params
 		shopID := r.URL.Query().Get("shop_id")
 		if shopID == "" {
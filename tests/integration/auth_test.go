@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"mebellar-backend/internal/grpc/server"
+	"mebellar-backend/pkg/jwtkeys"
+	"mebellar-backend/pkg/otp"
 	"mebellar-backend/pkg/pb"
 	"mebellar-backend/pkg/sms"
 	"mebellar-backend/pkg/testutil"
@@ -23,14 +25,15 @@ func TestIntegration_AuthFlow(t *testing.T) {
 	// Полный flow: Register -> Login -> Refresh Token
 	ctx := context.Background()
 
-	// 1. Регистрация
-	authService := setupAuthService(t, db)
+	// 1. Подтверждение номера и регистрация
+	authService, mockSMS := setupAuthService(t, db)
 
 	registerReq := &pb.RegisterRequest{
-		FullName: "Integration Test User",
-		Phone:    "+998901111111",
-		Password: "testpass123",
-		Role:     "customer",
+		FullName:          "Integration Test User",
+		Phone:             "+998901111111",
+		Password:          "testpass123",
+		Role:              "customer",
+		VerificationToken: verifyPhone(t, authService, mockSMS, "+998901111111"),
 	}
 
 	registerResp, err := authService.Register(ctx, registerReq)
@@ -52,10 +55,14 @@ func TestIntegration_AuthFlow(t *testing.T) {
 	assert.Equal(t, registerResp.User.Id, loginResp.User.Id)
 
 	// 3. Проверка что нельзя зарегистрироваться повторно
-	_, err = authService.Register(ctx, registerReq)
+	_, err = authService.SendOTP(ctx, &pb.SendOTPRequest{Phone: registerReq.Phone})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "зарегистрирован")
 
+	_, err = authService.Register(ctx, registerReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "не подтвержден")
+
 	// 4. Refresh token
 	refreshReq := &pb.RefreshTokenRequest{
 		RefreshToken: loginResp.RefreshToken,
@@ -65,8 +72,12 @@ func TestIntegration_AuthFlow(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, refreshResp.AccessToken)
 	assert.NotEmpty(t, refreshResp.RefreshToken)
-	// Access token должен быть новым
-	assert.NotEqual(t, loginResp.AccessToken, refreshResp.AccessToken)
+	// Refresh token ротируется
+	assert.NotEqual(t, loginResp.RefreshToken, refreshResp.RefreshToken)
+
+	// Старый refresh token больше не принимается
+	_, err = authService.RefreshToken(ctx, refreshReq)
+	require.Error(t, err)
 }
 
 func TestIntegration_MultipleUsers(t *testing.T) {
@@ -74,7 +85,7 @@ func TestIntegration_MultipleUsers(t *testing.T) {
 	defer testutil.CleanupTestDB(t, db)
 
 	ctx := context.Background()
-	authService := setupAuthService(t, db)
+	authService, mockSMS := setupAuthService(t, db)
 
 	// Создание нескольких пользователей
 	users := []struct {
@@ -92,10 +103,11 @@ func TestIntegration_MultipleUsers(t *testing.T) {
 
 	for _, u := range users {
 		req := &pb.RegisterRequest{
-			FullName: u.fullName,
-			Phone:    u.phone,
-			Password: u.password,
-			Role:     u.role,
+			FullName:          u.fullName,
+			Phone:             u.phone,
+			Password:          u.password,
+			Role:              u.role,
+			VerificationToken: verifyPhone(t, authService, mockSMS, u.phone),
 		}
 		resp, err := authService.Register(ctx, req)
 		require.NoError(t, err)
@@ -115,9 +127,24 @@ func TestIntegration_MultipleUsers(t *testing.T) {
 	}
 }
 
-func setupAuthService(t *testing.T, db *sql.DB) *server.AuthServiceServer {
+func setupAuthService(t *testing.T, db *sql.DB) (*server.AuthServiceServer, *sms.MockSMSService) {
 	t.Helper()
 	mockSMS := &sms.MockSMSService{}
 	jwtSecret := []byte("test-secret-key-for-testing-32chars")
-	return server.NewAuthServiceServer(db, jwtSecret, mockSMS)
+	otpStore := otp.NewMemoryStore(jwtSecret, otp.DefaultConfig())
+	jwtKeys, err := jwtkeys.NewKeyRing(jwtkeys.NewHMACKey(jwtSecret))
+	require.NoError(t, err)
+	return server.NewAuthServiceServer(db, jwtKeys, mockSMS, otpStore), mockSMS
+}
+
+func verifyPhone(t *testing.T, authService *server.AuthServiceServer, mockSMS *sms.MockSMSService, phone string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := authService.SendOTP(ctx, &pb.SendOTPRequest{Phone: phone})
+	require.NoError(t, err)
+
+	resp, err := authService.VerifyOTP(ctx, &pb.VerifyOTPRequest{Phone: phone, Code: mockSMS.LastCode})
+	require.NoError(t, err)
+	return resp.VerificationToken
 }